@@ -3,15 +3,19 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	// The runtime image has no zoneinfo; user timezones need the embedded copy.
+	_ "time/tzdata"
 
 	"pr-reviewer-assignment/internal/config"
 	"pr-reviewer-assignment/internal/infrastructure"
 	"pr-reviewer-assignment/internal/logger"
+	"pr-reviewer-assignment/internal/tracing"
 
 	"go.uber.org/zap"
 )
@@ -29,6 +33,18 @@ func main() {
 		}
 	}()
 
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		appLogger.Fatal("failed to init tracing", zap.Error(err))
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			appLogger.Error("failed to flush traces", zap.Error(err))
+		}
+	}()
+
 	app, err := infrastructure.NewApp(cfg, appLogger)
 	if err != nil {
 		appLogger.Fatal("failed to init app", zap.Error(err))
@@ -36,6 +52,7 @@ func main() {
 	defer app.Close()
 
 	server := app.HTTPServer()
+	app.StartWorkers(context.Background())
 
 	go func() {
 		appLogger.Info("HTTP server starting", zap.String("addr", server.Addr))
@@ -44,10 +61,31 @@ func main() {
 		}
 	}()
 
+	grpcServer := app.GRPCServer()
+	if grpcServer != nil {
+		listener, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
+		if err != nil {
+			appLogger.Fatal("failed to listen for gRPC", zap.Error(err))
+		}
+
+		go func() {
+			appLogger.Info("gRPC server starting", zap.String("addr", listener.Addr().String()))
+			if err := grpcServer.Serve(listener); err != nil {
+				appLogger.Fatal("gRPC server stopped unexpectedly", zap.Error(err))
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
+	app.BeginShutdown()
+	if delay := cfg.Health.ShutdownDrainDelay; delay > 0 {
+		appLogger.Info("Readiness withdrawn, draining traffic", zap.Duration("delay", delay))
+		time.Sleep(delay)
+	}
+
 	appLogger.Info("Shutting down HTTP server")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -55,4 +93,19 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		appLogger.Error("Graceful shutdown failed", zap.Error(err))
 	}
+
+	if grpcServer != nil {
+		appLogger.Info("Shutting down gRPC server")
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			grpcServer.Stop()
+		}
+	}
 }
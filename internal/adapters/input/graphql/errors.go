@@ -0,0 +1,90 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/logger"
+	"pr-reviewer-assignment/internal/validation"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"go.uber.org/zap"
+)
+
+const (
+	errorCodeBadRequest = "BAD_REQUEST"
+	errorCodeInternal   = "INTERNAL_ERROR"
+	errorCodeTooDeep    = "QUERY_TOO_DEEP"
+	errorCodeTooCostly  = "QUERY_TOO_COSTLY"
+)
+
+// codedError carries the REST error code under extensions.code.
+type codedError struct {
+	code    string
+	message string
+}
+
+func (e codedError) Error() string {
+	return e.message
+}
+
+func (e codedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func requestError(code, message string) gqlerrors.FormattedError {
+	return gqlerrors.FormattedError{
+		Message:    message,
+		Locations:  []location.SourceLocation{},
+		Extensions: codedError{code: code, message: message}.Extensions(),
+	}
+}
+
+func serviceError(ctx context.Context, fallback *zap.Logger, err error) error {
+	var dErr domainErrors.DomainError
+	if errors.As(err, &dErr) {
+		return codedError{code: string(dErr.Code()), message: dErr.Message()}
+	}
+
+	var fieldErr validation.FieldError
+	if errors.As(err, &fieldErr) {
+		return codedError{code: errorCodeBadRequest, message: fieldErr.Error()}
+	}
+
+	logger.FromContext(ctx, fallback).Error("GraphQL resolver failed", zap.Error(err))
+	return codedError{code: errorCodeInternal, message: "internal server error"}
+}
+
+// withCodes restores extensions.code on errors of deferred results, which
+// the executor reports without the extensions of the original error.
+func withCodes(errs []gqlerrors.FormattedError) []gqlerrors.FormattedError {
+	for i := range errs {
+		if errs[i].Extensions != nil {
+			continue
+		}
+
+		var err error = errs[i]
+		for err != nil {
+			if coded, ok := err.(codedError); ok {
+				errs[i].Extensions = coded.Extensions()
+				break
+			}
+			err = originalError(err)
+		}
+	}
+
+	return errs
+}
+
+func originalError(err error) error {
+	switch e := err.(type) {
+	case gqlerrors.FormattedError:
+		return e.OriginalError()
+	case *gqlerrors.Error:
+		return e.OriginalError
+	default:
+		return nil
+	}
+}
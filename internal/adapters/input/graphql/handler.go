@@ -0,0 +1,133 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"go.uber.org/zap"
+)
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler: every request gets its own loaders, so nothing is cached across
+// requests.
+type Handler struct {
+	schema    graphql.Schema
+	dashboard serviceports.DashboardService
+	limits    Limits
+	logger    *zap.Logger
+}
+
+func NewHandler(
+	dashboard serviceports.DashboardService,
+	stats serviceports.StatsService,
+	limits Limits,
+	logger *zap.Logger,
+) (*Handler, error) {
+	schema, err := newSchema(&resolvers{dashboard: dashboard, stats: stats, logger: logger})
+	if err != nil {
+		return nil, fmt.Errorf("build graphql schema: %w", err)
+	}
+
+	return &Handler{
+		schema:    schema,
+		dashboard: dashboard,
+		limits:    limits,
+		logger:    logger,
+	}, nil
+}
+
+func (h *Handler) Serve(c *gin.Context) {
+	req, ok := h.bind(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	log := logger.FromContext(ctx, h.logger)
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		formatted := gqlerrors.FormatError(err)
+		formatted.Extensions = codedError{code: errorCodeBadRequest}.Extensions()
+		h.reject(c, formatted)
+		return
+	}
+
+	if validation := graphql.ValidateDocument(&h.schema, doc, graphql.SpecifiedRules); !validation.IsValid {
+		for i := range validation.Errors {
+			validation.Errors[i].Extensions = codedError{code: errorCodeBadRequest}.Extensions()
+		}
+		h.reject(c, validation.Errors...)
+		return
+	}
+
+	measured := measure(&h.schema, doc, req.OperationName)
+	if h.limits.MaxDepth > 0 && measured.depth > h.limits.MaxDepth {
+		log.Warn("GraphQL query rejected", zap.Int("depth", measured.depth), zap.Int("max_depth", h.limits.MaxDepth))
+		h.reject(c, requestError(errorCodeTooDeep,
+			fmt.Sprintf("query depth %d exceeds the limit of %d", measured.depth, h.limits.MaxDepth)))
+		return
+	}
+	if h.limits.MaxCost > 0 && measured.cost > h.limits.MaxCost {
+		log.Warn("GraphQL query rejected", zap.Int("cost", measured.cost), zap.Int("max_cost", h.limits.MaxCost))
+		h.reject(c, requestError(errorCodeTooCostly,
+			fmt.Sprintf("query cost %d exceeds the limit of %d", measured.cost, h.limits.MaxCost)))
+		return
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx, newLoaders(h.dashboard)),
+	})
+	result.Errors = withCodes(result.Errors)
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) bind(c *gin.Context) (request, bool) {
+	var req request
+
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if raw := c.Query("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+				h.reject(c, requestError(errorCodeBadRequest, "variables must be a JSON object"))
+				return req, false
+			}
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		h.reject(c, requestError(errorCodeBadRequest, "invalid request body"))
+		return req, false
+	}
+
+	if req.Query == "" {
+		h.reject(c, requestError(errorCodeBadRequest, "query is required"))
+		return req, false
+	}
+
+	return req, true
+}
+
+func (h *Handler) reject(c *gin.Context, errs ...gqlerrors.FormattedError) {
+	c.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+}
@@ -0,0 +1,146 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// listCostFactor is how many elements a list field is assumed to return:
+// every selection below a list is paid for once per element.
+const listCostFactor = 10
+
+// Limits reject a query before it runs. Depth counts nested fields; cost
+// counts one per field, multiplied by listCostFactor below each list. Zero
+// disables a limit. Introspection fields are free.
+type Limits struct {
+	MaxDepth int
+	MaxCost  int
+}
+
+type complexity struct {
+	depth int
+	cost  int
+}
+
+// measure expects a validated document, so fragments do not form cycles.
+func measure(schema *graphql.Schema, doc *ast.Document, operationName string) complexity {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	var operation *ast.OperationDefinition
+
+	for _, definition := range doc.Definitions {
+		switch def := definition.(type) {
+		case *ast.FragmentDefinition:
+			fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operation == nil && (operationName == "" || (def.Name != nil && def.Name.Value == operationName)) {
+				operation = def
+			}
+		}
+	}
+
+	if operation == nil || operation.Operation != ast.OperationTypeQuery {
+		return complexity{}
+	}
+
+	m := &measurer{schema: schema, fragments: fragments, measured: make(map[string]complexity)}
+	return m.selections(operation.SelectionSet, schema.QueryType())
+}
+
+type measurer struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	// measured remembers fragments, so spreading one many times does not
+	// walk it again.
+	measured map[string]complexity
+}
+
+type fieldsOwner interface {
+	Fields() graphql.FieldDefinitionMap
+}
+
+func (m *measurer) selections(set *ast.SelectionSet, parent graphql.Type) complexity {
+	var total complexity
+	if set == nil {
+		return total
+	}
+
+	add := func(c complexity) {
+		total.depth = max(total.depth, c.depth)
+		total.cost += c.cost
+	}
+
+	for _, selection := range set.Selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			add(m.field(sel, parent))
+		case *ast.InlineFragment:
+			typ := parent
+			if sel.TypeCondition != nil {
+				typ = m.schema.Type(sel.TypeCondition.Name.Value)
+			}
+			add(m.selections(sel.SelectionSet, typ))
+		case *ast.FragmentSpread:
+			add(m.fragment(sel.Name.Value))
+		}
+	}
+
+	return total
+}
+
+func (m *measurer) field(field *ast.Field, parent graphql.Type) complexity {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return complexity{}
+	}
+
+	owner, ok := parent.(fieldsOwner)
+	if !ok {
+		return complexity{depth: 1, cost: 1}
+	}
+
+	definition := owner.Fields()[field.Name.Value]
+	if definition == nil {
+		return complexity{depth: 1, cost: 1}
+	}
+
+	typ, isList := unwrap(definition.Type)
+	children := m.selections(field.SelectionSet, typ)
+
+	factor := 1
+	if isList {
+		factor = listCostFactor
+	}
+
+	return complexity{depth: children.depth + 1, cost: 1 + factor*children.cost}
+}
+
+func (m *measurer) fragment(name string) complexity {
+	if c, ok := m.measured[name]; ok {
+		return c
+	}
+
+	fragment, ok := m.fragments[name]
+	if !ok {
+		return complexity{}
+	}
+
+	c := m.selections(fragment.SelectionSet, m.schema.Type(fragment.TypeCondition.Name.Value))
+	m.measured[name] = c
+	return c
+}
+
+func unwrap(typ graphql.Type) (graphql.Type, bool) {
+	isList := false
+	for {
+		switch t := typ.(type) {
+		case *graphql.NonNull:
+			typ = t.OfType
+		case *graphql.List:
+			isList = true
+			typ = t.OfType
+		default:
+			return typ, isList
+		}
+	}
+}
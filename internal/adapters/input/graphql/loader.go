@@ -0,0 +1,99 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+)
+
+type loaded[V any] struct {
+	value V
+	found bool
+	err   error
+}
+
+// loader batches lookups by key. The executor calls every resolver of a
+// level of the query before it asks for any of their results, so resolvers
+// only queue keys and the first result requested fetches all queued keys
+// with one call. Results are kept for the rest of the request.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]struct{}
+	results map[K]loaded[V]
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:   fetch,
+		queued:  make(map[K]struct{}),
+		results: make(map[K]loaded[V]),
+	}
+}
+
+func (l *loader[K, V]) load(ctx context.Context, key K) func() (V, bool, error) {
+	l.mu.Lock()
+	if _, done := l.results[key]; !done {
+		if _, ok := l.queued[key]; !ok {
+			l.queued[key] = struct{}{}
+			l.pending = append(l.pending, key)
+		}
+	}
+	l.mu.Unlock()
+
+	return func() (V, bool, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if _, done := l.results[key]; !done {
+			l.flush(ctx)
+		}
+
+		result := l.results[key]
+		return result.value, result.found, result.err
+	}
+}
+
+// flush runs with l.mu held.
+func (l *loader[K, V]) flush(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+	clear(l.queued)
+
+	values, err := l.fetch(ctx, keys)
+	for _, key := range keys {
+		value, found := values[key]
+		l.results[key] = loaded[V]{value: value, found: found && err == nil, err: err}
+	}
+}
+
+type loaders struct {
+	teams        *loader[string, *entities.Team]
+	users        *loader[string, *entities.User]
+	pullRequests *loader[string, *entities.PullRequest]
+	reviews      *loader[string, []*entities.PullRequest]
+}
+
+func newLoaders(service serviceports.DashboardService) *loaders {
+	return &loaders{
+		teams:        newLoader(service.TeamsByName),
+		users:        newLoader(service.UsersByID),
+		pullRequests: newLoader(service.PullRequestsByID),
+		reviews:      newLoader(service.ReviewsByUser),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	l, _ := ctx.Value(loadersKey{}).(*loaders)
+	return l
+}
@@ -0,0 +1,279 @@
+package graphql
+
+import (
+	"context"
+	"sort"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+
+	"github.com/graphql-go/graphql"
+	"go.uber.org/zap"
+)
+
+type resolvers struct {
+	dashboard serviceports.DashboardService
+	stats     serviceports.StatsService
+	logger    *zap.Logger
+}
+
+func newSchema(r *resolvers) (graphql.Schema, error) {
+	status := graphql.NewEnum(graphql.EnumConfig{
+		Name: "PullRequestStatus",
+		Values: graphql.EnumValueConfigMap{
+			string(types.PRStatusOpen):   &graphql.EnumValueConfig{Value: types.PRStatusOpen},
+			string(types.PRStatusMerged): &graphql.EnumValueConfig{Value: types.PRStatusMerged},
+			string(types.PRStatusClosed): &graphql.EnumValueConfig{Value: types.PRStatusClosed},
+		},
+	})
+
+	id := graphql.NewNonNull(graphql.ID)
+	text := graphql.NewNonNull(graphql.String)
+
+	team := graphql.NewObject(graphql.ObjectConfig{Name: "Team", Fields: graphql.Fields{}})
+	user := graphql.NewObject(graphql.ObjectConfig{Name: "User", Fields: graphql.Fields{}})
+	pullRequest := graphql.NewObject(graphql.ObjectConfig{Name: "PullRequest", Fields: graphql.Fields{}})
+
+	team.AddFieldConfig("name", field(text, func(v *entities.Team) interface{} { return v.Name }))
+	team.AddFieldConfig("members", &graphql.Field{
+		Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(user))),
+		Resolve: r.teamMembers,
+	})
+
+	user.AddFieldConfig("id", field(id, func(v *entities.User) interface{} { return v.ID }))
+	user.AddFieldConfig("username", field(text, func(v *entities.User) interface{} { return v.Username }))
+	user.AddFieldConfig("teamName", field(text, func(v *entities.User) interface{} { return v.TeamName }))
+	user.AddFieldConfig("isActive", field(graphql.NewNonNull(graphql.Boolean), func(v *entities.User) interface{} { return v.IsActive }))
+	user.AddFieldConfig("team", &graphql.Field{
+		Type: team,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return r.loadTeam(p.Context, p.Source.(*entities.User).TeamName), nil
+		},
+	})
+	user.AddFieldConfig("reviews", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(pullRequest))),
+		Description: "Pull requests the user is assigned to review, newest first.",
+		Args: graphql.FieldConfigArgument{
+			"status": &graphql.ArgumentConfig{Type: status},
+		},
+		Resolve: r.userReviews,
+	})
+
+	pullRequest.AddFieldConfig("id", field(id, func(v *entities.PullRequest) interface{} { return v.ID }))
+	pullRequest.AddFieldConfig("name", field(text, func(v *entities.PullRequest) interface{} { return v.Name }))
+	pullRequest.AddFieldConfig("status", field(graphql.NewNonNull(status), func(v *entities.PullRequest) interface{} { return v.Status }))
+	pullRequest.AddFieldConfig("createdAt", field(graphql.NewNonNull(graphql.DateTime), func(v *entities.PullRequest) interface{} { return v.CreatedAt.UTC() }))
+	pullRequest.AddFieldConfig("mergedAt", &graphql.Field{
+		Type: graphql.DateTime,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if mergedAt := p.Source.(*entities.PullRequest).MergedAt; mergedAt != nil {
+				return mergedAt.UTC(), nil
+			}
+			return nil, nil
+		},
+	})
+	pullRequest.AddFieldConfig("version", field(graphql.NewNonNull(graphql.Int), func(v *entities.PullRequest) interface{} { return v.Version }))
+	pullRequest.AddFieldConfig("author", &graphql.Field{
+		Type: user,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return r.loadUser(p.Context, p.Source.(*entities.PullRequest).AuthorID), nil
+		},
+	})
+	pullRequest.AddFieldConfig("reviewers", &graphql.Field{
+		Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(user))),
+		Resolve: r.pullRequestReviewers,
+	})
+
+	count := graphql.NewNonNull(graphql.Int)
+	stats := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Stats",
+		Fields: graphql.Fields{
+			"teams":        field(count, func(s *entities.Stats) interface{} { return s.Teams }),
+			"users":        field(count, func(s *entities.Stats) interface{} { return s.Users }),
+			"pullRequests": field(count, func(s *entities.Stats) interface{} { return s.PullRequests }),
+			"assignments":  field(count, func(s *entities.Stats) interface{} { return s.Assignments }),
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"team": &graphql.Field{
+				Type: team,
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: text},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return r.loadTeam(p.Context, p.Args["name"].(string)), nil
+				},
+			},
+			"teams": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(team))),
+				Resolve: r.teams,
+			},
+			"user": &graphql.Field{
+				Type: user,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: id},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return r.loadUser(p.Context, p.Args["id"].(string)), nil
+				},
+			},
+			"pullRequest": &graphql.Field{
+				Type: pullRequest,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: id},
+				},
+				Resolve: r.pullRequest,
+			},
+			"stats": &graphql.Field{
+				Type:    graphql.NewNonNull(stats),
+				Resolve: r.statsSummary,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+func field[T any](typ graphql.Output, value func(T) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return value(p.Source.(T)), nil
+		},
+	}
+}
+
+// loadTeam and the other load helpers resolve a missing record to null rather
+// than an error.
+func (r *resolvers) loadTeam(ctx context.Context, name string) func() (interface{}, error) {
+	wait := loadersFrom(ctx).teams.load(ctx, name)
+	return func() (interface{}, error) {
+		team, found, err := wait()
+		if err != nil || !found {
+			return nil, r.failure(ctx, err)
+		}
+		return team, nil
+	}
+}
+
+func (r *resolvers) loadUser(ctx context.Context, id string) func() (interface{}, error) {
+	wait := loadersFrom(ctx).users.load(ctx, id)
+	return func() (interface{}, error) {
+		user, found, err := wait()
+		if err != nil || !found {
+			return nil, r.failure(ctx, err)
+		}
+		return user, nil
+	}
+}
+
+func (r *resolvers) teams(p graphql.ResolveParams) (interface{}, error) {
+	names, err := r.dashboard.ListTeamNames(p.Context)
+	if err != nil {
+		return nil, r.failure(p.Context, err)
+	}
+
+	waits := make([]func() (interface{}, error), 0, len(names))
+	for _, name := range names {
+		waits = append(waits, r.loadTeam(p.Context, name))
+	}
+
+	return collect(waits), nil
+}
+
+func (r *resolvers) teamMembers(p graphql.ResolveParams) (interface{}, error) {
+	team := p.Source.(*entities.Team)
+
+	members := make([]*entities.User, 0, len(team.Members))
+	for _, member := range team.Members {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].Username != members[j].Username {
+			return members[i].Username < members[j].Username
+		}
+		return members[i].ID < members[j].ID
+	})
+
+	return members, nil
+}
+
+func (r *resolvers) userReviews(p graphql.ResolveParams) (interface{}, error) {
+	wait := loadersFrom(p.Context).reviews.load(p.Context, p.Source.(*entities.User).ID)
+	status, filtered := p.Args["status"].(types.PRStatus)
+
+	return func() (interface{}, error) {
+		prs, _, err := wait()
+		if err != nil {
+			return nil, r.failure(p.Context, err)
+		}
+
+		reviews := make([]*entities.PullRequest, 0, len(prs))
+		for _, pr := range prs {
+			if !filtered || pr.Status == status {
+				reviews = append(reviews, pr)
+			}
+		}
+		return reviews, nil
+	}, nil
+}
+
+func (r *resolvers) pullRequest(p graphql.ResolveParams) (interface{}, error) {
+	wait := loadersFrom(p.Context).pullRequests.load(p.Context, p.Args["id"].(string))
+
+	return func() (interface{}, error) {
+		pr, found, err := wait()
+		if err != nil || !found {
+			return nil, r.failure(p.Context, err)
+		}
+		return pr, nil
+	}, nil
+}
+
+func (r *resolvers) pullRequestReviewers(p graphql.ResolveParams) (interface{}, error) {
+	reviewers := p.Source.(*entities.PullRequest).AssignedReviewers
+
+	waits := make([]func() (interface{}, error), 0, len(reviewers))
+	for _, id := range reviewers {
+		waits = append(waits, r.loadUser(p.Context, id))
+	}
+
+	return collect(waits), nil
+}
+
+func (r *resolvers) statsSummary(p graphql.ResolveParams) (interface{}, error) {
+	stats, err := r.stats.GetStats(p.Context)
+	if err != nil {
+		return nil, r.failure(p.Context, err)
+	}
+
+	return stats, nil
+}
+
+func collect(waits []func() (interface{}, error)) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		items := make([]interface{}, 0, len(waits))
+		for _, wait := range waits {
+			item, err := wait()
+			if err != nil {
+				return nil, err
+			}
+			if item != nil {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	}
+}
+
+func (r *resolvers) failure(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return serviceError(ctx, r.logger, err)
+}
@@ -0,0 +1,174 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	reviewerv1 "pr-reviewer-assignment/api/proto/reviewer/v1"
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/core/identity"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/logger"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+const bearerPrefix = "Bearer "
+
+type methodRule struct {
+	scope types.Scope
+	route string
+}
+
+// methodRules gives each RPC the scope of the REST route it mirrors; the
+// route also picks its rate limit.
+var methodRules = map[string]methodRule{
+	reviewerv1.TeamService_CreateTeam_FullMethodName:       {types.ScopeAdminTeams, "POST /team/add"},
+	reviewerv1.TeamService_GetTeam_FullMethodName:          {types.ScopeRead, "GET /team/get"},
+	reviewerv1.TeamService_SetMemberRole_FullMethodName:    {types.ScopeAdminTeams, "POST /team/setRole"},
+	reviewerv1.TeamService_RemoveMemberRole_FullMethodName: {types.ScopeAdminTeams, "POST /team/removeRole"},
+	reviewerv1.TeamService_ListMemberRoles_FullMethodName:  {types.ScopeRead, "GET /team/roles"},
+
+	reviewerv1.UserService_SetIsActive_FullMethodName:    {types.ScopeAdminTeams, "POST /users/setIsActive"},
+	reviewerv1.UserService_SetPreferences_FullMethodName: {types.ScopeWritePR, "POST /users/setPreferences"},
+	reviewerv1.UserService_GetReview_FullMethodName:      {types.ScopeRead, "GET /users/getReview"},
+
+	reviewerv1.PullRequestService_CreatePullRequest_FullMethodName: {types.ScopeWritePR, "POST /pullRequest/create"},
+	reviewerv1.PullRequestService_MergePullRequest_FullMethodName:  {types.ScopeWritePR, "POST /pullRequest/merge"},
+	reviewerv1.PullRequestService_ReassignReviewer_FullMethodName:  {types.ScopeWritePR, "POST /pullRequest/reassign"},
+
+	reviewerv1.StatsService_GetStats_FullMethodName:        {types.ScopeRead, "GET /stats"},
+	reviewerv1.StatsService_GetStatsHistory_FullMethodName: {types.ScopeRead, "GET /stats/history"},
+	reviewerv1.StatsService_GetFairness_FullMethodName:     {types.ScopeRead, "GET /stats/fairness"},
+}
+
+// publicServices need no token, like /health on the REST port.
+var publicServices = []string{
+	"/" + grpc_health_v1.Health_ServiceDesc.ServiceName + "/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+func isPublic(fullMethod string) bool {
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenVerifier validates bearer tokens issued by an external IdP.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*entities.Principal, error)
+}
+
+// Authenticator checks the bearer token in the authorization metadata the
+// same way the REST API checks the Authorization header. A nil or disabled
+// Authenticator lets every call through.
+type Authenticator struct {
+	service  serviceports.APIKeyService
+	verifier TokenVerifier
+	enabled  bool
+	logger   *zap.Logger
+}
+
+func NewAuthenticator(service serviceports.APIKeyService, verifier TokenVerifier, enabled bool, logger *zap.Logger) *Authenticator {
+	return &Authenticator{
+		service:  service,
+		verifier: verifier,
+		enabled:  enabled,
+		logger:   logger,
+	}
+}
+
+// UnaryInterceptor rejects calls without the scope of their method. Methods
+// missing from methodRules are denied unless their service is public.
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if a == nil || !a.enabled || isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor applies the same rules to streaming calls; the API has
+// none, so only public services stream.
+func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if a == nil || !a.enabled || isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		if _, err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func (a *Authenticator) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	rule, ok := methodRules[fullMethod]
+	if !ok {
+		loggerFor(ctx, a.logger).Warn("Call to a method without a scope", zap.String("method", fullMethod))
+		return nil, errorStatus(codes.PermissionDenied, string(domainErrors.ErrorCodeForbidden), "method is not available")
+	}
+
+	token, ok := bearerToken(metadata.ValueFromIncomingContext(ctx, "authorization"))
+	if !ok {
+		return nil, errorStatus(codes.Unauthenticated, string(domainErrors.ErrorCodeUnauthorized), "missing bearer token")
+	}
+
+	principal, err := a.authenticate(ctx, token)
+	if err != nil {
+		return nil, serviceError(ctx, a.logger, err)
+	}
+
+	if !principal.HasScope(rule.scope) {
+		loggerFor(ctx, a.logger).Info("Call lacks required scope",
+			zap.String("subject", principal.Subject),
+			zap.String("scope", rule.scope.String()),
+		)
+		return nil, errorStatus(codes.PermissionDenied, string(domainErrors.ErrorCodeForbidden), "missing scope "+rule.scope.String())
+	}
+
+	ctx = identity.ContextWithPrincipal(ctx, principal)
+	ctx = logger.ContextWithLogger(ctx, loggerFor(ctx, a.logger).With(zap.String("principal", principal.Subject)))
+	return ctx, nil
+}
+
+func (a *Authenticator) authenticate(ctx context.Context, token string) (*entities.Principal, error) {
+	if a.verifier != nil && strings.Count(token, ".") == 2 {
+		return a.verifier.Verify(ctx, token)
+	}
+
+	return a.service.Authenticate(ctx, token)
+}
+
+func bearerToken(values []string) (string, bool) {
+	if len(values) == 0 {
+		return "", false
+	}
+
+	header := values[0]
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(header[len(bearerPrefix):])
+	return token, token != ""
+}
@@ -0,0 +1,201 @@
+package grpc
+
+import (
+	"time"
+
+	reviewerv1 "pr-reviewer-assignment/api/proto/reviewer/v1"
+	"pr-reviewer-assignment/internal/core/domain/entities"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func teamToProto(team *entities.Team) *reviewerv1.Team {
+	if team == nil {
+		return nil
+	}
+
+	members := make([]*reviewerv1.TeamMember, 0, len(team.Members))
+	for _, member := range team.Members {
+		if member == nil {
+			continue
+		}
+
+		members = append(members, &reviewerv1.TeamMember{
+			UserId:   member.ID,
+			Username: member.Username,
+			IsActive: member.IsActive,
+		})
+	}
+
+	return &reviewerv1.Team{TeamName: team.Name, Members: members}
+}
+
+func teamMembersFromProto(teamName string, members []*reviewerv1.TeamMember) []*entities.User {
+	if len(members) == 0 {
+		return nil
+	}
+
+	result := make([]*entities.User, 0, len(members))
+	for _, member := range members {
+		result = append(result, entities.NewUser(member.GetUserId(), member.GetUsername(), teamName, member.GetIsActive(), time.Time{}, time.Time{}))
+	}
+
+	return result
+}
+
+func teamRoleToProto(role *entities.TeamRole) *reviewerv1.TeamRole {
+	return &reviewerv1.TeamRole{
+		TeamName: role.TeamName,
+		UserId:   role.UserID,
+		Role:     role.Role.String(),
+	}
+}
+
+func userToProto(user *entities.User) *reviewerv1.User {
+	if user == nil {
+		return nil
+	}
+
+	return &reviewerv1.User{
+		UserId:        user.ID,
+		Username:      user.Username,
+		TeamName:      user.TeamName,
+		IsActive:      user.IsActive,
+		Email:         user.Email,
+		Timezone:      user.Timezone,
+		DigestEnabled: user.DigestEnabled,
+	}
+}
+
+func pullRequestToProto(pr *entities.PullRequest) *reviewerv1.PullRequest {
+	if pr == nil {
+		return nil
+	}
+
+	result := &reviewerv1.PullRequest{
+		PullRequestId:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID,
+		Status:            pr.Status.String(),
+		AssignedReviewers: append([]string(nil), pr.AssignedReviewers...),
+		MergedAt:          optionalTimestamp(pr.MergedAt),
+		Version:           int32(pr.Version),
+	}
+	if !pr.CreatedAt.IsZero() {
+		result.CreatedAt = timestamppb.New(pr.CreatedAt)
+	}
+
+	return result
+}
+
+func pullRequestsToShortProto(prs []*entities.PullRequest) []*reviewerv1.PullRequestShort {
+	result := make([]*reviewerv1.PullRequestShort, 0, len(prs))
+	for _, pr := range prs {
+		if pr == nil {
+			continue
+		}
+
+		result = append(result, &reviewerv1.PullRequestShort{
+			PullRequestId:   pr.ID,
+			PullRequestName: pr.Name,
+			AuthorId:        pr.AuthorID,
+			Status:          pr.Status.String(),
+		})
+	}
+
+	return result
+}
+
+func statsToProto(stats *entities.Stats) *reviewerv1.Stats {
+	if stats == nil {
+		return &reviewerv1.Stats{}
+	}
+
+	return &reviewerv1.Stats{
+		Teams:        int32(stats.Teams),
+		Users:        int32(stats.Users),
+		PullRequests: int32(stats.PullRequests),
+		Assignments:  int32(stats.Assignments),
+	}
+}
+
+func statsHistoryToProto(history *entities.StatsHistory) *reviewerv1.GetStatsHistoryResponse {
+	if history == nil {
+		return &reviewerv1.GetStatsHistoryResponse{}
+	}
+
+	query := history.Query
+	points := make([]*reviewerv1.StatsPoint, 0, len(history.Points))
+	for _, point := range history.Points {
+		points = append(points, &reviewerv1.StatsPoint{
+			Bucket: timestamppb.New(point.Bucket),
+			Last:   int32(point.Last),
+			Min:    int32(point.Min),
+			Max:    int32(point.Max),
+		})
+	}
+
+	return &reviewerv1.GetStatsHistoryResponse{
+		Metric:   query.Metric.String(),
+		TeamName: query.TeamName,
+		Bucket:   query.Bucket.String(),
+		From:     timestamppb.New(query.From),
+		To:       timestamppb.New(query.To),
+		Points:   points,
+	}
+}
+
+func fairnessToProto(report *entities.FairnessReport) *reviewerv1.FairnessReport {
+	if report == nil {
+		return &reviewerv1.FairnessReport{}
+	}
+
+	return &reviewerv1.FairnessReport{
+		TeamName:         report.TeamName,
+		From:             optionalTimestamp(report.From),
+		To:               optionalTimestamp(report.To),
+		TotalAssignments: int32(report.TotalAssignments),
+		IdealShare:       report.IdealShare,
+		Gini:             report.Gini,
+		Members:          memberSharesToProto(report.Members),
+		LargestDeviation: memberSharesToProto(report.LargestDeviation),
+	}
+}
+
+func memberSharesToProto(shares []entities.MemberShare) []*reviewerv1.MemberShare {
+	result := make([]*reviewerv1.MemberShare, 0, len(shares))
+	for _, share := range shares {
+		result = append(result, &reviewerv1.MemberShare{
+			UserId:      share.UserID,
+			Username:    share.Username,
+			Assignments: int32(share.Assignments),
+			Share:       share.Share,
+			Deviation:   share.Deviation,
+		})
+	}
+
+	return result
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+// timeFromProto treats an unset timestamp as absent, like an omitted query
+// parameter.
+func timeFromProto(ts *timestamppb.Timestamp) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+
+	if err := ts.CheckValid(); err != nil {
+		return nil, err
+	}
+
+	t := ts.AsTime().UTC()
+	return &t, nil
+}
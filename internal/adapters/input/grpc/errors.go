@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/logger"
+	"pr-reviewer-assignment/internal/validation"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	errorCodeBadRequest = "BAD_REQUEST"
+	errorCodeInternal   = "INTERNAL_ERROR"
+	errorDomain         = "pr-reviewer-assignment"
+)
+
+// errorStatus builds a status whose ErrorInfo reason carries the same code
+// the REST API puts in error.code.
+func errorStatus(code codes.Code, reason, message string) error {
+	st := status.New(code, message)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}); err == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
+
+func invalidArgument(message string) error {
+	return errorStatus(codes.InvalidArgument, errorCodeBadRequest, message)
+}
+
+func loggerFor(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	return logger.FromContext(ctx, fallback)
+}
+
+// serviceError translates service errors the way the HTTP adapter's
+// handleServiceError does, with the gRPC code closest to its HTTP status.
+func serviceError(ctx context.Context, fallback *zap.Logger, err error) error {
+	log := loggerFor(ctx, fallback)
+
+	var dErr domainErrors.DomainError
+	if errors.As(err, &dErr) {
+		reason := string(dErr.Code())
+
+		switch dErr.Code() {
+		case domainErrors.ErrorCodeTeamExists,
+			domainErrors.ErrorCodePRExists:
+			return errorStatus(codes.AlreadyExists, reason, dErr.Message())
+		case domainErrors.ErrorCodePRMerged,
+			domainErrors.ErrorCodePRClosed,
+			domainErrors.ErrorCodeNotAssigned,
+			domainErrors.ErrorCodeNoCandidate:
+			return errorStatus(codes.FailedPrecondition, reason, dErr.Message())
+		case domainErrors.ErrorCodeConflict:
+			return errorStatus(codes.Aborted, reason, dErr.Message())
+		case domainErrors.ErrorCodeNotFound:
+			return errorStatus(codes.NotFound, reason, dErr.Message())
+		case domainErrors.ErrorCodeUnauthorized:
+			return errorStatus(codes.Unauthenticated, reason, dErr.Message())
+		case domainErrors.ErrorCodeForbidden:
+			return errorStatus(codes.PermissionDenied, reason, dErr.Message())
+		default:
+			log.Warn("Unhandled domain error", zap.String("code", reason), zap.String("message", dErr.Message()))
+			return errorStatus(codes.Internal, errorCodeInternal, "internal server error")
+		}
+	}
+
+	var fieldErr validation.FieldError
+	if errors.As(err, &fieldErr) {
+		return invalidArgument(fieldErr.Error())
+	}
+
+	log.Error("Service error", zap.Error(err))
+	return errorStatus(codes.Internal, errorCodeInternal, "internal server error")
+}
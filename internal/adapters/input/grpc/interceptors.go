@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"pr-reviewer-assignment/internal/logger"
+	"pr-reviewer-assignment/internal/requestid"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestContext mirrors the HTTP request ID and logging middleware: it
+// accepts a well-formed x-request-id or generates one, returns it in the
+// response header and logs every call with its outcome.
+func requestContext(base *zap.Logger) grpc.UnaryServerInterceptor {
+	header := strings.ToLower(requestid.Header)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		id := ""
+		if values := metadata.ValueFromIncomingContext(ctx, header); len(values) > 0 {
+			id = values[0]
+		}
+		if !requestid.IsValid(id) {
+			id = requestid.New()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(header, id))
+
+		ctx = requestid.ContextWithRequestID(ctx, id)
+		log := logger.FromContext(ctx, base).With(zap.String("request_id", id))
+		ctx = logger.ContextWithLogger(ctx, log)
+
+		resp, err := handler(ctx, req)
+
+		log.Info("grpc request",
+			zap.String("method", info.FullMethod),
+			zap.String("code", status.Code(err).String()),
+			zap.Duration("duration", time.Since(start)),
+		)
+
+		return resp, err
+	}
+}
+
+// recoverPanics turns a panicking handler into an Internal error instead of
+// taking the whole process down, as gin.Recovery does for HTTP.
+func recoverPanics(base *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				loggerFor(ctx, base).Error("Panic in grpc handler",
+					zap.String("method", info.FullMethod),
+					zap.Any("panic", r),
+					zap.ByteString("stack", debug.Stack()),
+				)
+				err = errorStatus(codes.Internal, errorCodeInternal, "internal server error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	reviewerv1 "pr-reviewer-assignment/api/proto/reviewer/v1"
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+
+	"go.uber.org/zap"
+)
+
+type PullRequestServer struct {
+	reviewerv1.UnimplementedPullRequestServiceServer
+
+	service serviceports.PullRequestService
+	logger  *zap.Logger
+}
+
+func NewPullRequestServer(service serviceports.PullRequestService, logger *zap.Logger) *PullRequestServer {
+	return &PullRequestServer{service: service, logger: logger}
+}
+
+func (s *PullRequestServer) CreatePullRequest(ctx context.Context, req *reviewerv1.CreatePullRequestRequest) (*reviewerv1.CreatePullRequestResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetPullRequestName() == "" || req.GetAuthorId() == "" {
+		return nil, invalidArgument("pull_request_id, pull_request_name and author_id are required")
+	}
+
+	pr := entities.NewPullRequest(req.GetPullRequestId(), req.GetPullRequestName(), req.GetAuthorId(), time.Time{})
+	created, err := s.service.CreatePullRequest(ctx, pr)
+	if err != nil {
+		loggerFor(ctx, s.logger).Warn("Create PR failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	return &reviewerv1.CreatePullRequestResponse{Pr: pullRequestToProto(created)}, nil
+}
+
+func (s *PullRequestServer) MergePullRequest(ctx context.Context, req *reviewerv1.MergePullRequestRequest) (*reviewerv1.MergePullRequestResponse, error) {
+	if strings.TrimSpace(req.GetPullRequestId()) == "" {
+		return nil, invalidArgument("pull_request_id is required")
+	}
+	if req.GetExpectedVersion() < 0 {
+		return nil, invalidArgument("expected_version must not be negative")
+	}
+
+	pr, err := s.service.MergePullRequest(ctx, req.GetPullRequestId(), int(req.GetExpectedVersion()))
+	if err != nil {
+		loggerFor(ctx, s.logger).Warn("Merge PR failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	return &reviewerv1.MergePullRequestResponse{Pr: pullRequestToProto(pr)}, nil
+}
+
+func (s *PullRequestServer) ReassignReviewer(ctx context.Context, req *reviewerv1.ReassignReviewerRequest) (*reviewerv1.ReassignReviewerResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetOldUserId() == "" {
+		return nil, invalidArgument("pull_request_id and old_user_id are required")
+	}
+	if req.GetExpectedVersion() < 0 {
+		return nil, invalidArgument("expected_version must not be negative")
+	}
+
+	pr, replacedBy, err := s.service.ReassignReviewer(ctx, req.GetPullRequestId(), req.GetOldUserId(), int(req.GetExpectedVersion()))
+	if err != nil {
+		loggerFor(ctx, s.logger).Warn("Reassign reviewer failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	return &reviewerv1.ReassignReviewerResponse{Pr: pullRequestToProto(pr), ReplacedBy: replacedBy}, nil
+}
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+
+	"pr-reviewer-assignment/internal/core/identity"
+	"pr-reviewer-assignment/internal/ratelimit"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const errorCodeRateLimited = "RATE_LIMITED"
+
+// RateLimiter applies the REST rate limits to RPCs. Buckets are keyed the same
+// way as in the HTTP middleware, so with a shared store a client has one
+// budget across both ports.
+type RateLimiter struct {
+	store  ratelimit.Store
+	rules  *ratelimit.Rules
+	logger *zap.Logger
+}
+
+func NewRateLimiter(store ratelimit.Store, rules *ratelimit.Rules, logger *zap.Logger) *RateLimiter {
+	return &RateLimiter{
+		store:  store,
+		rules:  rules,
+		logger: logger,
+	}
+}
+
+// PerIPInterceptor runs before authentication, like the HTTP PerIP middleware.
+func (l *RateLimiter) PerIPInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if l == nil || l.rules.PerIP == nil || isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		if err := l.allow(ctx, "ip|"+peerIP(ctx), *l.rules.PerIP); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// UnaryInterceptor runs after authentication and limits a call by the rule
+// of the REST route its method mirrors.
+func (l *RateLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		rule, ok := methodRules[info.FullMethod]
+		if l == nil || !ok {
+			return handler(ctx, req)
+		}
+
+		limit, scope, ok := l.rules.For(rule.route)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		client := "ip:" + peerIP(ctx)
+		if principal := identity.PrincipalFromContext(ctx); principal != nil {
+			client = principal.Subject
+		}
+
+		if err := l.allow(ctx, scope+"|"+client, limit); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (l *RateLimiter) allow(ctx context.Context, key string, limit ratelimit.Limit) error {
+	decision, err := l.store.Allow(ctx, key, limit)
+	if err != nil {
+		loggerFor(ctx, l.logger).Warn("Rate limit store failed", zap.Error(err))
+		return nil
+	}
+
+	if !decision.Allowed {
+		seconds := int(math.Ceil(decision.RetryAfter.Seconds()))
+		_ = grpc.SetTrailer(ctx, metadata.Pairs("retry-after", strconv.Itoa(max(seconds, 1))))
+		return errorStatus(codes.ResourceExhausted, errorCodeRateLimited, "rate limit exceeded")
+	}
+
+	return nil
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	reviewerv1 "pr-reviewer-assignment/api/proto/reviewer/v1"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+type ServerDeps struct {
+	Logger  *zap.Logger
+	Auth    *Authenticator
+	Limiter *RateLimiter
+
+	Team        serviceports.TeamService
+	User        serviceports.UserService
+	PullRequest serviceports.PullRequestService
+	Stats       serviceports.StatsService
+}
+
+// NewServer registers the API services backed by the same service ports as
+// the REST handlers. Reflection is enabled so tools like grpcurl can discover
+// the API without the proto file.
+func NewServer(deps ServerDeps) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			recoverPanics(deps.Logger),
+			requestContext(deps.Logger),
+			deps.Limiter.PerIPInterceptor(),
+			deps.Auth.UnaryInterceptor(),
+			deps.Limiter.UnaryInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(deps.Auth.StreamInterceptor()),
+	)
+
+	if deps.Team != nil {
+		reviewerv1.RegisterTeamServiceServer(server, NewTeamServer(deps.Team, deps.Logger))
+	}
+	if deps.User != nil {
+		reviewerv1.RegisterUserServiceServer(server, NewUserServer(deps.User, deps.Logger))
+	}
+	if deps.PullRequest != nil {
+		reviewerv1.RegisterPullRequestServiceServer(server, NewPullRequestServer(deps.PullRequest, deps.Logger))
+	}
+	if deps.Stats != nil {
+		reviewerv1.RegisterStatsServiceServer(server, NewStatsServer(deps.Stats, deps.Logger))
+	}
+
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	reflection.Register(server)
+
+	return server
+}
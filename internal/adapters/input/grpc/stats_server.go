@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	reviewerv1 "pr-reviewer-assignment/api/proto/reviewer/v1"
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+
+	"go.uber.org/zap"
+)
+
+type StatsServer struct {
+	reviewerv1.UnimplementedStatsServiceServer
+
+	service serviceports.StatsService
+	logger  *zap.Logger
+}
+
+func NewStatsServer(service serviceports.StatsService, logger *zap.Logger) *StatsServer {
+	return &StatsServer{service: service, logger: logger}
+}
+
+func (s *StatsServer) GetStats(ctx context.Context, _ *reviewerv1.GetStatsRequest) (*reviewerv1.GetStatsResponse, error) {
+	stats, err := s.service.GetStats(ctx)
+	if err != nil {
+		loggerFor(ctx, s.logger).Warn("Get stats failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	return &reviewerv1.GetStatsResponse{Stats: statsToProto(stats)}, nil
+}
+
+func (s *StatsServer) GetStatsHistory(ctx context.Context, req *reviewerv1.GetStatsHistoryRequest) (*reviewerv1.GetStatsHistoryResponse, error) {
+	metric, ok := types.ParseStatsMetric(strings.TrimSpace(req.GetMetric()))
+	if !ok {
+		return nil, invalidArgument("metric must be one of teams, users, pull_requests, assignments, open_pull_requests")
+	}
+
+	bucket := types.StatsBucketHour
+	if raw := strings.TrimSpace(req.GetBucket()); raw != "" {
+		if bucket, ok = types.ParseStatsBucket(raw); !ok {
+			return nil, invalidArgument("bucket must be one of minute, hour, day, week, month")
+		}
+	}
+
+	from, err := timeFromProto(req.GetFrom())
+	if err != nil {
+		return nil, invalidArgument("from is not a valid timestamp")
+	}
+
+	to, err := timeFromProto(req.GetTo())
+	if err != nil {
+		return nil, invalidArgument("to is not a valid timestamp")
+	}
+
+	query := entities.StatsHistoryQuery{
+		Metric:   metric,
+		TeamName: strings.TrimSpace(req.GetTeamName()),
+		Bucket:   bucket,
+	}
+	if from != nil {
+		query.From = *from
+	}
+	if to != nil {
+		query.To = *to
+	}
+
+	history, err := s.service.GetHistory(ctx, query)
+	if err != nil {
+		loggerFor(ctx, s.logger).Warn("Get stats history failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	return statsHistoryToProto(history), nil
+}
+
+func (s *StatsServer) GetFairness(ctx context.Context, req *reviewerv1.GetFairnessRequest) (*reviewerv1.GetFairnessResponse, error) {
+	teamName := strings.TrimSpace(req.GetTeamName())
+	if teamName == "" {
+		return nil, invalidArgument("team_name is required")
+	}
+
+	from, err := timeFromProto(req.GetFrom())
+	if err != nil {
+		return nil, invalidArgument("from is not a valid timestamp")
+	}
+
+	to, err := timeFromProto(req.GetTo())
+	if err != nil {
+		return nil, invalidArgument("to is not a valid timestamp")
+	}
+
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, invalidArgument("from must be before to")
+	}
+
+	report, err := s.service.GetFairness(ctx, teamName, from, to)
+	if err != nil {
+		loggerFor(ctx, s.logger).Warn("Get fairness failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	return &reviewerv1.GetFairnessResponse{Fairness: fairnessToProto(report)}, nil
+}
@@ -0,0 +1,97 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	reviewerv1 "pr-reviewer-assignment/api/proto/reviewer/v1"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+
+	"go.uber.org/zap"
+)
+
+type TeamServer struct {
+	reviewerv1.UnimplementedTeamServiceServer
+
+	service serviceports.TeamService
+	logger  *zap.Logger
+}
+
+func NewTeamServer(service serviceports.TeamService, logger *zap.Logger) *TeamServer {
+	return &TeamServer{service: service, logger: logger}
+}
+
+func (s *TeamServer) CreateTeam(ctx context.Context, req *reviewerv1.CreateTeamRequest) (*reviewerv1.CreateTeamResponse, error) {
+	teamName := strings.TrimSpace(req.GetTeamName())
+	if teamName == "" {
+		return nil, invalidArgument("team_name is required")
+	}
+
+	team, err := s.service.CreateTeam(ctx, teamName, teamMembersFromProto(teamName, req.GetMembers()))
+	if err != nil {
+		loggerFor(ctx, s.logger).Warn("CreateTeam failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	return &reviewerv1.CreateTeamResponse{Team: teamToProto(team)}, nil
+}
+
+func (s *TeamServer) GetTeam(ctx context.Context, req *reviewerv1.GetTeamRequest) (*reviewerv1.GetTeamResponse, error) {
+	teamName := strings.TrimSpace(req.GetTeamName())
+	if teamName == "" {
+		return nil, invalidArgument("team_name is required")
+	}
+
+	team, err := s.service.GetTeam(ctx, teamName)
+	if err != nil {
+		loggerFor(ctx, s.logger).Warn("GetTeam failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	return &reviewerv1.GetTeamResponse{Team: teamToProto(team)}, nil
+}
+
+func (s *TeamServer) SetMemberRole(ctx context.Context, req *reviewerv1.SetMemberRoleRequest) (*reviewerv1.SetMemberRoleResponse, error) {
+	role, ok := types.ParseRole(req.GetRole())
+	if !ok {
+		return nil, invalidArgument("role must be viewer or team-lead")
+	}
+
+	teamRole, err := s.service.SetMemberRole(ctx, req.GetTeamName(), req.GetUserId(), role)
+	if err != nil {
+		loggerFor(ctx, s.logger).Warn("SetRole failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	return &reviewerv1.SetMemberRoleResponse{Role: teamRoleToProto(teamRole)}, nil
+}
+
+func (s *TeamServer) RemoveMemberRole(ctx context.Context, req *reviewerv1.RemoveMemberRoleRequest) (*reviewerv1.RemoveMemberRoleResponse, error) {
+	if err := s.service.RemoveMemberRole(ctx, req.GetTeamName(), req.GetUserId()); err != nil {
+		loggerFor(ctx, s.logger).Warn("RemoveRole failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	return &reviewerv1.RemoveMemberRoleResponse{}, nil
+}
+
+func (s *TeamServer) ListMemberRoles(ctx context.Context, req *reviewerv1.ListMemberRolesRequest) (*reviewerv1.ListMemberRolesResponse, error) {
+	teamName := strings.TrimSpace(req.GetTeamName())
+	if teamName == "" {
+		return nil, invalidArgument("team_name is required")
+	}
+
+	roles, err := s.service.ListMemberRoles(ctx, teamName)
+	if err != nil {
+		loggerFor(ctx, s.logger).Warn("GetRoles failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	result := make([]*reviewerv1.TeamRole, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, teamRoleToProto(role))
+	}
+
+	return &reviewerv1.ListMemberRolesResponse{TeamName: teamName, Roles: result}, nil
+}
@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	reviewerv1 "pr-reviewer-assignment/api/proto/reviewer/v1"
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+
+	"go.uber.org/zap"
+)
+
+type UserServer struct {
+	reviewerv1.UnimplementedUserServiceServer
+
+	service serviceports.UserService
+	logger  *zap.Logger
+}
+
+func NewUserServer(service serviceports.UserService, logger *zap.Logger) *UserServer {
+	return &UserServer{service: service, logger: logger}
+}
+
+func (s *UserServer) SetIsActive(ctx context.Context, req *reviewerv1.SetIsActiveRequest) (*reviewerv1.SetIsActiveResponse, error) {
+	if strings.TrimSpace(req.GetUserId()) == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	user, err := s.service.SetActivity(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		loggerFor(ctx, s.logger).Warn("SetActivity failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	return &reviewerv1.SetIsActiveResponse{User: userToProto(user)}, nil
+}
+
+func (s *UserServer) SetPreferences(ctx context.Context, req *reviewerv1.SetPreferencesRequest) (*reviewerv1.SetPreferencesResponse, error) {
+	if strings.TrimSpace(req.GetUserId()) == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	prefs := entities.UserPreferences{
+		Email:         req.Email,
+		Timezone:      req.Timezone,
+		DigestEnabled: req.DigestEnabled,
+	}
+
+	user, err := s.service.SetPreferences(ctx, req.GetUserId(), prefs)
+	if err != nil {
+		loggerFor(ctx, s.logger).Warn("SetPreferences failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	return &reviewerv1.SetPreferencesResponse{User: userToProto(user)}, nil
+}
+
+func (s *UserServer) GetReview(ctx context.Context, req *reviewerv1.GetReviewRequest) (*reviewerv1.GetReviewResponse, error) {
+	userID := strings.TrimSpace(req.GetUserId())
+	if userID == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	prs, err := s.service.GetReviewerAssignments(ctx, userID)
+	if err != nil {
+		loggerFor(ctx, s.logger).Warn("GetReviewerAssignments failed", zap.Error(err))
+		return nil, serviceError(ctx, s.logger, err)
+	}
+
+	return &reviewerv1.GetReviewResponse{UserId: userID, PullRequests: pullRequestsToShortProto(prs)}, nil
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/core/mappers"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type APIKeyHandler struct {
+	service serviceports.APIKeyService
+	logger  *zap.Logger
+}
+
+func NewAPIKeyHandler(service serviceports.APIKeyService, logger *zap.Logger) *APIKeyHandler {
+	return &APIKeyHandler{service: service, logger: logger}
+}
+
+type createAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	Teams  []string `json:"teams"`
+}
+
+type revokeAPIKeyRequest struct {
+	KeyID string `json:"key_id"`
+}
+
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	var payload createAPIKeyRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	scopes := make([]types.Scope, 0, len(payload.Scopes))
+	for _, raw := range payload.Scopes {
+		scope, ok := types.ParseScope(raw)
+		if !ok {
+			respondError(c, http.StatusBadRequest, errorCodeBadRequest, "unknown scope "+strings.TrimSpace(raw))
+			return
+		}
+		scopes = append(scopes, scope)
+	}
+
+	key, token, err := h.service.CreateKey(c.Request.Context(), payload.Name, scopes, payload.Teams)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("Create api key failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreateAPIKeyResponse{
+		APIKey: mappers.APIKeyToDTO(key),
+		Token:  token,
+	})
+}
+
+func (h *APIKeyHandler) List(c *gin.Context) {
+	keys, err := h.service.ListKeys(c.Request.Context())
+	if err != nil {
+		loggerFor(c, h.logger).Warn("List api keys failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ListAPIKeysResponse{APIKeys: mappers.APIKeysToDTO(keys)})
+}
+
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	var payload revokeAPIKeyRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	key, err := h.service.RevokeKey(c.Request.Context(), payload.KeyID)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("Revoke api key failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIKeyResponse{APIKey: mappers.APIKeyToDTO(key)})
+}
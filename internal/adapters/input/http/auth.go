@@ -0,0 +1,100 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/core/identity"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const bearerPrefix = "Bearer "
+
+// TokenVerifier validates bearer tokens issued by an external IdP.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*entities.Principal, error)
+}
+
+// Authenticator guards routes with bearer API keys and, when a verifier is
+// configured, JWTs. A nil or disabled Authenticator lets every request
+// through.
+type Authenticator struct {
+	service  serviceports.APIKeyService
+	verifier TokenVerifier
+	enabled  bool
+	logger   *zap.Logger
+}
+
+func NewAuthenticator(service serviceports.APIKeyService, verifier TokenVerifier, enabled bool, logger *zap.Logger) *Authenticator {
+	return &Authenticator{
+		service:  service,
+		verifier: verifier,
+		enabled:  enabled,
+		logger:   logger,
+	}
+}
+
+func (a *Authenticator) Require(scope types.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if a == nil || !a.enabled {
+			c.Next()
+			return
+		}
+
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			c.Header("WWW-Authenticate", `Bearer realm="pr-reviewer"`)
+			respondError(c, http.StatusUnauthorized, errorCodeUnauthorized, "missing bearer token")
+			return
+		}
+
+		principal, err := a.authenticate(c.Request.Context(), token)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="pr-reviewer", error="invalid_token"`)
+			handleServiceError(c, a.logger, err)
+			return
+		}
+
+		if !principal.HasScope(scope) {
+			loggerFor(c, a.logger).Info("Request lacks required scope",
+				zap.String("subject", principal.Subject),
+				zap.String("scope", scope.String()),
+			)
+			respondError(c, http.StatusForbidden, errorCodeForbidden, "missing scope "+scope.String())
+			return
+		}
+
+		ctx := identity.ContextWithPrincipal(c.Request.Context(), principal)
+		ctx = logger.ContextWithLogger(ctx, loggerFor(c, a.logger).With(zap.String("principal", principal.Subject)))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func (a *Authenticator) authenticate(ctx context.Context, token string) (*entities.Principal, error) {
+	if a.verifier != nil && looksLikeJWT(token) {
+		return a.verifier.Verify(ctx, token)
+	}
+
+	return a.service.Authenticate(ctx, token)
+}
+
+func looksLikeJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+func bearerToken(header string) (string, bool) {
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(header[len(bearerPrefix):])
+	return token, token != ""
+}
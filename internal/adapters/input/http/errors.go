@@ -6,26 +6,38 @@ import (
 
 	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
 	"pr-reviewer-assignment/internal/dto"
+	"pr-reviewer-assignment/internal/logger"
+	"pr-reviewer-assignment/internal/requestid"
+	"pr-reviewer-assignment/internal/validation"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
 const (
-	errorCodeBadRequest = "BAD_REQUEST"
-	errorCodeInternal   = "INTERNAL_ERROR"
+	errorCodeBadRequest   = "BAD_REQUEST"
+	errorCodeInternal     = "INTERNAL_ERROR"
+	errorCodeUnauthorized = string(domainErrors.ErrorCodeUnauthorized)
+	errorCodeForbidden    = string(domainErrors.ErrorCodeForbidden)
 )
 
 func respondError(c *gin.Context, status int, code, message string) {
 	c.AbortWithStatusJSON(status, dto.ErrorResponse{
 		Error: dto.ErrorBody{
-			Code:    code,
-			Message: message,
+			Code:      code,
+			Message:   message,
+			RequestID: requestid.FromContext(c.Request.Context()),
 		},
 	})
 }
 
-func handleServiceError(c *gin.Context, logger *zap.Logger, err error) {
+func loggerFor(c *gin.Context, fallback *zap.Logger) *zap.Logger {
+	return logger.FromContext(c.Request.Context(), fallback)
+}
+
+func handleServiceError(c *gin.Context, fallback *zap.Logger, err error) {
+	log := loggerFor(c, fallback)
+
 	var dErr domainErrors.DomainError
 	if errors.As(err, &dErr) {
 		switch dErr.Code() {
@@ -34,18 +46,31 @@ func handleServiceError(c *gin.Context, logger *zap.Logger, err error) {
 		case domainErrors.ErrorCodePRExists:
 			respondError(c, http.StatusConflict, string(dErr.Code()), dErr.Message())
 		case domainErrors.ErrorCodePRMerged,
+			domainErrors.ErrorCodePRClosed,
 			domainErrors.ErrorCodeNotAssigned,
 			domainErrors.ErrorCodeNoCandidate:
 			respondError(c, http.StatusConflict, string(dErr.Code()), dErr.Message())
+		case domainErrors.ErrorCodeConflict:
+			respondError(c, http.StatusPreconditionFailed, string(dErr.Code()), dErr.Message())
 		case domainErrors.ErrorCodeNotFound:
 			respondError(c, http.StatusNotFound, string(dErr.Code()), dErr.Message())
+		case domainErrors.ErrorCodeUnauthorized:
+			respondError(c, http.StatusUnauthorized, string(dErr.Code()), dErr.Message())
+		case domainErrors.ErrorCodeForbidden:
+			respondError(c, http.StatusForbidden, string(dErr.Code()), dErr.Message())
 		default:
-			logger.Warn("Unhandled domain error", zap.String("code", string(dErr.Code())), zap.String("message", dErr.Message()))
+			log.Warn("Unhandled domain error", zap.String("code", string(dErr.Code())), zap.String("message", dErr.Message()))
 			respondError(c, http.StatusInternalServerError, errorCodeInternal, "internal server error")
 		}
 		return
 	}
 
-	logger.Error("Service error", zap.Error(err))
+	var fieldErr validation.FieldError
+	if errors.As(err, &fieldErr) {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, fieldErr.Error())
+		return
+	}
+
+	log.Error("Service error", zap.Error(err))
 	respondError(c, http.StatusInternalServerError, errorCodeInternal, "internal server error")
 }
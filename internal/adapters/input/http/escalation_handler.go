@@ -0,0 +1,120 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/mappers"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const maxEscalationListLimit = 500
+
+type EscalationHandler struct {
+	service serviceports.EscalationService
+	logger  *zap.Logger
+}
+
+func NewEscalationHandler(service serviceports.EscalationService, logger *zap.Logger) *EscalationHandler {
+	return &EscalationHandler{service: service, logger: logger}
+}
+
+type setReviewSLARequest struct {
+	TeamName      string `json:"team_name"`
+	RemindAfter   string `json:"remind_after"`
+	EscalateAfter string `json:"escalate_after"`
+}
+
+type removeReviewSLARequest struct {
+	TeamName string `json:"team_name"`
+}
+
+func (h *EscalationHandler) SetSLA(c *gin.Context) {
+	var payload setReviewSLARequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	remindAfter, err := time.ParseDuration(strings.TrimSpace(payload.RemindAfter))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "remind_after must be a duration such as 24h")
+		return
+	}
+
+	var escalateAfter time.Duration
+	if raw := strings.TrimSpace(payload.EscalateAfter); raw != "" {
+		if escalateAfter, err = time.ParseDuration(raw); err != nil {
+			respondError(c, http.StatusBadRequest, errorCodeBadRequest, "escalate_after must be a duration such as 48h")
+			return
+		}
+	}
+
+	sla, err := h.service.SetSLA(c.Request.Context(), payload.TeamName, remindAfter, escalateAfter)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("Set review SLA failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ReviewSLAResponse{SLA: mappers.ReviewSLAToDTO(sla)})
+}
+
+func (h *EscalationHandler) ListSLAs(c *gin.Context) {
+	slas, err := h.service.ListSLAs(c.Request.Context())
+	if err != nil {
+		loggerFor(c, h.logger).Warn("List review SLAs failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ListReviewSLAsResponse{SLAs: mappers.ReviewSLAsToDTO(slas)})
+}
+
+func (h *EscalationHandler) RemoveSLA(c *gin.Context) {
+	var payload removeReviewSLARequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	if err := h.service.RemoveSLA(c.Request.Context(), payload.TeamName); err != nil {
+		loggerFor(c, h.logger).Warn("Remove review SLA failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *EscalationHandler) List(c *gin.Context) {
+	filter := repo.EscalationFilter{
+		TeamName:      strings.TrimSpace(c.Query("team_name")),
+		PullRequestID: strings.TrimSpace(c.Query("pull_request_id")),
+	}
+
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 || limit > maxEscalationListLimit {
+			respondError(c, http.StatusBadRequest, errorCodeBadRequest, "limit must be between 1 and "+strconv.Itoa(maxEscalationListLimit))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	escalations, err := h.service.ListEscalations(c.Request.Context(), filter)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("List review escalations failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ListReviewEscalationsResponse{Escalations: mappers.ReviewEscalationsToDTO(escalations)})
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/mappers"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultStreamHeartbeat = 15 * time.Second
+	eventReplayPage        = 500
+)
+
+type EventStreamHandler struct {
+	service   serviceports.EventStreamService
+	heartbeat time.Duration
+	logger    *zap.Logger
+}
+
+func NewEventStreamHandler(service serviceports.EventStreamService, heartbeat time.Duration, logger *zap.Logger) *EventStreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = defaultStreamHeartbeat
+	}
+
+	return &EventStreamHandler{service: service, heartbeat: heartbeat, logger: logger}
+}
+
+// Stream: a client that reconnects with Last-Event-ID (or last_event_id)
+// first receives what it missed, then live events.
+func (h *EventStreamHandler) Stream(c *gin.Context) {
+	filter := serviceports.EventStreamFilter{
+		TeamName: c.Query("team_name"),
+		UserID:   c.Query("user_id"),
+	}
+
+	last, resume, ok := lastEventID(c)
+	if !ok {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "Last-Event-ID must be a non-negative integer")
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Subscribing before the replay means nothing published in between is
+	// lost; events seen twice are skipped by position.
+	sub, err := h.service.Subscribe(ctx, filter)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("Subscribe to event stream failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+	defer sub.Close()
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+
+	for resume {
+		events, err := h.service.Replay(ctx, filter, last, eventReplayPage)
+		if err != nil {
+			loggerFor(c, h.logger).Warn("Replay event stream failed", zap.Error(err))
+			return
+		}
+
+		for _, event := range events {
+			if err := writeStreamEvent(c, event); err != nil {
+				return
+			}
+			last = event.Position
+		}
+
+		resume = len(events) == eventReplayPage
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, open := <-sub.Events():
+			if !open {
+				return
+			}
+			if event.Position <= last {
+				continue
+			}
+			if err := writeStreamEvent(c, event); err != nil {
+				return
+			}
+			last = event.Position
+		case <-heartbeat.C:
+			if _, err := c.Writer.WriteString(": keepalive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+func writeStreamEvent(c *gin.Context, event *entities.Event) error {
+	err := sse.Encode(c.Writer, sse.Event{
+		Id:    strconv.FormatInt(event.Position, 10),
+		Event: event.Type.String(),
+		Data:  mappers.EventToDTO(event),
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Writer.Flush()
+	return nil
+}
+
+func lastEventID(c *gin.Context) (int64, bool, bool) {
+	raw := strings.TrimSpace(c.GetHeader("Last-Event-ID"))
+	if raw == "" {
+		raw = strings.TrimSpace(c.Query("last_event_id"))
+	}
+	if raw == "" {
+		return 0, false, true
+	}
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 0 {
+		return 0, false, false
+	}
+
+	return id, true, true
+}
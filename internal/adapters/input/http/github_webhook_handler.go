@@ -0,0 +1,163 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/core/mappers"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	githubSignatureHeader = "X-Hub-Signature-256"
+	githubEventHeader     = "X-GitHub-Event"
+
+	// maxCodeHostPayloadBytes matches GitHub's own cap on webhook payloads.
+	maxCodeHostPayloadBytes = 25 << 20
+)
+
+// GitHubWebhookHandler receives GitHub pull_request webhooks. Requests are
+// authenticated by their HMAC signature rather than an API key.
+type GitHubWebhookHandler struct {
+	service serviceports.IntegrationService
+	secret  []byte
+	logger  *zap.Logger
+}
+
+func NewGitHubWebhookHandler(service serviceports.IntegrationService, secret string, logger *zap.Logger) *GitHubWebhookHandler {
+	return &GitHubWebhookHandler{service: service, secret: []byte(secret), logger: logger}
+}
+
+type githubPullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Draft  bool   `json:"draft"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+func (h *GitHubWebhookHandler) Handle(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxCodeHostPayloadBytes))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "failed to read payload")
+		return
+	}
+
+	if !h.validSignature(c.GetHeader(githubSignatureHeader), body) {
+		loggerFor(c, h.logger).Warn("GitHub webhook signature mismatch")
+		respondError(c, http.StatusUnauthorized, errorCodeUnauthorized, "invalid signature")
+		return
+	}
+
+	switch eventName := c.GetHeader(githubEventHeader); eventName {
+	case "pull_request":
+	case "ping":
+		c.JSON(http.StatusOK, dto.SyncResponse{Result: types.SyncIgnored.String(), Reason: "pong"})
+		return
+	default:
+		c.JSON(http.StatusOK, dto.SyncResponse{Result: types.SyncIgnored.String(), Reason: fmt.Sprintf("event %q is not handled", eventName)})
+		return
+	}
+
+	event, err := parseGitHubPullRequest(body)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, err.Error())
+		return
+	}
+
+	if event.Action == "" {
+		c.JSON(http.StatusOK, dto.SyncResponse{Result: types.SyncIgnored.String(), Reason: "action is not handled"})
+		return
+	}
+
+	outcome, err := h.service.HandlePullRequestEvent(c.Request.Context(), event)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("GitHub webhook failed", zap.String("pr_id", event.PullRequestID), zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.SyncOutcomeToDTO(outcome))
+}
+
+func (h *GitHubWebhookHandler) validSignature(header string, body []byte) bool {
+	digest, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// parseGitHubPullRequest leaves Action empty for actions that do not change
+// anything here, such as labels or edits.
+func parseGitHubPullRequest(body []byte) (entities.CodeHostPullRequestEvent, error) {
+	var payload githubPullRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return entities.CodeHostPullRequestEvent{}, errors.New("invalid payload")
+	}
+
+	if payload.Repository.FullName == "" || payload.PullRequest.Number <= 0 {
+		return entities.CodeHostPullRequestEvent{}, errors.New("payload has no pull request")
+	}
+
+	ref := entities.CodeHostRef{
+		Provider:   types.ProviderGitHub,
+		Repository: payload.Repository.FullName,
+		Number:     payload.PullRequest.Number,
+	}
+
+	event := entities.CodeHostPullRequestEvent{
+		Provider:      types.ProviderGitHub,
+		PullRequestID: ref.ID(),
+		Title:         payload.PullRequest.Title,
+		AuthorLogin:   payload.PullRequest.User.Login,
+		Draft:         payload.PullRequest.Draft,
+	}
+
+	switch payload.Action {
+	case "opened":
+		event.Action = types.CodeHostOpened
+	case "ready_for_review":
+		event.Action = types.CodeHostReadyForReview
+	case "converted_to_draft":
+		event.Action = types.CodeHostConvertedDraft
+	case "reopened":
+		event.Action = types.CodeHostReopened
+	case "closed":
+		event.Action = types.CodeHostClosed
+		if payload.PullRequest.Merged {
+			event.Action = types.CodeHostMerged
+		}
+	}
+
+	return event, nil
+}
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/core/mappers"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	gitlabTokenHeader = "X-Gitlab-Token"
+	gitlabEventHeader = "X-Gitlab-Event"
+
+	gitlabMergeRequestEvent = "Merge Request Hook"
+)
+
+// GitLabWebhookHandler receives GitLab Merge Request Hook events. GitLab does
+// not sign payloads; it echoes the webhook's secret token in a header.
+type GitLabWebhookHandler struct {
+	service serviceports.IntegrationService
+	token   []byte
+	logger  *zap.Logger
+}
+
+func NewGitLabWebhookHandler(service serviceports.IntegrationService, token string, logger *zap.Logger) *GitLabWebhookHandler {
+	return &GitLabWebhookHandler{service: service, token: []byte(token), logger: logger}
+}
+
+type gitlabDraftChange struct {
+	Previous bool `json:"previous"`
+	Current  bool `json:"current"`
+}
+
+type gitlabMergeRequestPayload struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		ID       int64  `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID            int    `json:"iid"`
+		Title          string `json:"title"`
+		AuthorID       int64  `json:"author_id"`
+		Action         string `json:"action"`
+		Draft          bool   `json:"draft"`
+		WorkInProgress bool   `json:"work_in_progress"`
+	} `json:"object_attributes"`
+	Changes struct {
+		Draft          *gitlabDraftChange `json:"draft"`
+		WorkInProgress *gitlabDraftChange `json:"work_in_progress"`
+	} `json:"changes"`
+}
+
+func (h *GitLabWebhookHandler) Handle(c *gin.Context) {
+	if subtle.ConstantTimeCompare([]byte(c.GetHeader(gitlabTokenHeader)), h.token) != 1 {
+		loggerFor(c, h.logger).Warn("GitLab webhook token mismatch")
+		respondError(c, http.StatusUnauthorized, errorCodeUnauthorized, "invalid token")
+		return
+	}
+
+	if eventName := c.GetHeader(gitlabEventHeader); eventName != gitlabMergeRequestEvent {
+		c.JSON(http.StatusOK, dto.SyncResponse{Result: types.SyncIgnored.String(), Reason: fmt.Sprintf("event %q is not handled", eventName)})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxCodeHostPayloadBytes))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "failed to read payload")
+		return
+	}
+
+	event, err := parseGitLabMergeRequest(body)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, err.Error())
+		return
+	}
+
+	if event.Action == "" {
+		c.JSON(http.StatusOK, dto.SyncResponse{Result: types.SyncIgnored.String(), Reason: "action is not handled"})
+		return
+	}
+
+	outcome, err := h.service.HandlePullRequestEvent(c.Request.Context(), event)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("GitLab webhook failed", zap.String("pr_id", event.PullRequestID), zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.SyncOutcomeToDTO(outcome))
+}
+
+// parseGitLabMergeRequest maps merge request actions onto the shared
+// lifecycle. GitLab only reports the author's numeric ID, so the author login
+// is taken from the acting user when they are the author; a merge request
+// that becomes ready through someone else is left without one and ignored.
+func parseGitLabMergeRequest(body []byte) (entities.CodeHostPullRequestEvent, error) {
+	var payload gitlabMergeRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return entities.CodeHostPullRequestEvent{}, errors.New("invalid payload")
+	}
+
+	attrs := payload.ObjectAttributes
+	if payload.ObjectKind != "merge_request" || payload.Project.PathWithNamespace == "" || attrs.IID <= 0 {
+		return entities.CodeHostPullRequestEvent{}, errors.New("payload has no merge request")
+	}
+
+	ref := entities.CodeHostRef{
+		Provider:   types.ProviderGitLab,
+		Repository: payload.Project.PathWithNamespace,
+		Number:     attrs.IID,
+	}
+
+	event := entities.CodeHostPullRequestEvent{
+		Provider:      types.ProviderGitLab,
+		PullRequestID: ref.ID(),
+		Title:         attrs.Title,
+		Draft:         attrs.Draft || attrs.WorkInProgress,
+	}
+
+	if payload.User.ID != 0 && payload.User.ID == attrs.AuthorID {
+		event.AuthorLogin = payload.User.Username
+	}
+
+	switch attrs.Action {
+	case "open":
+		event.Action = types.CodeHostOpened
+	case "reopen":
+		event.Action = types.CodeHostReopened
+	case "close":
+		event.Action = types.CodeHostClosed
+	case "merge":
+		event.Action = types.CodeHostMerged
+	case "update":
+		change := payload.Changes.Draft
+		if change == nil {
+			change = payload.Changes.WorkInProgress
+		}
+
+		switch {
+		case change == nil || change.Previous == change.Current:
+		case change.Current:
+			event.Action = types.CodeHostConvertedDraft
+		default:
+			event.Action = types.CodeHostReadyForReview
+		}
+	}
+
+	return event, nil
+}
@@ -3,20 +3,46 @@ package http
 import (
 	"net/http"
 
+	"pr-reviewer-assignment/internal/health"
+
 	"github.com/gin-gonic/gin"
 )
 
-type HealthHandler struct{}
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	if checker == nil {
+		checker = health.NewChecker(0)
+	}
 
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+	return &HealthHandler{checker: checker}
 }
 
 func (h *HealthHandler) Register(router *gin.Engine) {
 	router.GET("/health", h.Ping)
 	router.HEAD("/health", h.Ping)
+	router.GET("/health/live", h.Ping)
+	router.HEAD("/health/live", h.Ping)
+	router.GET("/health/ready", h.Ready)
+	router.HEAD("/health/ready", h.Ready)
 }
 
 func (h *HealthHandler) Ping(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
+
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.checker.Run(c.Request.Context())
+
+	status, label := http.StatusOK, "ready"
+	if !report.Ready {
+		status, label = http.StatusServiceUnavailable, "not_ready"
+	}
+
+	c.JSON(status, gin.H{
+		"status": label,
+		"checks": report.Checks,
+	})
+}
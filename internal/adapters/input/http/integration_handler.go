@@ -0,0 +1,102 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/core/mappers"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// IntegrationHandler manages the links between code host logins and users
+// that the inbound webhooks rely on.
+type IntegrationHandler struct {
+	service serviceports.IntegrationService
+	logger  *zap.Logger
+}
+
+func NewIntegrationHandler(service serviceports.IntegrationService, logger *zap.Logger) *IntegrationHandler {
+	return &IntegrationHandler{service: service, logger: logger}
+}
+
+type linkAccountRequest struct {
+	Provider string `json:"provider"`
+	Login    string `json:"login"`
+	UserID   string `json:"user_id"`
+}
+
+type unlinkAccountRequest struct {
+	Provider string `json:"provider"`
+	Login    string `json:"login"`
+}
+
+func (h *IntegrationHandler) LinkAccount(c *gin.Context) {
+	var payload linkAccountRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	provider, ok := types.ParseProvider(payload.Provider)
+	if !ok {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "provider must be github or gitlab")
+		return
+	}
+
+	account, err := h.service.LinkAccount(c.Request.Context(), provider, payload.Login, strings.TrimSpace(payload.UserID))
+	if err != nil {
+		loggerFor(c, h.logger).Warn("Link account failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ExternalAccountResponse{Account: mappers.ExternalAccountToDTO(account)})
+}
+
+func (h *IntegrationHandler) UnlinkAccount(c *gin.Context) {
+	var payload unlinkAccountRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	provider, ok := types.ParseProvider(payload.Provider)
+	if !ok {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "provider must be github or gitlab")
+		return
+	}
+
+	if err := h.service.UnlinkAccount(c.Request.Context(), provider, payload.Login); err != nil {
+		loggerFor(c, h.logger).Warn("Unlink account failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *IntegrationHandler) ListAccounts(c *gin.Context) {
+	var provider types.Provider
+	if raw := strings.TrimSpace(c.Query("provider")); raw != "" {
+		parsed, ok := types.ParseProvider(raw)
+		if !ok {
+			respondError(c, http.StatusBadRequest, errorCodeBadRequest, "provider must be github or gitlab")
+			return
+		}
+		provider = parsed
+	}
+
+	accounts, err := h.service.ListAccounts(c.Request.Context(), provider)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("List accounts failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ListExternalAccountsResponse{Accounts: mappers.ExternalAccountsToDTO(accounts)})
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"pr-reviewer-assignment/internal/core/mappers"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type NotificationHandler struct {
+	service serviceports.NotificationService
+	logger  *zap.Logger
+}
+
+func NewNotificationHandler(service serviceports.NotificationService, logger *zap.Logger) *NotificationHandler {
+	return &NotificationHandler{service: service, logger: logger}
+}
+
+type setChatChannelRequest struct {
+	TeamName   string `json:"team_name"`
+	WebhookURL string `json:"webhook_url"`
+	Channel    string `json:"channel"`
+}
+
+type removeChatChannelRequest struct {
+	TeamName string `json:"team_name"`
+}
+
+func (h *NotificationHandler) SetChannel(c *gin.Context) {
+	var payload setChatChannelRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	channel, err := h.service.SetChannel(c.Request.Context(), payload.TeamName, strings.TrimSpace(payload.WebhookURL), payload.Channel)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("Set chat channel failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ChatChannelResponse{Channel: mappers.ChatChannelToDTO(channel)})
+}
+
+func (h *NotificationHandler) ListChannels(c *gin.Context) {
+	channels, err := h.service.ListChannels(c.Request.Context())
+	if err != nil {
+		loggerFor(c, h.logger).Warn("List chat channels failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ListChatChannelsResponse{Channels: mappers.ChatChannelsToDTO(channels)})
+}
+
+func (h *NotificationHandler) RemoveChannel(c *gin.Context) {
+	var payload removeChatChannelRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	if err := h.service.RemoveChannel(c.Request.Context(), payload.TeamName); err != nil {
+		loggerFor(c, h.logger).Warn("Remove chat channel failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
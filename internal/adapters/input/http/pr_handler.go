@@ -2,6 +2,7 @@ package http
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -49,11 +50,12 @@ func (h *PullRequestHandler) Create(c *gin.Context) {
 	pr := entities.NewPullRequest(payload.PullRequestID, payload.PullRequestName, payload.AuthorID, time.Time{})
 	created, err := h.service.CreatePullRequest(c.Request.Context(), pr)
 	if err != nil {
-		h.logger.Warn("Create PR failed", zap.Error(err))
+		loggerFor(c, h.logger).Warn("Create PR failed", zap.Error(err))
 		handleServiceError(c, h.logger, err)
 		return
 	}
 
+	setETag(c, created)
 	c.JSON(http.StatusCreated, gin.H{"pr": mappers.PullRequestToDTO(created)})
 }
 
@@ -73,13 +75,20 @@ func (h *PullRequestHandler) Merge(c *gin.Context) {
 		return
 	}
 
-	pr, err := h.service.MergePullRequest(c.Request.Context(), payload.PullRequestID)
+	expectedVersion, ok := ifMatchVersion(c)
+	if !ok {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid If-Match header")
+		return
+	}
+
+	pr, err := h.service.MergePullRequest(c.Request.Context(), payload.PullRequestID, expectedVersion)
 	if err != nil {
-		h.logger.Warn("Merge PR failed", zap.Error(err))
+		loggerFor(c, h.logger).Warn("Merge PR failed", zap.Error(err))
 		handleServiceError(c, h.logger, err)
 		return
 	}
 
+	setETag(c, pr)
 	c.JSON(http.StatusOK, gin.H{"pr": mappers.PullRequestToDTO(pr)})
 }
 
@@ -106,15 +115,49 @@ func (h *PullRequestHandler) Reassign(c *gin.Context) {
 		return
 	}
 
-	pr, replacedBy, err := h.service.ReassignReviewer(c.Request.Context(), payload.PullRequestID, oldUserID)
+	expectedVersion, ok := ifMatchVersion(c)
+	if !ok {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid If-Match header")
+		return
+	}
+
+	pr, replacedBy, err := h.service.ReassignReviewer(c.Request.Context(), payload.PullRequestID, oldUserID, expectedVersion)
 	if err != nil {
-		h.logger.Warn("Reassign reviewer failed", zap.Error(err))
+		loggerFor(c, h.logger).Warn("Reassign reviewer failed", zap.Error(err))
 		handleServiceError(c, h.logger, err)
 		return
 	}
 
+	setETag(c, pr)
 	c.JSON(http.StatusOK, gin.H{
 		"pr":          mappers.PullRequestToDTO(pr),
 		"replaced_by": replacedBy,
 	})
 }
+
+func setETag(c *gin.Context, pr *entities.PullRequest) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(pr.Version)))
+}
+
+// ifMatchVersion reads the pull request version from If-Match. A missing
+// header or "*" yields 0, meaning the update is unconditional; weak tags are
+// accepted since versions are compared as plain numbers.
+func ifMatchVersion(c *gin.Context) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+
+	return version, true
+}
@@ -2,7 +2,12 @@ package http
 
 import (
 	"net/http"
+	"strings"
+	"time"
 
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/core/mappers"
 	serviceports "pr-reviewer-assignment/internal/core/ports/services"
 	"pr-reviewer-assignment/internal/dto"
 
@@ -24,22 +29,119 @@ func NewStatsHandler(service serviceports.StatsService, logger *zap.Logger) *Sta
 
 func (h *StatsHandler) RegisterRoutes(router *gin.Engine) {
 	router.GET("/stats", h.GetStats)
+	router.GET("/stats/history", h.GetHistory)
+	router.GET("/stats/fairness", h.GetFairness)
 }
 
 func (h *StatsHandler) GetStats(c *gin.Context) {
 	stats, err := h.service.GetStats(c.Request.Context())
 	if err != nil {
-		h.logger.Warn("Get stats failed", zap.Error(err))
+		loggerFor(c, h.logger).Warn("Get stats failed", zap.Error(err))
 		handleServiceError(c, h.logger, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.StatsResponse{
-		Stats: dto.StatsDTO{
-			Teams:        stats.Teams,
-			Users:        stats.Users,
-			PullRequests: stats.PullRequests,
-			Assignments:  stats.Assignments,
-		},
+		Stats: mappers.StatsToDTO(stats),
 	})
 }
+
+func (h *StatsHandler) GetHistory(c *gin.Context) {
+	metric, ok := types.ParseStatsMetric(strings.TrimSpace(c.Query("metric")))
+	if !ok {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "metric must be one of teams, users, pull_requests, assignments, open_pull_requests")
+		return
+	}
+
+	bucket := types.StatsBucketHour
+	if raw := strings.TrimSpace(c.Query("bucket")); raw != "" {
+		if bucket, ok = types.ParseStatsBucket(raw); !ok {
+			respondError(c, http.StatusBadRequest, errorCodeBadRequest, "bucket must be one of minute, hour, day, week, month")
+			return
+		}
+	}
+
+	from, ok := parseTimeQuery(c, "from")
+	if !ok {
+		return
+	}
+
+	to, ok := parseTimeQuery(c, "to")
+	if !ok {
+		return
+	}
+
+	query := entities.StatsHistoryQuery{
+		Metric:   metric,
+		TeamName: strings.TrimSpace(c.Query("team_name")),
+		Bucket:   bucket,
+	}
+	if from != nil {
+		query.From = *from
+	}
+	if to != nil {
+		query.To = *to
+	}
+
+	history, err := h.service.GetHistory(c.Request.Context(), query)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("Get stats history failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.StatsHistoryToDTO(history))
+}
+
+func (h *StatsHandler) GetFairness(c *gin.Context) {
+	teamName := strings.TrimSpace(c.Query("team_name"))
+	if teamName == "" {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "team_name is required")
+		return
+	}
+
+	from, ok := parseTimeQuery(c, "from")
+	if !ok {
+		return
+	}
+
+	to, ok := parseTimeQuery(c, "to")
+	if !ok {
+		return
+	}
+
+	if from != nil && to != nil && !from.Before(*to) {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "from must be before to")
+		return
+	}
+
+	report, err := h.service.GetFairness(c.Request.Context(), teamName, from, to)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("Get fairness failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.FairnessResponse{
+		Fairness: mappers.FairnessToDTO(report),
+	})
+}
+
+// parseTimeQuery accepts RFC3339 timestamps or plain dates; it writes the 400
+// response itself and reports false when the value cannot be parsed.
+func parseTimeQuery(c *gin.Context, key string) (*time.Time, bool) {
+	raw := strings.TrimSpace(c.Query(key))
+	if raw == "" {
+		return nil, true
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if parsed, err := time.Parse(layout, raw); err == nil {
+			parsed = parsed.UTC()
+			return &parsed, true
+		}
+	}
+
+	respondError(c, http.StatusBadRequest, errorCodeBadRequest, key+" must be an RFC3339 timestamp or a YYYY-MM-DD date")
+	return nil, false
+}
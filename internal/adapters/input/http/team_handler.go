@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"pr-reviewer-assignment/internal/core/domain/types"
 	"pr-reviewer-assignment/internal/core/mappers"
 	serviceports "pr-reviewer-assignment/internal/core/ports/services"
 	"pr-reviewer-assignment/internal/dto"
@@ -42,7 +43,7 @@ func (h *TeamHandler) CreateTeam(c *gin.Context) {
 	members := mappers.TeamMembersFromDTO(teamName, payload.Members)
 	team, err := h.service.CreateTeam(c.Request.Context(), teamName, members)
 	if err != nil {
-		h.logger.Warn("CreateTeam failed", zap.Error(err))
+		loggerFor(c, h.logger).Warn("CreateTeam failed", zap.Error(err))
 		handleServiceError(c, h.logger, err)
 		return
 	}
@@ -59,10 +60,75 @@ func (h *TeamHandler) GetTeam(c *gin.Context) {
 
 	team, err := h.service.GetTeam(c.Request.Context(), teamName)
 	if err != nil {
-		h.logger.Warn("GetTeam failed", zap.Error(err))
+		loggerFor(c, h.logger).Warn("GetTeam failed", zap.Error(err))
 		handleServiceError(c, h.logger, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, mappers.TeamToDTO(team))
 }
+
+type teamRoleRequest struct {
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+	Role     string `json:"role"`
+}
+
+func (h *TeamHandler) SetRole(c *gin.Context) {
+	var payload teamRoleRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	role, ok := types.ParseRole(payload.Role)
+	if !ok {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "role must be viewer or team-lead")
+		return
+	}
+
+	teamRole, err := h.service.SetMemberRole(c.Request.Context(), payload.TeamName, payload.UserID, role)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("SetRole failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"role": mappers.TeamRoleToDTO(teamRole)})
+}
+
+func (h *TeamHandler) RemoveRole(c *gin.Context) {
+	var payload teamRoleRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	if err := h.service.RemoveMemberRole(c.Request.Context(), payload.TeamName, payload.UserID); err != nil {
+		loggerFor(c, h.logger).Warn("RemoveRole failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TeamHandler) GetRoles(c *gin.Context) {
+	teamName := strings.TrimSpace(c.Query("team_name"))
+	if teamName == "" {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "team_name is required")
+		return
+	}
+
+	roles, err := h.service.ListMemberRoles(c.Request.Context(), teamName)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("GetRoles failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TeamRolesResponse{
+		TeamName: teamName,
+		Roles:    mappers.TeamRolesToDTO(roles),
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"pr-reviewer-assignment/internal/core/domain/entities"
 	"pr-reviewer-assignment/internal/core/mappers"
 	serviceports "pr-reviewer-assignment/internal/core/ports/services"
 	"pr-reviewer-assignment/internal/dto"
@@ -24,6 +25,7 @@ func NewUserHandler(service serviceports.UserService, logger *zap.Logger) *UserH
 func (h *UserHandler) RegisterRoutes(router *gin.Engine) {
 	router.POST("/users/setIsActive", h.SetActivity)
 	router.GET("/users/getReview", h.GetReviewerAssignments)
+	router.POST("/users/setPreferences", h.SetPreferences)
 }
 
 type setActivityRequest struct {
@@ -50,7 +52,42 @@ func (h *UserHandler) SetActivity(c *gin.Context) {
 
 	user, err := h.service.SetActivity(c.Request.Context(), payload.UserID, *payload.IsActive)
 	if err != nil {
-		h.logger.Warn("SetActivity failed", zap.Error(err))
+		loggerFor(c, h.logger).Warn("SetActivity failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": mappers.UserToDTO(user)})
+}
+
+type setPreferencesRequest struct {
+	UserID        string  `json:"user_id"`
+	Email         *string `json:"email"`
+	Timezone      *string `json:"timezone"`
+	DigestEnabled *bool   `json:"digest_enabled"`
+}
+
+func (h *UserHandler) SetPreferences(c *gin.Context) {
+	var payload setPreferencesRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	if strings.TrimSpace(payload.UserID) == "" {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "user_id is required")
+		return
+	}
+
+	prefs := entities.UserPreferences{
+		Email:         payload.Email,
+		Timezone:      payload.Timezone,
+		DigestEnabled: payload.DigestEnabled,
+	}
+
+	user, err := h.service.SetPreferences(c.Request.Context(), payload.UserID, prefs)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("SetPreferences failed", zap.Error(err))
 		handleServiceError(c, h.logger, err)
 		return
 	}
@@ -67,7 +104,7 @@ func (h *UserHandler) GetReviewerAssignments(c *gin.Context) {
 
 	prs, err := h.service.GetReviewerAssignments(c.Request.Context(), userID)
 	if err != nil {
-		h.logger.Warn("GetReviewerAssignments failed", zap.Error(err))
+		loggerFor(c, h.logger).Warn("GetReviewerAssignments failed", zap.Error(err))
 		handleServiceError(c, h.logger, err)
 		return
 	}
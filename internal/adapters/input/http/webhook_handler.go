@@ -0,0 +1,156 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/core/mappers"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const maxDeliveryListLimit = 500
+
+type WebhookHandler struct {
+	service serviceports.WebhookService
+	logger  *zap.Logger
+}
+
+func NewWebhookHandler(service serviceports.WebhookService, logger *zap.Logger) *WebhookHandler {
+	return &WebhookHandler{service: service, logger: logger}
+}
+
+type createWebhookRequest struct {
+	URL      string   `json:"url"`
+	Secret   string   `json:"secret"`
+	Events   []string `json:"events"`
+	TeamName string   `json:"team_name"`
+}
+
+type deleteWebhookRequest struct {
+	WebhookID string `json:"webhook_id"`
+}
+
+type redeliverRequest struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var payload createWebhookRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	eventTypes := make([]types.EventType, 0, len(payload.Events))
+	for _, raw := range payload.Events {
+		eventType, ok := types.ParseEventType(raw)
+		if !ok {
+			respondError(c, http.StatusBadRequest, errorCodeBadRequest, "unknown event "+strings.TrimSpace(raw))
+			return
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	sub, err := h.service.CreateSubscription(c.Request.Context(), strings.TrimSpace(payload.URL), payload.Secret, eventTypes, strings.TrimSpace(payload.TeamName))
+	if err != nil {
+		loggerFor(c, h.logger).Warn("Create webhook failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreateWebhookResponse{
+		Webhook: mappers.WebhookToDTO(sub),
+		Secret:  sub.Secret,
+	})
+}
+
+func (h *WebhookHandler) List(c *gin.Context) {
+	subs, err := h.service.ListSubscriptions(c.Request.Context())
+	if err != nil {
+		loggerFor(c, h.logger).Warn("List webhooks failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ListWebhooksResponse{Webhooks: mappers.WebhooksToDTO(subs)})
+}
+
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	var payload deleteWebhookRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	if err := h.service.DeleteSubscription(c.Request.Context(), payload.WebhookID); err != nil {
+		loggerFor(c, h.logger).Warn("Delete webhook failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Deliveries is the delivery log, newest first, optionally narrowed by
+// webhook_id and status.
+func (h *WebhookHandler) Deliveries(c *gin.Context) {
+	filter := repo.WebhookDeliveryFilter{
+		SubscriptionID: strings.TrimSpace(c.Query("webhook_id")),
+	}
+
+	if raw := strings.TrimSpace(c.Query("status")); raw != "" {
+		status, ok := types.ParseDeliveryStatus(raw)
+		if !ok {
+			respondError(c, http.StatusBadRequest, errorCodeBadRequest, "status must be pending, delivered or dead")
+			return
+		}
+		filter.Status = status
+	}
+
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 || limit > maxDeliveryListLimit {
+			respondError(c, http.StatusBadRequest, errorCodeBadRequest, "limit must be between 1 and "+strconv.Itoa(maxDeliveryListLimit))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	deliveries, err := h.service.ListDeliveries(c.Request.Context(), filter)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("List webhook deliveries failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ListWebhookDeliveriesResponse{Deliveries: mappers.WebhookDeliveriesToDTO(deliveries)})
+}
+
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	var payload redeliverRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "invalid payload")
+		return
+	}
+
+	if payload.DeliveryID <= 0 {
+		respondError(c, http.StatusBadRequest, errorCodeBadRequest, "delivery_id is required")
+		return
+	}
+
+	delivery, err := h.service.Redeliver(c.Request.Context(), payload.DeliveryID)
+	if err != nil {
+		loggerFor(c, h.logger).Warn("Redeliver webhook failed", zap.Error(err))
+		handleServiceError(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.WebhookDeliveryResponse{Delivery: mappers.WebhookDeliveryToDTO(delivery)})
+}
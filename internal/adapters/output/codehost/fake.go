@@ -0,0 +1,83 @@
+package codehost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
+)
+
+type FakeCall struct {
+	Method string
+	Ref    entities.CodeHostRef
+	Logins []string
+}
+
+type FakeClient struct {
+	mu       sync.Mutex
+	calls    []FakeCall
+	failures int
+	reject   bool
+}
+
+func NewFakeClient() *FakeClient {
+	return &FakeClient{}
+}
+
+func (c *FakeClient) FailNext(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = n
+}
+
+func (c *FakeClient) Reject(reject bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reject = reject
+}
+
+func (c *FakeClient) Calls() []FakeCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.calls)
+}
+
+func (c *FakeClient) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = nil
+	c.failures = 0
+	c.reject = false
+}
+
+func (c *FakeClient) RequestReviewers(_ context.Context, ref entities.CodeHostRef, logins []string) error {
+	return c.record("request", ref, logins)
+}
+
+func (c *FakeClient) RemoveReviewers(_ context.Context, ref entities.CodeHostRef, logins []string) error {
+	return c.record("remove", ref, logins)
+}
+
+func (c *FakeClient) record(method string, ref entities.CodeHostRef, logins []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, FakeCall{Method: method, Ref: ref, Logins: slices.Clone(logins)})
+
+	if c.reject {
+		return fmt.Errorf("%w: fake rejection", eventports.ErrCodeHostRejected)
+	}
+
+	if c.failures > 0 {
+		c.failures--
+		return errors.New("fake code host unavailable")
+	}
+
+	return nil
+}
+
+var _ eventports.CodeHostClient = (*FakeClient)(nil)
@@ -0,0 +1,94 @@
+package codehost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
+)
+
+const (
+	githubAPIVersion = "2022-11-28"
+
+	maxErrorBody = 1 << 10
+)
+
+type GitHubClient struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func NewGitHubClient(baseURL, token string, timeout time.Duration) *GitHubClient {
+	return &GitHubClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *GitHubClient) RequestReviewers(ctx context.Context, ref entities.CodeHostRef, logins []string) error {
+	return c.do(ctx, http.MethodPost, ref, logins)
+}
+
+// GitHub answers 200 for logins that were not requested, so retries are safe.
+func (c *GitHubClient) RemoveReviewers(ctx context.Context, ref entities.CodeHostRef, logins []string) error {
+	return c.do(ctx, http.MethodDelete, ref, logins)
+}
+
+func (c *GitHubClient) do(ctx context.Context, method string, ref entities.CodeHostRef, logins []string) error {
+	body, err := json.Marshal(map[string][]string{"reviewers": logins})
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/pulls/%d/requested_reviewers", c.baseURL, ref.Repository, ref.Number)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("X-GitHub-Api-Version", githubAPIVersion)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+		return nil
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	err = fmt.Errorf("github %s %s: status %d: %s", method, ref.ID(), resp.StatusCode, strings.TrimSpace(string(detail)))
+
+	if isPermanentStatus(resp.StatusCode) {
+		return fmt.Errorf("%w: %w", eventports.ErrCodeHostRejected, err)
+	}
+
+	return err
+}
+
+// GitHub reports secondary rate limits as 403, so those are retried as well.
+func isPermanentStatus(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusForbidden:
+		return false
+	}
+
+	return status >= 400 && status < 500
+}
+
+var _ eventports.CodeHostClient = (*GitHubClient)(nil)
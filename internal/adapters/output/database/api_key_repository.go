@@ -0,0 +1,208 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/logger"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// apiKeyTouchInterval throttles last_used_at writes so authentication does not
+// turn every request into an UPDATE.
+const apiKeyTouchInterval = time.Minute
+
+type APIKeyRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewAPIKeyRepository(db DB, logger *zap.Logger) *APIKeyRepository {
+	return &APIKeyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	const query = `
+		INSERT INTO api_keys (key_id, name, key_hash, scopes, team_names, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	if _, err := r.dbFor(ctx).Exec(ctx, query,
+		key.ID,
+		key.Name,
+		key.Hash,
+		scopesToStrings(key.Scopes),
+		key.Teams,
+		key.CreatedAt,
+	); err != nil {
+		r.log(ctx).Error("Failed to create api key",
+			zap.String("key_id", key.ID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepository) GetByID(ctx context.Context, keyID string) (*entities.APIKey, error) {
+	const query = `
+		SELECT key_id, name, key_hash, scopes, team_names, created_at, last_used_at, revoked_at
+		FROM api_keys
+		WHERE key_id = $1
+	`
+
+	key, err := scanAPIKey(r.dbFor(ctx).QueryRow(ctx, query, keyID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.NotFound(fmt.Sprintf("api key %s", keyID))
+		}
+
+		r.log(ctx).Error("Failed to get api key",
+			zap.String("key_id", keyID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) List(ctx context.Context) ([]*entities.APIKey, error) {
+	const query = `
+		SELECT key_id, name, key_hash, scopes, team_names, created_at, last_used_at, revoked_at
+		FROM api_keys
+		ORDER BY created_at ASC, key_id ASC
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query)
+	if err != nil {
+		r.log(ctx).Error("Failed to list api keys", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*entities.APIKey
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			r.log(ctx).Error("Failed to scan api key row", zap.Error(err))
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing api keys", zap.Error(err))
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, keyID string, at time.Time) (*entities.APIKey, error) {
+	const query = `
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, $2)
+		WHERE key_id = $1
+		RETURNING key_id, name, key_hash, scopes, team_names, created_at, last_used_at, revoked_at
+	`
+
+	key, err := scanAPIKey(r.dbFor(ctx).QueryRow(ctx, query, keyID, at))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.log(ctx).Warn("Api key not found while revoking",
+				zap.String("key_id", keyID))
+			return nil, domainErrors.NotFound(fmt.Sprintf("api key %s", keyID))
+		}
+
+		r.log(ctx).Error("Failed to revoke api key",
+			zap.String("key_id", keyID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, keyID string, at time.Time) error {
+	const query = `
+		UPDATE api_keys
+		SET last_used_at = $2
+		WHERE key_id = $1
+		  AND (last_used_at IS NULL OR last_used_at < $3)
+	`
+
+	if _, err := r.dbFor(ctx).Exec(ctx, query, keyID, at, at.Add(-apiKeyTouchInterval)); err != nil {
+		r.log(ctx).Error("Failed to update api key usage",
+			zap.String("key_id", keyID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func scanAPIKey(row rowScanner) (*entities.APIKey, error) {
+	var (
+		key        entities.APIKey
+		scopes     []string
+		lastUsedAt sql.NullTime
+		revokedAt  sql.NullTime
+	)
+
+	if err := row.Scan(&key.ID, &key.Name, &key.Hash, &scopes, &key.Teams, &key.CreatedAt, &lastUsedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+
+	key.Scopes = make([]types.Scope, 0, len(scopes))
+	for _, raw := range scopes {
+		if scope, ok := types.ParseScope(raw); ok {
+			key.Scopes = append(key.Scopes, scope)
+		}
+	}
+
+	if lastUsedAt.Valid {
+		t := lastUsedAt.Time.UTC()
+		key.LastUsedAt = &t
+	}
+
+	if revokedAt.Valid {
+		t := revokedAt.Time.UTC()
+		key.RevokedAt = &t
+	}
+
+	return &key, nil
+}
+
+func scopesToStrings(scopes []types.Scope) []string {
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		result = append(result, scope.String())
+	}
+
+	return result
+}
+
+func (r *APIKeyRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
+
+func (r *APIKeyRepository) dbFor(ctx context.Context) DB {
+	if tx := DBFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	return r.db
+}
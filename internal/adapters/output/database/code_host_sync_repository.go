@@ -0,0 +1,196 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/logger"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type CodeHostSyncRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewCodeHostSyncRepository(db DB, logger *zap.Logger) *CodeHostSyncRepository {
+	return &CodeHostSyncRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *CodeHostSyncRepository) Enqueue(ctx context.Context, prID string, removedReviewers []string, at time.Time) error {
+	const query = `
+		INSERT INTO code_host_sync_jobs (pull_request_id, removed_reviewers, status, attempts, next_attempt_at, revision, updated_at)
+		VALUES ($1, $2, 'pending', 0, $3, 1, $3)
+		ON CONFLICT (pull_request_id) DO UPDATE
+		SET removed_reviewers = ARRAY(
+		        SELECT DISTINCT unnest(code_host_sync_jobs.removed_reviewers || EXCLUDED.removed_reviewers)
+		    ),
+		    status = 'pending',
+		    attempts = 0,
+		    next_attempt_at = EXCLUDED.next_attempt_at,
+		    last_error = NULL,
+		    revision = code_host_sync_jobs.revision + 1,
+		    updated_at = EXCLUDED.updated_at
+	`
+
+	if removedReviewers == nil {
+		removedReviewers = []string{}
+	}
+
+	if _, err := r.dbFor(ctx).Exec(ctx, query, prID, removedReviewers, at.UTC()); err != nil {
+		r.log(ctx).Error("Failed to enqueue code host sync",
+			zap.String("pr_id", prID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *CodeHostSyncRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.CodeHostSyncJob, error) {
+	const query = `
+		UPDATE code_host_sync_jobs
+		SET next_attempt_at = $2
+		WHERE pull_request_id IN (
+			SELECT pull_request_id
+			FROM code_host_sync_jobs
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, pull_request_id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + codeHostSyncJobColumns
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		r.log(ctx).Error("Failed to claim code host sync jobs", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*entities.CodeHostSyncJob
+
+	for rows.Next() {
+		job, err := scanCodeHostSyncJob(rows)
+		if err != nil {
+			r.log(ctx).Error("Failed to scan code host sync job", zap.Error(err))
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while claiming code host sync jobs", zap.Error(err))
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *CodeHostSyncRepository) Update(ctx context.Context, job *entities.CodeHostSyncJob) (bool, error) {
+	const query = `
+		UPDATE code_host_sync_jobs
+		SET removed_reviewers = $2,
+		    status = $3,
+		    attempts = $4,
+		    next_attempt_at = $5,
+		    last_error = $6,
+		    updated_at = $7
+		WHERE pull_request_id = $1 AND revision = $8
+	`
+
+	removed := job.RemovedReviewers
+	if removed == nil {
+		removed = []string{}
+	}
+
+	tag, err := r.dbFor(ctx).Exec(ctx, query,
+		job.PullRequestID,
+		removed,
+		job.Status.String(),
+		job.Attempts,
+		job.NextAttemptAt,
+		optionalString(job.LastError),
+		job.UpdatedAt,
+		job.Revision,
+	)
+	if err != nil {
+		r.log(ctx).Error("Failed to update code host sync job",
+			zap.String("pr_id", job.PullRequestID),
+			zap.Error(err))
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *CodeHostSyncRepository) Get(ctx context.Context, prID string) (*entities.CodeHostSyncJob, error) {
+	query := `SELECT ` + codeHostSyncJobColumns + ` FROM code_host_sync_jobs WHERE pull_request_id = $1`
+
+	job, err := scanCodeHostSyncJob(r.dbFor(ctx).QueryRow(ctx, query, prID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.NotFound(fmt.Sprintf("code host sync of pull request %s", prID))
+		}
+
+		r.log(ctx).Error("Failed to get code host sync job",
+			zap.String("pr_id", prID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return job, nil
+}
+
+const codeHostSyncJobColumns = `
+	pull_request_id, removed_reviewers, status, attempts, next_attempt_at,
+	last_error, revision, updated_at`
+
+func scanCodeHostSyncJob(row rowScanner) (*entities.CodeHostSyncJob, error) {
+	var (
+		job       entities.CodeHostSyncJob
+		status    string
+		lastError sql.NullString
+	)
+
+	if err := row.Scan(
+		&job.PullRequestID,
+		&job.RemovedReviewers,
+		&status,
+		&job.Attempts,
+		&job.NextAttemptAt,
+		&lastError,
+		&job.Revision,
+		&job.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	job.Status = types.DeliveryStatus(status)
+	job.LastError = lastError.String
+	return &job, nil
+}
+
+func (r *CodeHostSyncRepository) dbFor(ctx context.Context) DB {
+	if tx := DBFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *CodeHostSyncRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
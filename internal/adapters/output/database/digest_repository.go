@@ -0,0 +1,161 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+type DigestRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewDigestRepository(db DB, logger *zap.Logger) *DigestRepository {
+	return &DigestRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *DigestRepository) ListRecipients(ctx context.Context) ([]*entities.DigestRecipient, error) {
+	const query = `
+		SELECT u.user_id, u.username, u.team_name, u.is_active, u.email, u.timezone, u.digest_enabled, u.created_at, u.updated_at,
+		       (SELECT MAX(d.digest_date) FROM review_digests d WHERE d.user_id = u.user_id)
+		FROM users u
+		WHERE u.is_active
+		  AND u.digest_enabled
+		  AND EXISTS (
+			SELECT 1
+			FROM pr_reviewers r
+			JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+			WHERE r.user_id = u.user_id AND pr.status = 'OPEN'
+		  )
+		ORDER BY u.user_id
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query)
+	if err != nil {
+		r.log(ctx).Error("Failed to list digest recipients", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []*entities.DigestRecipient
+	for rows.Next() {
+		var lastSentOn *time.Time
+		user, err := scanUser(scannerWithTail{row: rows, tail: []any{&lastSentOn}})
+		if err != nil {
+			r.log(ctx).Error("Failed to scan digest recipient", zap.Error(err))
+			return nil, err
+		}
+
+		recipient := &entities.DigestRecipient{User: user}
+		if lastSentOn != nil {
+			recipient.LastSentOn = *lastSentOn
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing digest recipients", zap.Error(err))
+		return nil, err
+	}
+
+	return recipients, nil
+}
+
+func (r *DigestRepository) ListPendingReviews(ctx context.Context, userID string) ([]*entities.PendingReview, error) {
+	const query = `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, COALESCE(a.username, ''), pr.created_at, r.assigned_at
+		FROM pr_reviewers r
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		LEFT JOIN users a ON a.user_id = pr.author_id
+		WHERE r.user_id = $1 AND pr.status = 'OPEN'
+		ORDER BY pr.created_at, pr.pull_request_id
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, userID)
+	if err != nil {
+		r.log(ctx).Error("Failed to list pending reviews",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reviews []*entities.PendingReview
+	for rows.Next() {
+		review := &entities.PendingReview{}
+		if err := rows.Scan(
+			&review.PullRequestID,
+			&review.PullRequestName,
+			&review.AuthorID,
+			&review.AuthorName,
+			&review.CreatedAt,
+			&review.AssignedAt,
+		); err != nil {
+			r.log(ctx).Error("Failed to scan pending review",
+				zap.String("user_id", userID),
+				zap.Error(err))
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing pending reviews",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *DigestRepository) Claim(ctx context.Context, userID string, day time.Time, reviews int, at time.Time) (bool, error) {
+	const query = `
+		INSERT INTO review_digests (user_id, digest_date, review_count, sent_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, digest_date) DO NOTHING
+	`
+
+	tag, err := r.dbFor(ctx).Exec(ctx, query, userID, day, reviews, at)
+	if err != nil {
+		r.log(ctx).Error("Failed to claim review digest",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *DigestRepository) Release(ctx context.Context, userID string, day time.Time) error {
+	const query = `DELETE FROM review_digests WHERE user_id = $1 AND digest_date = $2`
+
+	if _, err := r.dbFor(ctx).Exec(ctx, query, userID, day); err != nil {
+		r.log(ctx).Error("Failed to release review digest",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *DigestRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
+
+func (r *DigestRepository) dbFor(ctx context.Context) DB {
+	if tx := DBFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	return r.db
+}
@@ -0,0 +1,295 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	"pr-reviewer-assignment/internal/logger"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const defaultEscalationListLimit = 100
+
+type EscalationRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewEscalationRepository(db DB, logger *zap.Logger) *EscalationRepository {
+	return &EscalationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *EscalationRepository) UpsertSLA(ctx context.Context, sla *entities.ReviewSLA) error {
+	const query = `
+		INSERT INTO review_slas (team_name, remind_after_seconds, escalate_after_seconds, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (team_name) DO UPDATE
+		SET remind_after_seconds = EXCLUDED.remind_after_seconds,
+		    escalate_after_seconds = EXCLUDED.escalate_after_seconds,
+		    updated_at = EXCLUDED.updated_at
+	`
+
+	var escalateAfter any
+	if sla.EscalateAfter > 0 {
+		escalateAfter = int64(sla.EscalateAfter / time.Second)
+	}
+
+	if _, err := r.dbFor(ctx).Exec(ctx, query,
+		sla.TeamName,
+		int64(sla.RemindAfter/time.Second),
+		escalateAfter,
+		sla.UpdatedAt,
+	); err != nil {
+		if isPgError(err, pgCodeForeignKeyViolation) {
+			return domainErrors.NotFound(fmt.Sprintf("team %s", sla.TeamName))
+		}
+
+		r.log(ctx).Error("Failed to upsert review SLA",
+			zap.String("team_name", sla.TeamName),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *EscalationRepository) ListSLAs(ctx context.Context) ([]*entities.ReviewSLA, error) {
+	const query = `
+		SELECT team_name, remind_after_seconds, escalate_after_seconds, updated_at
+		FROM review_slas
+		ORDER BY team_name
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query)
+	if err != nil {
+		r.log(ctx).Error("Failed to list review SLAs", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slas []*entities.ReviewSLA
+
+	for rows.Next() {
+		var (
+			sla           entities.ReviewSLA
+			remindAfter   int64
+			escalateAfter sql.NullInt64
+		)
+
+		if err := rows.Scan(&sla.TeamName, &remindAfter, &escalateAfter, &sla.UpdatedAt); err != nil {
+			r.log(ctx).Error("Failed to scan review SLA row", zap.Error(err))
+			return nil, err
+		}
+
+		sla.RemindAfter = time.Duration(remindAfter) * time.Second
+		sla.EscalateAfter = time.Duration(escalateAfter.Int64) * time.Second
+		slas = append(slas, &sla)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing review SLAs", zap.Error(err))
+		return nil, err
+	}
+
+	return slas, nil
+}
+
+func (r *EscalationRepository) DeleteSLA(ctx context.Context, teamName string) error {
+	tag, err := r.dbFor(ctx).Exec(ctx, `DELETE FROM review_slas WHERE team_name = $1`, teamName)
+	if err != nil {
+		r.log(ctx).Error("Failed to delete review SLA",
+			zap.String("team_name", teamName),
+			zap.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domainErrors.NotFound(fmt.Sprintf("review SLA of team %s", teamName))
+	}
+
+	return nil
+}
+
+func (r *EscalationRepository) ListOverdue(ctx context.Context, now time.Time, limit int) ([]*entities.OverdueReview, error) {
+	const query = `
+		SELECT
+			pr.pull_request_id, pr.pull_request_name, pr.author_id, sla.team_name,
+			rev.user_id, rev.assigned_at, reminded.escalation_id IS NOT NULL,
+			sla.remind_after_seconds, sla.escalate_after_seconds, sla.updated_at
+		FROM pr_reviewers rev
+		JOIN pull_requests pr ON pr.pull_request_id = rev.pull_request_id
+		JOIN users author ON author.user_id = pr.author_id
+		JOIN review_slas sla ON sla.team_name = author.team_name
+		LEFT JOIN review_escalations reminded
+		       ON reminded.pull_request_id = rev.pull_request_id
+		      AND reminded.reviewer_id = rev.user_id
+		      AND reminded.assigned_at = rev.assigned_at
+		      AND reminded.action = 'reminded'
+		WHERE pr.status = 'OPEN'
+		  AND rev.assigned_at <= $1::timestamp - sla.remind_after_seconds * INTERVAL '1 second'
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM review_escalations done
+			WHERE done.pull_request_id = rev.pull_request_id
+			  AND done.reviewer_id = rev.user_id
+			  AND done.assigned_at = rev.assigned_at
+			  AND done.action <> 'reminded'
+		  )
+		  AND (
+			reminded.escalation_id IS NULL
+			OR rev.assigned_at <= $1::timestamp - sla.escalate_after_seconds * INTERVAL '1 second'
+		  )
+		ORDER BY rev.assigned_at, rev.pull_request_id, rev.user_id
+		LIMIT $2
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, now, limit)
+	if err != nil {
+		r.log(ctx).Error("Failed to list overdue reviews", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reviews []*entities.OverdueReview
+
+	for rows.Next() {
+		var (
+			review        entities.OverdueReview
+			remindAfter   int64
+			escalateAfter sql.NullInt64
+		)
+
+		if err := rows.Scan(
+			&review.PullRequestID,
+			&review.PullRequestName,
+			&review.AuthorID,
+			&review.TeamName,
+			&review.ReviewerID,
+			&review.AssignedAt,
+			&review.Reminded,
+			&remindAfter,
+			&escalateAfter,
+			&review.SLA.UpdatedAt,
+		); err != nil {
+			r.log(ctx).Error("Failed to scan overdue review row", zap.Error(err))
+			return nil, err
+		}
+
+		review.SLA.TeamName = review.TeamName
+		review.SLA.RemindAfter = time.Duration(remindAfter) * time.Second
+		review.SLA.EscalateAfter = time.Duration(escalateAfter.Int64) * time.Second
+		reviews = append(reviews, &review)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing overdue reviews", zap.Error(err))
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *EscalationRepository) Record(ctx context.Context, e *entities.ReviewEscalation) (bool, error) {
+	const query = `
+		INSERT INTO review_escalations (pull_request_id, team_name, reviewer_id, assigned_at, action, replaced_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (pull_request_id, reviewer_id, assigned_at, action) DO NOTHING
+		RETURNING escalation_id
+	`
+
+	err := r.dbFor(ctx).QueryRow(ctx, query,
+		e.PullRequestID,
+		e.TeamName,
+		e.ReviewerID,
+		e.AssignedAt,
+		e.Action.String(),
+		optionalString(e.ReplacedBy),
+		e.CreatedAt,
+	).Scan(&e.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+
+		r.log(ctx).Error("Failed to record review escalation",
+			zap.String("pr_id", e.PullRequestID),
+			zap.String("reviewer_id", e.ReviewerID),
+			zap.Error(err))
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *EscalationRepository) ListEscalations(ctx context.Context, filter repo.EscalationFilter) ([]*entities.ReviewEscalation, error) {
+	const query = `
+		SELECT escalation_id, pull_request_id, team_name, reviewer_id, assigned_at, action, replaced_by, created_at
+		FROM review_escalations
+		WHERE ($1 = '' OR team_name = $1)
+		  AND ($2 = '' OR pull_request_id = $2)
+		ORDER BY escalation_id DESC
+		LIMIT $3
+	`
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultEscalationListLimit
+	}
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, filter.TeamName, filter.PullRequestID, limit)
+	if err != nil {
+		r.log(ctx).Error("Failed to list review escalations", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var escalations []*entities.ReviewEscalation
+
+	for rows.Next() {
+		var (
+			e          entities.ReviewEscalation
+			action     string
+			replacedBy sql.NullString
+		)
+
+		if err := rows.Scan(&e.ID, &e.PullRequestID, &e.TeamName, &e.ReviewerID, &e.AssignedAt, &action, &replacedBy, &e.CreatedAt); err != nil {
+			r.log(ctx).Error("Failed to scan review escalation row", zap.Error(err))
+			return nil, err
+		}
+
+		e.Action = types.EscalationAction(action)
+		e.ReplacedBy = replacedBy.String
+		escalations = append(escalations, &e)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing review escalations", zap.Error(err))
+		return nil, err
+	}
+
+	return escalations, nil
+}
+
+func (r *EscalationRepository) dbFor(ctx context.Context) DB {
+	if tx := DBFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *EscalationRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
@@ -0,0 +1,191 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/logger"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type ExternalAccountRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewExternalAccountRepository(db DB, logger *zap.Logger) *ExternalAccountRepository {
+	return &ExternalAccountRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *ExternalAccountRepository) Link(ctx context.Context, account *entities.ExternalAccount) error {
+	const query = `
+		INSERT INTO external_accounts (provider, login, user_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, login) DO UPDATE
+		SET user_id = EXCLUDED.user_id
+	`
+
+	if _, err := r.dbFor(ctx).Exec(ctx, query,
+		account.Provider.String(),
+		account.Login,
+		account.UserID,
+		account.CreatedAt,
+	); err != nil {
+		if isPgError(err, pgCodeForeignKeyViolation) {
+			return domainErrors.NotFound(fmt.Sprintf("user %s", account.UserID))
+		}
+
+		r.log(ctx).Error("Failed to link external account",
+			zap.String("provider", account.Provider.String()),
+			zap.String("login", account.Login),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *ExternalAccountRepository) Unlink(ctx context.Context, provider types.Provider, login string) error {
+	const query = `
+		DELETE FROM external_accounts
+		WHERE provider = $1 AND login = $2
+	`
+
+	tag, err := r.dbFor(ctx).Exec(ctx, query, provider.String(), login)
+	if err != nil {
+		r.log(ctx).Error("Failed to unlink external account",
+			zap.String("provider", provider.String()),
+			zap.String("login", login),
+			zap.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domainErrors.NotFound(fmt.Sprintf("%s account %s", provider, login))
+	}
+
+	return nil
+}
+
+func (r *ExternalAccountRepository) ResolveUser(ctx context.Context, provider types.Provider, login string) (string, error) {
+	const query = `
+		SELECT user_id
+		FROM external_accounts
+		WHERE provider = $1 AND login = $2
+	`
+
+	var userID string
+	if err := r.dbFor(ctx).QueryRow(ctx, query, provider.String(), login).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", domainErrors.NotFound(fmt.Sprintf("%s account %s", provider, login))
+		}
+
+		r.log(ctx).Error("Failed to resolve external account",
+			zap.String("provider", provider.String()),
+			zap.String("login", login),
+			zap.Error(err))
+		return "", err
+	}
+
+	return userID, nil
+}
+
+func (r *ExternalAccountRepository) LoginsByUsers(ctx context.Context, provider types.Provider, userIDs []string) (map[string]string, error) {
+	const query = `
+		SELECT DISTINCT ON (user_id) user_id, login
+		FROM external_accounts
+		WHERE provider = $1 AND user_id = ANY($2)
+		ORDER BY user_id ASC, created_at DESC
+	`
+
+	logins := make(map[string]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return logins, nil
+	}
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, provider.String(), userIDs)
+	if err != nil {
+		r.log(ctx).Error("Failed to load external logins",
+			zap.String("provider", provider.String()),
+			zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID, login string
+		if err := rows.Scan(&userID, &login); err != nil {
+			r.log(ctx).Error("Failed to scan external login row", zap.Error(err))
+			return nil, err
+		}
+		logins[userID] = login
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while loading external logins", zap.Error(err))
+		return nil, err
+	}
+
+	return logins, nil
+}
+
+func (r *ExternalAccountRepository) List(ctx context.Context, provider types.Provider) ([]*entities.ExternalAccount, error) {
+	const query = `
+		SELECT provider, login, user_id, created_at
+		FROM external_accounts
+		WHERE $1 = '' OR provider = $1
+		ORDER BY provider ASC, login ASC
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, provider.String())
+	if err != nil {
+		r.log(ctx).Error("Failed to list external accounts", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []*entities.ExternalAccount
+
+	for rows.Next() {
+		var (
+			account     entities.ExternalAccount
+			rawProvider string
+		)
+
+		if err := rows.Scan(&rawProvider, &account.Login, &account.UserID, &account.CreatedAt); err != nil {
+			r.log(ctx).Error("Failed to scan external account row", zap.Error(err))
+			return nil, err
+		}
+
+		account.Provider = types.Provider(rawProvider)
+		accounts = append(accounts, &account)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing external accounts", zap.Error(err))
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (r *ExternalAccountRepository) dbFor(ctx context.Context) DB {
+	if tx := DBFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *ExternalAccountRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
 )
@@ -23,3 +24,38 @@ func isPgError(err error, code string) bool {
 
 	return false
 }
+
+func optionalTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+
+	return t.UTC()
+}
+
+func optionalString(s string) any {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+func optionalInt(n int) any {
+	if n == 0 {
+		return nil
+	}
+
+	return n
+}
+
+// scannerWithTail lets the shared scan helpers read a row that carries extra
+// columns after theirs.
+type scannerWithTail struct {
+	row  rowScanner
+	tail []any
+}
+
+func (s scannerWithTail) Scan(dest ...any) error {
+	return s.row.Scan(append(dest, s.tail...)...)
+}
@@ -0,0 +1,175 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/logger"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type IdempotencyRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewIdempotencyRepository(db DB, logger *zap.Logger) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *entities.IdempotencyRecord, staleBefore time.Time) (bool, error) {
+	const query = `
+		INSERT INTO idempotency_keys (owner, idempotency_key, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (owner, idempotency_key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+		    status_code = NULL,
+		    content_type = NULL,
+		    etag = NULL,
+		    response_body = NULL,
+		    created_at = EXCLUDED.created_at,
+		    expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+		   OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < $6)
+		RETURNING owner
+	`
+
+	var owner string
+	err := r.dbFor(ctx).QueryRow(ctx, query,
+		record.Owner,
+		record.Key,
+		record.RequestHash,
+		record.CreatedAt,
+		record.ExpiresAt,
+		staleBefore,
+	).Scan(&owner)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+
+		r.log(ctx).Error("Failed to reserve idempotency key",
+			zap.String("idempotency_key", record.Key),
+			zap.Error(err))
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *IdempotencyRepository) Get(ctx context.Context, owner, key string) (*entities.IdempotencyRecord, error) {
+	const query = `
+		SELECT owner, idempotency_key, request_hash, status_code, content_type, etag, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE owner = $1 AND idempotency_key = $2
+	`
+
+	var (
+		record      entities.IdempotencyRecord
+		statusCode  sql.NullInt32
+		contentType sql.NullString
+		etag        sql.NullString
+	)
+
+	err := r.dbFor(ctx).QueryRow(ctx, query, owner, key).Scan(
+		&record.Owner,
+		&record.Key,
+		&record.RequestHash,
+		&statusCode,
+		&contentType,
+		&etag,
+		&record.ResponseBody,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.NotFound(fmt.Sprintf("idempotency key %s", key))
+		}
+
+		r.log(ctx).Error("Failed to get idempotency key",
+			zap.String("idempotency_key", key),
+			zap.Error(err))
+		return nil, err
+	}
+
+	record.StatusCode = int(statusCode.Int32)
+	record.ContentType = contentType.String
+	record.ETag = etag.String
+
+	return &record, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *entities.IdempotencyRecord) error {
+	const query = `
+		UPDATE idempotency_keys
+		SET status_code = $3, content_type = $4, etag = $5, response_body = $6
+		WHERE owner = $1 AND idempotency_key = $2
+	`
+
+	if _, err := r.dbFor(ctx).Exec(ctx, query,
+		record.Owner,
+		record.Key,
+		record.StatusCode,
+		record.ContentType,
+		optionalString(record.ETag),
+		record.ResponseBody,
+	); err != nil {
+		r.log(ctx).Error("Failed to store idempotent response",
+			zap.String("idempotency_key", record.Key),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, owner, key string) error {
+	const query = `
+		DELETE FROM idempotency_keys
+		WHERE owner = $1 AND idempotency_key = $2 AND status_code IS NULL
+	`
+
+	if _, err := r.dbFor(ctx).Exec(ctx, query, owner, key); err != nil {
+		r.log(ctx).Error("Failed to release idempotency key",
+			zap.String("idempotency_key", key),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	const query = `DELETE FROM idempotency_keys WHERE expires_at <= $1`
+
+	tag, err := r.dbFor(ctx).Exec(ctx, query, now)
+	if err != nil {
+		r.log(ctx).Error("Failed to delete expired idempotency keys", zap.Error(err))
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *IdempotencyRepository) dbFor(ctx context.Context) DB {
+	if tx := DBFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *IdempotencyRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
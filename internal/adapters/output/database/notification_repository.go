@@ -0,0 +1,329 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/logger"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type NotificationRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewNotificationRepository(db DB, logger *zap.Logger) *NotificationRepository {
+	return &NotificationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *NotificationRepository) UpsertChannel(ctx context.Context, channel *entities.ChatChannel) error {
+	const query = `
+		INSERT INTO chat_channels (team_name, webhook_url, channel, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (team_name) DO UPDATE
+		SET webhook_url = EXCLUDED.webhook_url,
+		    channel = EXCLUDED.channel,
+		    updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.dbFor(ctx).Exec(ctx, query,
+		channel.TeamName,
+		channel.WebhookURL,
+		optionalString(channel.Channel),
+		channel.UpdatedAt,
+	); err != nil {
+		if isPgError(err, pgCodeForeignKeyViolation) {
+			return domainErrors.NotFound(fmt.Sprintf("team %s", channel.TeamName))
+		}
+
+		r.log(ctx).Error("Failed to upsert chat channel",
+			zap.String("team_name", channel.TeamName),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) GetChannel(ctx context.Context, teamName string) (*entities.ChatChannel, error) {
+	const query = `
+		SELECT team_name, webhook_url, channel, updated_at
+		FROM chat_channels
+		WHERE team_name = $1
+	`
+
+	channel, err := scanChatChannel(r.dbFor(ctx).QueryRow(ctx, query, teamName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.NotFound(fmt.Sprintf("chat channel of team %s", teamName))
+		}
+
+		r.log(ctx).Error("Failed to get chat channel",
+			zap.String("team_name", teamName),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+func (r *NotificationRepository) ListChannels(ctx context.Context) ([]*entities.ChatChannel, error) {
+	const query = `
+		SELECT team_name, webhook_url, channel, updated_at
+		FROM chat_channels
+		ORDER BY team_name
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query)
+	if err != nil {
+		r.log(ctx).Error("Failed to list chat channels", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var channels []*entities.ChatChannel
+
+	for rows.Next() {
+		channel, err := scanChatChannel(rows)
+		if err != nil {
+			r.log(ctx).Error("Failed to scan chat channel row", zap.Error(err))
+			return nil, err
+		}
+
+		channels = append(channels, channel)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing chat channels", zap.Error(err))
+		return nil, err
+	}
+
+	return channels, nil
+}
+
+func (r *NotificationRepository) DeleteChannel(ctx context.Context, teamName string) error {
+	tag, err := r.dbFor(ctx).Exec(ctx, `DELETE FROM chat_channels WHERE team_name = $1`, teamName)
+	if err != nil {
+		r.log(ctx).Error("Failed to delete chat channel",
+			zap.String("team_name", teamName),
+			zap.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domainErrors.NotFound(fmt.Sprintf("chat channel of team %s", teamName))
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) Enqueue(ctx context.Context, n *entities.ChatNotification) error {
+	const query = `
+		INSERT INTO chat_notifications (
+			team_name, event_id, kind, pull_request_id, pull_request_name, author_id,
+			reviewer_id, old_reviewer_id, assigned_at, review_count, status, next_attempt_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, COALESCE((
+			SELECT MIN(next_attempt_at)
+			FROM chat_notifications
+			WHERE team_name = $1 AND status = 'pending' AND attempts = 0 AND next_attempt_at > $13
+		), $12), $13)
+		ON CONFLICT (event_id) DO NOTHING
+		RETURNING notification_id, next_attempt_at
+	`
+
+	var eventID any
+	if n.EventID != 0 {
+		eventID = n.EventID
+	}
+
+	err := r.dbFor(ctx).QueryRow(ctx, query,
+		n.TeamName,
+		eventID,
+		n.Kind.String(),
+		n.PullRequestID,
+		n.PullRequestName,
+		n.AuthorID,
+		optionalString(n.ReviewerID),
+		optionalString(n.OldReviewerID),
+		optionalTime(n.AssignedAt),
+		optionalInt(n.ReviewCount),
+		n.Status.String(),
+		n.NextAttemptAt,
+		n.CreatedAt,
+	).Scan(&n.ID, &n.NextAttemptAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		r.log(ctx).Error("Failed to enqueue chat notification",
+			zap.String("team_name", n.TeamName),
+			zap.Int64("event_id", n.EventID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.ChatNotification, error) {
+	const query = `
+		UPDATE chat_notifications
+		SET next_attempt_at = $2
+		WHERE notification_id IN (
+			SELECT notification_id
+			FROM chat_notifications
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, notification_id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + chatNotificationColumns
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		r.log(ctx).Error("Failed to claim chat notifications", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*entities.ChatNotification
+
+	for rows.Next() {
+		n, err := scanChatNotification(rows)
+		if err != nil {
+			r.log(ctx).Error("Failed to scan chat notification", zap.Error(err))
+			return nil, err
+		}
+
+		notifications = append(notifications, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while claiming chat notifications", zap.Error(err))
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepository) Update(ctx context.Context, n *entities.ChatNotification) error {
+	const query = `
+		UPDATE chat_notifications
+		SET status = $2,
+		    attempts = $3,
+		    next_attempt_at = $4,
+		    last_error = $5,
+		    sent_at = $6
+		WHERE notification_id = $1
+	`
+
+	if _, err := r.dbFor(ctx).Exec(ctx, query,
+		n.ID,
+		n.Status.String(),
+		n.Attempts,
+		n.NextAttemptAt,
+		optionalString(n.LastError),
+		optionalTime(n.SentAt),
+	); err != nil {
+		r.log(ctx).Error("Failed to update chat notification",
+			zap.Int64("notification_id", n.ID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+const chatNotificationColumns = `
+	notification_id, team_name, event_id, kind, pull_request_id, pull_request_name,
+	author_id, reviewer_id, old_reviewer_id, assigned_at, review_count, status, attempts,
+	next_attempt_at, last_error, created_at, sent_at`
+
+func scanChatChannel(row rowScanner) (*entities.ChatChannel, error) {
+	var (
+		channel entities.ChatChannel
+		name    sql.NullString
+	)
+
+	if err := row.Scan(&channel.TeamName, &channel.WebhookURL, &name, &channel.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	channel.Channel = name.String
+	return &channel, nil
+}
+
+func scanChatNotification(row rowScanner) (*entities.ChatNotification, error) {
+	var (
+		n             entities.ChatNotification
+		eventID       sql.NullInt64
+		kind          string
+		reviewerID    sql.NullString
+		oldReviewerID sql.NullString
+		assignedAt    sql.NullTime
+		reviewCount   sql.NullInt32
+		status        string
+		lastError     sql.NullString
+		sentAt        sql.NullTime
+	)
+
+	if err := row.Scan(
+		&n.ID,
+		&n.TeamName,
+		&eventID,
+		&kind,
+		&n.PullRequestID,
+		&n.PullRequestName,
+		&n.AuthorID,
+		&reviewerID,
+		&oldReviewerID,
+		&assignedAt,
+		&reviewCount,
+		&status,
+		&n.Attempts,
+		&n.NextAttemptAt,
+		&lastError,
+		&n.CreatedAt,
+		&sentAt,
+	); err != nil {
+		return nil, err
+	}
+
+	n.EventID = eventID.Int64
+	n.Kind = types.NotificationKind(kind)
+	n.ReviewerID = reviewerID.String
+	n.OldReviewerID = oldReviewerID.String
+	n.Status = types.DeliveryStatus(status)
+	n.LastError = lastError.String
+	n.ReviewCount = int(reviewCount.Int32)
+	if assignedAt.Valid {
+		n.AssignedAt = &assignedAt.Time
+	}
+	if sentAt.Valid {
+		n.SentAt = &sentAt.Time
+	}
+
+	return &n, nil
+}
+
+func (r *NotificationRepository) dbFor(ctx context.Context) DB {
+	if tx := DBFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *NotificationRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
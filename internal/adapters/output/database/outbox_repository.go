@@ -0,0 +1,292 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	"pr-reviewer-assignment/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+// outboxRelayLockID keys the transaction-scoped advisory lock that keeps a
+// single relay delivering events, which is what preserves their order.
+const outboxRelayLockID = 40_700_001
+
+type OutboxRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewOutboxRepository(db DB, logger *zap.Logger) *OutboxRepository {
+	return &OutboxRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type eventPayload struct {
+	PullRequestID   string     `json:"pull_request_id,omitempty"`
+	PullRequestName string     `json:"pull_request_name,omitempty"`
+	AuthorID        string     `json:"author_id,omitempty"`
+	Status          string     `json:"status,omitempty"`
+	Reviewers       []string   `json:"reviewers,omitempty"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	MergedAt        *time.Time `json:"merged_at,omitempty"`
+	Version         int        `json:"version,omitempty"`
+	ReviewerID      string     `json:"reviewer_id,omitempty"`
+	OldReviewerID   string     `json:"old_reviewer_id,omitempty"`
+	UserID          string     `json:"user_id,omitempty"`
+}
+
+func (r *OutboxRepository) Append(ctx context.Context, events ...*entities.Event) error {
+	const query = `
+		INSERT INTO outbox_events (event_type, team_name, payload, occurred_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	db := r.dbFor(ctx)
+
+	for _, event := range events {
+		payload, err := json.Marshal(eventPayload{
+			PullRequestID:   event.Data.PullRequestID,
+			PullRequestName: event.Data.PullRequestName,
+			AuthorID:        event.Data.AuthorID,
+			Status:          event.Data.Status.String(),
+			Reviewers:       event.Data.Reviewers,
+			CreatedAt:       optionalTimeValue(event.Data.CreatedAt),
+			MergedAt:        event.Data.MergedAt,
+			Version:         event.Data.Version,
+			ReviewerID:      event.Data.ReviewerID,
+			OldReviewerID:   event.Data.OldReviewerID,
+			UserID:          event.Data.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("encode %s event: %w", event.Type, err)
+		}
+
+		if err := db.QueryRow(ctx, query,
+			event.Type.String(),
+			optionalString(event.TeamName),
+			payload,
+			event.OccurredAt,
+		).Scan(&event.ID); err != nil {
+			r.log(ctx).Error("Failed to append outbox event",
+				zap.String("event_type", event.Type.String()),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) LockRelay(ctx context.Context) (bool, error) {
+	var locked bool
+	if err := r.dbFor(ctx).QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, outboxRelayLockID).Scan(&locked); err != nil {
+		r.log(ctx).Error("Failed to take outbox relay lock", zap.Error(err))
+		return false, err
+	}
+
+	return locked, nil
+}
+
+func (r *OutboxRepository) ListPending(ctx context.Context, limit int) ([]*entities.Event, error) {
+	const query = `
+		SELECT id, event_type, team_name, payload, occurred_at
+		FROM outbox_events
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, limit)
+	if err != nil {
+		r.log(ctx).Error("Failed to list pending outbox events", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*entities.Event
+
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			r.log(ctx).Error("Failed to scan outbox event row", zap.Error(err))
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing outbox events", zap.Error(err))
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, ids []int64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	const query = `
+		UPDATE outbox_events e
+		SET published_at = $2,
+		    stream_position = numbered.position
+		FROM (
+			SELECT id, nextval('outbox_stream_position') AS position
+			FROM (SELECT id FROM outbox_events WHERE id = ANY($1) ORDER BY id) ordered
+		) numbered
+		WHERE e.id = numbered.id
+	`
+
+	if _, err := r.dbFor(ctx).Exec(ctx, query, ids, at); err != nil {
+		r.log(ctx).Error("Failed to mark outbox events as published",
+			zap.Int("count", len(ids)),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) ListPublished(ctx context.Context, filter repo.PublishedEventFilter, limit int) ([]*entities.Event, error) {
+	const query = `
+		SELECT id, event_type, team_name, payload, occurred_at, stream_position
+		FROM outbox_events
+		WHERE stream_position > $1
+		  AND (cardinality($2::text[]) = 0 OR event_type = ANY($2))
+		  AND ($3 = '' OR team_name = $3)
+		  AND ($4 = '' OR payload->>'author_id' = $4 OR payload->>'reviewer_id' = $4
+		       OR payload->>'old_reviewer_id' = $4 OR payload->>'user_id' = $4
+		       OR COALESCE(payload->'reviewers', '[]'::jsonb) ? $4)
+		ORDER BY stream_position
+		LIMIT $5
+	`
+
+	eventTypes := make([]string, 0, len(filter.Types))
+	for _, eventType := range filter.Types {
+		eventTypes = append(eventTypes, eventType.String())
+	}
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, filter.After, eventTypes, filter.TeamName, filter.UserID, limit)
+	if err != nil {
+		r.log(ctx).Error("Failed to list published outbox events", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*entities.Event
+
+	for rows.Next() {
+		var position int64
+		event, err := scanEvent(scannerWithTail{row: rows, tail: []any{&position}})
+		if err != nil {
+			r.log(ctx).Error("Failed to scan outbox event row", zap.Error(err))
+			return nil, err
+		}
+
+		event.Position = position
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing published events", zap.Error(err))
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepository) LastPosition(ctx context.Context) (int64, error) {
+	const query = `SELECT COALESCE(MAX(stream_position), 0) FROM outbox_events`
+
+	var position int64
+	if err := r.dbFor(ctx).QueryRow(ctx, query).Scan(&position); err != nil {
+		r.log(ctx).Error("Failed to read last stream position", zap.Error(err))
+		return 0, err
+	}
+
+	return position, nil
+}
+
+func (r *OutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	const query = `DELETE FROM outbox_events WHERE published_at IS NOT NULL AND published_at < $1`
+
+	tag, err := r.dbFor(ctx).Exec(ctx, query, before)
+	if err != nil {
+		r.log(ctx).Error("Failed to delete published outbox events", zap.Error(err))
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func scanEvent(row rowScanner) (*entities.Event, error) {
+	var (
+		event     entities.Event
+		eventType string
+		teamName  sql.NullString
+		payload   []byte
+		data      eventPayload
+	)
+
+	if err := row.Scan(&event.ID, &eventType, &teamName, &payload, &event.OccurredAt); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, fmt.Errorf("decode outbox event %d: %w", event.ID, err)
+	}
+
+	event.Type = types.EventType(eventType)
+	event.TeamName = teamName.String
+	event.Data = entities.EventData{
+		PullRequestID:   data.PullRequestID,
+		PullRequestName: data.PullRequestName,
+		AuthorID:        data.AuthorID,
+		Status:          types.PRStatus(data.Status),
+		Reviewers:       data.Reviewers,
+		MergedAt:        data.MergedAt,
+		Version:         data.Version,
+		ReviewerID:      data.ReviewerID,
+		OldReviewerID:   data.OldReviewerID,
+		UserID:          data.UserID,
+	}
+
+	if data.CreatedAt != nil {
+		event.Data.CreatedAt = *data.CreatedAt
+	}
+
+	return &event, nil
+}
+
+func optionalTimeValue(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func (r *OutboxRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
+
+func (r *OutboxRepository) dbFor(ctx context.Context) DB {
+	if tx := DBFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	return r.db
+}
@@ -10,6 +10,7 @@ import (
 	"pr-reviewer-assignment/internal/core/domain/entities"
 	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
 	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/logger"
 
 	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
@@ -29,11 +30,11 @@ func NewPullRequestRepository(db DB, logger *zap.Logger) *PullRequestRepository
 
 func (r *PullRequestRepository) Create(ctx context.Context, pr *entities.PullRequest) error {
 	const query = `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, merged_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, 1)
 	`
 
-	r.logger.Debug("Creating pull request",
+	r.log(ctx).Debug("Creating pull request",
 		zap.String("pr_id", pr.ID),
 		zap.String("author_id", pr.AuthorID))
 
@@ -53,25 +54,26 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *entities.PullReq
 		mergedAt,
 	); err != nil {
 		if isPgError(err, pgCodeUniqueViolation) {
-			r.logger.Warn("Pull request already exists",
+			r.log(ctx).Warn("Pull request already exists",
 				zap.String("pr_id", pr.ID))
 			return domainErrors.PRExists(pr.ID)
 		}
 
-		r.logger.Error("Failed to create pull request",
+		r.log(ctx).Error("Failed to create pull request",
 			zap.String("pr_id", pr.ID),
 			zap.Error(err))
 		return err
 	}
 
-	if err := r.syncReviewers(ctx, db, pr.ID, pr.AssignedReviewers, false); err != nil {
-		r.logger.Error("Failed to create reviewers, rolling back PR row",
+	if err := r.syncReviewers(ctx, db, pr, false); err != nil {
+		r.log(ctx).Error("Failed to create reviewers, rolling back PR row",
 			zap.String("pr_id", pr.ID),
 			zap.Error(err))
 		_, _ = db.Exec(ctx, `DELETE FROM pull_requests WHERE pull_request_id = $1`, pr.ID)
 		return err
 	}
 
+	pr.Version = 1
 	return nil
 }
 
@@ -80,13 +82,14 @@ func (r *PullRequestRepository) Update(ctx context.Context, pr *entities.PullReq
 		UPDATE pull_requests
 		SET pull_request_name = $2,
 		    status = $3,
-		    merged_at = $4
-		WHERE pull_request_id = $1
+		    merged_at = $4,
+		    version = version + 1
+		WHERE pull_request_id = $1 AND version = $5
 	`
 
 	db := r.dbFor(ctx)
 
-	r.logger.Debug("Updating pull request", zap.String("pr_id", pr.ID))
+	r.log(ctx).Debug("Updating pull request", zap.String("pr_id", pr.ID))
 
 	var mergedAt any
 	if pr.MergedAt != nil {
@@ -98,30 +101,50 @@ func (r *PullRequestRepository) Update(ctx context.Context, pr *entities.PullReq
 		pr.Name,
 		pr.Status.String(),
 		mergedAt,
+		pr.Version,
 	)
 	if err != nil {
-		r.logger.Error("Failed to update pull request",
+		r.log(ctx).Error("Failed to update pull request",
 			zap.String("pr_id", pr.ID),
 			zap.Error(err))
 		return err
 	}
 
 	if tag.RowsAffected() == 0 {
-		r.logger.Warn("Pull request not found while updating",
+		var exists bool
+		if err := db.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM pull_requests WHERE pull_request_id = $1)`,
+			pr.ID,
+		).Scan(&exists); err != nil {
+			r.log(ctx).Error("Failed to check pull request existence",
+				zap.String("pr_id", pr.ID),
+				zap.Error(err))
+			return err
+		}
+
+		if exists {
+			r.log(ctx).Warn("Pull request version conflict while updating",
+				zap.String("pr_id", pr.ID),
+				zap.Int("version", pr.Version))
+			return domainErrors.Conflict(pr.ID)
+		}
+
+		r.log(ctx).Warn("Pull request not found while updating",
 			zap.String("pr_id", pr.ID))
 		return domainErrors.NotFound(fmt.Sprintf("pull request %s", pr.ID))
 	}
 
-	if err := r.syncReviewers(ctx, db, pr.ID, pr.AssignedReviewers, true); err != nil {
+	if err := r.syncReviewers(ctx, db, pr, true); err != nil {
 		return err
 	}
 
+	pr.Version++
 	return nil
 }
 
 func (r *PullRequestRepository) GetByID(ctx context.Context, prID string) (*entities.PullRequest, error) {
 	const query = `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, version
 		FROM pull_requests
 		WHERE pull_request_id = $1
 	`
@@ -131,12 +154,12 @@ func (r *PullRequestRepository) GetByID(ctx context.Context, prID string) (*enti
 	pr, err := scanPullRequest(db.QueryRow(ctx, query, prID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			r.logger.Warn("Pull request not found",
+			r.log(ctx).Warn("Pull request not found",
 				zap.String("pr_id", prID))
 			return nil, domainErrors.NotFound(fmt.Sprintf("pull request %s", prID))
 		}
 
-		r.logger.Error("Failed to get pull request",
+		r.log(ctx).Error("Failed to get pull request",
 			zap.String("pr_id", prID),
 			zap.Error(err))
 		return nil, err
@@ -154,7 +177,7 @@ func (r *PullRequestRepository) GetByID(ctx context.Context, prID string) (*enti
 func (r *PullRequestRepository) ListByReviewer(ctx context.Context, reviewerID string) ([]*entities.PullRequest, error) {
 	const query = `
 		SELECT DISTINCT 
-			pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at,
+			pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at, pr.version,
 			rev2.user_id
 		FROM pull_requests pr
 		JOIN pr_reviewers rev ON rev.pull_request_id = pr.pull_request_id
@@ -167,7 +190,7 @@ func (r *PullRequestRepository) ListByReviewer(ctx context.Context, reviewerID s
 
 	rows, err := db.Query(ctx, query, reviewerID)
 	if err != nil {
-		r.logger.Error("Failed to list PRs by reviewer",
+		r.log(ctx).Error("Failed to list PRs by reviewer",
 			zap.String("reviewer_id", reviewerID),
 			zap.Error(err))
 		return nil, err
@@ -182,11 +205,12 @@ func (r *PullRequestRepository) ListByReviewer(ctx context.Context, reviewerID s
 			id, name, authorID, statusStr string
 			createdAt                     time.Time
 			mergedAt                      sql.NullTime
+			version                       int
 			reviewerUserID                sql.NullString
 		)
 
-		if err := rows.Scan(&id, &name, &authorID, &statusStr, &createdAt, &mergedAt, &reviewerUserID); err != nil {
-			r.logger.Error("Failed to scan pull request row",
+		if err := rows.Scan(&id, &name, &authorID, &statusStr, &createdAt, &mergedAt, &version, &reviewerUserID); err != nil {
+			r.log(ctx).Error("Failed to scan pull request row",
 				zap.String("reviewer_id", reviewerID),
 				zap.Error(err))
 			return nil, err
@@ -195,7 +219,7 @@ func (r *PullRequestRepository) ListByReviewer(ctx context.Context, reviewerID s
 		if _, exists := prMap[id]; !exists {
 			status, ok := types.ParsePRStatus(statusStr)
 			if !ok {
-				r.logger.Error("Invalid pull request status while listing by reviewer",
+				r.log(ctx).Error("Invalid pull request status while listing by reviewer",
 					zap.String("status", statusStr))
 				return nil, fmt.Errorf("invalid pull request status: %s", statusStr)
 			}
@@ -214,6 +238,7 @@ func (r *PullRequestRepository) ListByReviewer(ctx context.Context, reviewerID s
 				AssignedReviewers: make([]string, 0, 2),
 				CreatedAt:         createdAt,
 				MergedAt:          mergedPtr,
+				Version:           version,
 			}
 			prOrder = append(prOrder, id)
 		}
@@ -224,7 +249,7 @@ func (r *PullRequestRepository) ListByReviewer(ctx context.Context, reviewerID s
 	}
 
 	if err := rows.Err(); err != nil {
-		r.logger.Error("Rows iteration failed while listing PRs by reviewer",
+		r.log(ctx).Error("Rows iteration failed while listing PRs by reviewer",
 			zap.String("reviewer_id", reviewerID),
 			zap.Error(err))
 		return nil, err
@@ -240,11 +265,108 @@ func (r *PullRequestRepository) ListByReviewer(ctx context.Context, reviewerID s
 	return result, nil
 }
 
+func (r *PullRequestRepository) ListByIDs(ctx context.Context, prIDs []string) ([]*entities.PullRequest, error) {
+	const query = `
+		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, version
+		FROM pull_requests
+		WHERE pull_request_id = ANY($1)
+		ORDER BY pull_request_id
+	`
+
+	db := r.dbFor(ctx)
+
+	rows, err := db.Query(ctx, query, prIDs)
+	if err != nil {
+		r.log(ctx).Error("Failed to list PRs by IDs",
+			zap.Int("pull_requests", len(prIDs)),
+			zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prs []*entities.PullRequest
+	for rows.Next() {
+		pr, err := scanPullRequest(rows)
+		if err != nil {
+			r.log(ctx).Error("Failed to scan pull request row", zap.Error(err))
+			return nil, err
+		}
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing PRs by IDs", zap.Error(err))
+		return nil, err
+	}
+
+	if err := r.attachReviewers(ctx, db, prs); err != nil {
+		return nil, err
+	}
+
+	return prs, nil
+}
+
+func (r *PullRequestRepository) ListByReviewers(ctx context.Context, reviewerIDs []string) (map[string][]*entities.PullRequest, error) {
+	const query = `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at, pr.version,
+			rev.user_id
+		FROM pr_reviewers rev
+		JOIN pull_requests pr ON pr.pull_request_id = rev.pull_request_id
+		WHERE rev.user_id = ANY($1)
+		ORDER BY pr.created_at DESC, pr.pull_request_id
+	`
+
+	db := r.dbFor(ctx)
+
+	rows, err := db.Query(ctx, query, reviewerIDs)
+	if err != nil {
+		r.log(ctx).Error("Failed to list PRs by reviewers",
+			zap.Int("reviewers", len(reviewerIDs)),
+			zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string][]*entities.PullRequest)
+	byID := make(map[string]*entities.PullRequest)
+	var prs []*entities.PullRequest
+
+	for rows.Next() {
+		var reviewerID string
+		pr, err := scanPullRequest(scannerWithTail{row: rows, tail: []any{&reviewerID}})
+		if err != nil {
+			r.log(ctx).Error("Failed to scan pull request row", zap.Error(err))
+			return nil, err
+		}
+
+		// A pull request reviewed by several of the users is shared between
+		// their lists.
+		if seen, ok := byID[pr.ID]; ok {
+			pr = seen
+		} else {
+			byID[pr.ID] = pr
+			prs = append(prs, pr)
+		}
+		result[reviewerID] = append(result[reviewerID], pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing PRs by reviewers", zap.Error(err))
+		return nil, err
+	}
+
+	if err := r.attachReviewers(ctx, db, prs); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (r *PullRequestRepository) Count(ctx context.Context) (int, error) {
 	const query = `SELECT COUNT(*) FROM pull_requests`
 	var count int
-	if err := r.db.QueryRow(ctx, query).Scan(&count); err != nil {
-		r.logger.Error("Failed to count pull requests", zap.Error(err))
+	if err := r.dbFor(ctx).QueryRow(ctx, query).Scan(&count); err != nil {
+		r.log(ctx).Error("Failed to count pull requests", zap.Error(err))
 		return 0, err
 	}
 	return count, nil
@@ -253,13 +375,149 @@ func (r *PullRequestRepository) Count(ctx context.Context) (int, error) {
 func (r *PullRequestRepository) CountAssignments(ctx context.Context) (int, error) {
 	const query = `SELECT COUNT(*) FROM pr_reviewers`
 	var count int
-	if err := r.db.QueryRow(ctx, query).Scan(&count); err != nil {
-		r.logger.Error("Failed to count assignments", zap.Error(err))
+	if err := r.dbFor(ctx).QueryRow(ctx, query).Scan(&count); err != nil {
+		r.log(ctx).Error("Failed to count assignments", zap.Error(err))
 		return 0, err
 	}
 	return count, nil
 }
 
+func (r *PullRequestRepository) CountOpenByTeam(ctx context.Context) (map[string]int, error) {
+	const query = `
+		SELECT t.team_name, COUNT(pr.pull_request_id)
+		FROM teams t
+		LEFT JOIN users u ON u.team_name = t.team_name
+		LEFT JOIN pull_requests pr ON pr.author_id = u.user_id AND pr.status = 'OPEN'
+		GROUP BY t.team_name
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query)
+	if err != nil {
+		r.log(ctx).Error("Failed to count open pull requests by team", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+
+	for rows.Next() {
+		var (
+			teamName string
+			count    int
+		)
+
+		if err := rows.Scan(&teamName, &count); err != nil {
+			r.log(ctx).Error("Failed to scan open pull request count row", zap.Error(err))
+			return nil, err
+		}
+
+		counts[teamName] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while counting open pull requests by team", zap.Error(err))
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *PullRequestRepository) CountOpenAssignmentsByReviewer(ctx context.Context, teamName string) (map[string]int, error) {
+	const query = `
+		SELECT rev.user_id, COUNT(*)
+		FROM pr_reviewers rev
+		JOIN users u ON u.user_id = rev.user_id
+		JOIN pull_requests pr ON pr.pull_request_id = rev.pull_request_id
+		WHERE u.team_name = $1
+		  AND pr.status = 'OPEN'
+		GROUP BY rev.user_id
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, teamName)
+	if err != nil {
+		r.log(ctx).Error("Failed to count open assignments by reviewer",
+			zap.String("team_name", teamName),
+			zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+
+	for rows.Next() {
+		var (
+			userID string
+			count  int
+		)
+
+		if err := rows.Scan(&userID, &count); err != nil {
+			r.log(ctx).Error("Failed to scan open assignment count row",
+				zap.String("team_name", teamName),
+				zap.Error(err))
+			return nil, err
+		}
+
+		counts[userID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while counting open assignments by reviewer",
+			zap.String("team_name", teamName),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *PullRequestRepository) CountAssignmentsByReviewer(ctx context.Context, teamName string, from, to *time.Time) (map[string]int, error) {
+	const query = `
+		SELECT rev.user_id, COUNT(*)
+		FROM pr_reviewers rev
+		JOIN users u ON u.user_id = rev.user_id
+		WHERE u.team_name = $1
+		  AND ($2::timestamp IS NULL OR rev.assigned_at >= $2)
+		  AND ($3::timestamp IS NULL OR rev.assigned_at < $3)
+		GROUP BY rev.user_id
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, teamName, optionalTime(from), optionalTime(to))
+	if err != nil {
+		r.log(ctx).Error("Failed to count assignments by reviewer",
+			zap.String("team_name", teamName),
+			zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+
+	for rows.Next() {
+		var (
+			userID string
+			count  int
+		)
+
+		if err := rows.Scan(&userID, &count); err != nil {
+			r.log(ctx).Error("Failed to scan assignment count row",
+				zap.String("team_name", teamName),
+				zap.Error(err))
+			return nil, err
+		}
+
+		counts[userID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while counting assignments by reviewer",
+			zap.String("team_name", teamName),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return counts, nil
+}
+
 func (r *PullRequestRepository) fetchReviewers(ctx context.Context, db DB, prID string) ([]string, error) {
 	const query = `
 		SELECT user_id
@@ -270,7 +528,7 @@ func (r *PullRequestRepository) fetchReviewers(ctx context.Context, db DB, prID
 
 	rows, err := db.Query(ctx, query, prID)
 	if err != nil {
-		r.logger.Error("Failed to fetch reviewers",
+		r.log(ctx).Error("Failed to fetch reviewers",
 			zap.String("pr_id", prID),
 			zap.Error(err))
 		return nil, err
@@ -282,7 +540,7 @@ func (r *PullRequestRepository) fetchReviewers(ctx context.Context, db DB, prID
 	for rows.Next() {
 		var userID string
 		if err := rows.Scan(&userID); err != nil {
-			r.logger.Error("Failed to scan reviewer row",
+			r.log(ctx).Error("Failed to scan reviewer row",
 				zap.String("pr_id", prID),
 				zap.Error(err))
 			return nil, err
@@ -292,7 +550,7 @@ func (r *PullRequestRepository) fetchReviewers(ctx context.Context, db DB, prID
 	}
 
 	if err := rows.Err(); err != nil {
-		r.logger.Error("Reviewer rows iteration failed",
+		r.log(ctx).Error("Reviewer rows iteration failed",
 			zap.String("pr_id", prID),
 			zap.Error(err))
 		return nil, err
@@ -301,10 +559,19 @@ func (r *PullRequestRepository) fetchReviewers(ctx context.Context, db DB, prID
 	return reviewers, nil
 }
 
-func (r *PullRequestRepository) syncReviewers(ctx context.Context, db DB, prID string, reviewers []string, replace bool) error {
+func (r *PullRequestRepository) syncReviewers(ctx context.Context, db DB, pr *entities.PullRequest, replace bool) error {
+	prID, reviewers := pr.ID, pr.AssignedReviewers
+
+	// Reviewers that stay keep their row, so assigned_at keeps measuring how
+	// long they have had the review.
 	if replace {
-		if _, err := db.Exec(ctx, `DELETE FROM pr_reviewers WHERE pull_request_id = $1`, prID); err != nil {
-			r.logger.Error("Failed to delete existing reviewers",
+		kept := reviewers
+		if kept == nil {
+			kept = []string{}
+		}
+
+		if _, err := db.Exec(ctx, `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND NOT (user_id = ANY($2))`, prID, kept); err != nil {
+			r.log(ctx).Error("Failed to delete removed reviewers",
 				zap.String("pr_id", prID),
 				zap.Error(err))
 			return err
@@ -316,8 +583,9 @@ func (r *PullRequestRepository) syncReviewers(ctx context.Context, db DB, prID s
 	}
 
 	const query = `
-		INSERT INTO pr_reviewers (pull_request_id, user_id)
-		VALUES ($1, $2)
+		INSERT INTO pr_reviewers (pull_request_id, user_id, assigned_at)
+		VALUES ($1, $2, COALESCE($3::timestamp, NOW()))
+		ON CONFLICT (pull_request_id, user_id) DO NOTHING
 	`
 
 	for _, reviewer := range reviewers {
@@ -325,22 +593,15 @@ func (r *PullRequestRepository) syncReviewers(ctx context.Context, db DB, prID s
 			continue
 		}
 
-		if _, err := db.Exec(ctx, query, prID, reviewer); err != nil {
+		if _, err := db.Exec(ctx, query, prID, reviewer, optionalTimeValue(pr.ChangedAt)); err != nil {
 			if isPgError(err, pgCodeForeignKeyViolation) {
-				r.logger.Warn("Reviewer not found while syncing assignment",
+				r.log(ctx).Warn("Reviewer not found while syncing assignment",
 					zap.String("pr_id", prID),
 					zap.String("reviewer", reviewer))
 				return domainErrors.NotFound(fmt.Sprintf("user %s", reviewer))
 			}
 
-			if isPgError(err, pgCodeUniqueViolation) {
-				r.logger.Debug("Reviewer already assigned",
-					zap.String("pr_id", prID),
-					zap.String("reviewer", reviewer))
-				continue
-			}
-
-			r.logger.Error("Failed to assign reviewer",
+			r.log(ctx).Error("Failed to assign reviewer",
 				zap.String("pr_id", prID),
 				zap.String("reviewer", reviewer),
 				zap.Error(err))
@@ -351,6 +612,54 @@ func (r *PullRequestRepository) syncReviewers(ctx context.Context, db DB, prID s
 	return nil
 }
 
+func (r *PullRequestRepository) attachReviewers(ctx context.Context, db DB, prs []*entities.PullRequest) error {
+	if len(prs) == 0 {
+		return nil
+	}
+
+	const query = `
+		SELECT pull_request_id, user_id
+		FROM pr_reviewers
+		WHERE pull_request_id = ANY($1)
+		ORDER BY assigned_at ASC, user_id ASC
+	`
+
+	ids := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		ids = append(ids, pr.ID)
+	}
+
+	rows, err := db.Query(ctx, query, ids)
+	if err != nil {
+		r.log(ctx).Error("Failed to fetch reviewers of pull requests",
+			zap.Int("pull_requests", len(ids)),
+			zap.Error(err))
+		return err
+	}
+	defer rows.Close()
+
+	reviewers := make(map[string][]string, len(prs))
+	for rows.Next() {
+		var prID, userID string
+		if err := rows.Scan(&prID, &userID); err != nil {
+			r.log(ctx).Error("Failed to scan reviewer row", zap.Error(err))
+			return err
+		}
+		reviewers[prID] = append(reviewers[prID], userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Reviewer rows iteration failed", zap.Error(err))
+		return err
+	}
+
+	for _, pr := range prs {
+		pr.SetReviewers(reviewers[pr.ID])
+	}
+
+	return nil
+}
+
 func scanPullRequest(row rowScanner) (*entities.PullRequest, error) {
 	var (
 		id        string
@@ -359,9 +668,10 @@ func scanPullRequest(row rowScanner) (*entities.PullRequest, error) {
 		statusStr string
 		createdAt time.Time
 		mergedAt  sql.NullTime
+		version   int
 	)
 
-	if err := row.Scan(&id, &name, &authorID, &statusStr, &createdAt, &mergedAt); err != nil {
+	if err := row.Scan(&id, &name, &authorID, &statusStr, &createdAt, &mergedAt, &version); err != nil {
 		return nil, err
 	}
 
@@ -384,9 +694,14 @@ func scanPullRequest(row rowScanner) (*entities.PullRequest, error) {
 		AssignedReviewers: make([]string, 0, 2),
 		CreatedAt:         createdAt,
 		MergedAt:          mergedPtr,
+		Version:           version,
 	}, nil
 }
 
+func (r *PullRequestRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
+
 func (r *PullRequestRepository) dbFor(ctx context.Context) DB {
 	if tx := DBFromContext(ctx); tx != nil {
 		return tx
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+type StatsRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewStatsRepository(db DB, logger *zap.Logger) *StatsRepository {
+	return &StatsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *StatsRepository) SaveSnapshot(ctx context.Context, snapshot *entities.StatsSnapshot) error {
+	const query = `
+		INSERT INTO stats_snapshots (taken_at, metric, team_name, value)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (metric, team_name, taken_at) DO UPDATE
+		SET value = EXCLUDED.value
+	`
+
+	db := r.dbFor(ctx)
+
+	for _, sample := range snapshot.Samples {
+		if _, err := db.Exec(ctx, query,
+			snapshot.TakenAt.UTC(),
+			sample.Metric.String(),
+			sample.TeamName,
+			sample.Value,
+		); err != nil {
+			r.log(ctx).Error("Failed to save stats sample",
+				zap.String("metric", sample.Metric.String()),
+				zap.String("team_name", sample.TeamName),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *StatsRepository) History(ctx context.Context, q entities.StatsHistoryQuery) ([]entities.StatsPoint, error) {
+	const query = `
+		SELECT
+			date_trunc($1, taken_at) AS bucket,
+			(array_agg(value ORDER BY taken_at DESC))[1] AS last_value,
+			MIN(value),
+			MAX(value)
+		FROM stats_snapshots
+		WHERE metric = $2
+		  AND team_name = $3
+		  AND taken_at >= $4
+		  AND taken_at < $5
+		GROUP BY bucket
+		ORDER BY bucket ASC
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query,
+		q.Bucket.String(),
+		q.Metric.String(),
+		q.TeamName,
+		q.From.UTC(),
+		q.To.UTC(),
+	)
+	if err != nil {
+		r.log(ctx).Error("Failed to query stats history",
+			zap.String("metric", q.Metric.String()),
+			zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	points := make([]entities.StatsPoint, 0)
+
+	for rows.Next() {
+		var (
+			bucket time.Time
+			point  entities.StatsPoint
+		)
+
+		if err := rows.Scan(&bucket, &point.Last, &point.Min, &point.Max); err != nil {
+			r.log(ctx).Error("Failed to scan stats history row",
+				zap.String("metric", q.Metric.String()),
+				zap.Error(err))
+			return nil, err
+		}
+
+		point.Bucket = bucket.UTC()
+		points = append(points, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while querying stats history",
+			zap.String("metric", q.Metric.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return points, nil
+}
+
+func (r *StatsRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
+
+func (r *StatsRepository) dbFor(ctx context.Context) DB {
+	if tx := DBFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	return r.db
+}
@@ -3,12 +3,15 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"pr-reviewer-assignment/internal/core/domain/entities"
 	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/logger"
 
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -27,8 +30,8 @@ func NewTeamRepository(db DB, logger *zap.Logger) *TeamRepository {
 func (r *TeamRepository) Count(ctx context.Context) (int, error) {
 	const query = `SELECT COUNT(*) FROM teams`
 	var count int
-	if err := r.db.QueryRow(ctx, query).Scan(&count); err != nil {
-		r.logger.Error("Failed to count teams", zap.Error(err))
+	if err := r.dbFor(ctx).QueryRow(ctx, query).Scan(&count); err != nil {
+		r.log(ctx).Error("Failed to count teams", zap.Error(err))
 		return 0, err
 	}
 	return count, nil
@@ -40,19 +43,19 @@ func (r *TeamRepository) Create(ctx context.Context, team *entities.Team) error
 		VALUES ($1, $2, $3)
 	`
 
-	r.logger.Debug("Creating team", zap.String("team_name", team.Name))
+	r.log(ctx).Debug("Creating team", zap.String("team_name", team.Name))
 
 	db := r.dbFor(ctx)
 
 	_, err := db.Exec(ctx, query, team.Name, team.CreatedAt, team.UpdatedAt)
 	if err != nil {
 		if isPgError(err, pgCodeUniqueViolation) {
-			r.logger.Warn("Team already exists",
+			r.log(ctx).Warn("Team already exists",
 				zap.String("team_name", team.Name))
 			return domainErrors.TeamExists(team.Name)
 		}
 
-		r.logger.Error("Failed to create team",
+		r.log(ctx).Error("Failed to create team",
 			zap.String("team_name", team.Name),
 			zap.Error(err))
 		return err
@@ -68,20 +71,20 @@ func (r *TeamRepository) Update(ctx context.Context, team *entities.Team) error
 		WHERE team_name = $1
 	`
 
-	r.logger.Debug("Updating team", zap.String("team_name", team.Name))
+	r.log(ctx).Debug("Updating team", zap.String("team_name", team.Name))
 
 	db := r.dbFor(ctx)
 
 	tag, err := db.Exec(ctx, query, team.Name, team.UpdatedAt)
 	if err != nil {
-		r.logger.Error("Failed to update team",
+		r.log(ctx).Error("Failed to update team",
 			zap.String("team_name", team.Name),
 			zap.Error(err))
 		return err
 	}
 
 	if tag.RowsAffected() == 0 {
-		r.logger.Warn("Team not found while updating",
+		r.log(ctx).Warn("Team not found while updating",
 			zap.String("team_name", team.Name))
 		return domainErrors.NotFound(fmt.Sprintf("team %s", team.Name))
 	}
@@ -104,7 +107,7 @@ func (r *TeamRepository) Get(ctx context.Context, teamName string) (*entities.Te
 
 	rows, err := db.Query(ctx, query, teamName)
 	if err != nil {
-		r.logger.Error("Failed to get team",
+		r.log(ctx).Error("Failed to get team",
 			zap.String("team_name", teamName),
 			zap.Error(err))
 		return nil, err
@@ -125,7 +128,7 @@ func (r *TeamRepository) Get(ctx context.Context, teamName string) (*entities.Te
 
 		err := rows.Scan(&tName, &tCreated, &tUpdated, &userID, &username, &isActive, &uCreated, &uUpdated)
 		if err != nil {
-			r.logger.Error("Failed to scan team row",
+			r.log(ctx).Error("Failed to scan team row",
 				zap.String("team_name", teamName),
 				zap.Error(err))
 			return nil, err
@@ -142,13 +145,13 @@ func (r *TeamRepository) Get(ctx context.Context, teamName string) (*entities.Te
 	}
 
 	if team == nil {
-		r.logger.Warn("Team not found",
+		r.log(ctx).Warn("Team not found",
 			zap.String("team_name", teamName))
 		return nil, domainErrors.NotFound(fmt.Sprintf("team %s", teamName))
 	}
 
 	if err := rows.Err(); err != nil {
-		r.logger.Error("Team rows iteration failed",
+		r.log(ctx).Error("Team rows iteration failed",
 			zap.String("team_name", teamName),
 			zap.Error(err))
 		return nil, err
@@ -157,6 +160,115 @@ func (r *TeamRepository) Get(ctx context.Context, teamName string) (*entities.Te
 	return team, nil
 }
 
+func (r *TeamRepository) GetForUpdate(ctx context.Context, teamName string) (*entities.Team, error) {
+	const query = `SELECT team_name FROM teams WHERE team_name = $1 FOR UPDATE`
+
+	var locked string
+	if err := r.dbFor(ctx).QueryRow(ctx, query, teamName).Scan(&locked); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.log(ctx).Warn("Team not found while locking",
+				zap.String("team_name", teamName))
+			return nil, domainErrors.NotFound(fmt.Sprintf("team %s", teamName))
+		}
+
+		r.log(ctx).Error("Failed to lock team",
+			zap.String("team_name", teamName),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return r.Get(ctx, teamName)
+}
+
+func (r *TeamRepository) ListNames(ctx context.Context) ([]string, error) {
+	const query = `SELECT team_name FROM teams ORDER BY team_name`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query)
+	if err != nil {
+		r.log(ctx).Error("Failed to list team names", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			r.log(ctx).Error("Failed to scan team name", zap.Error(err))
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing team names", zap.Error(err))
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (r *TeamRepository) ListByNames(ctx context.Context, teamNames []string) ([]*entities.Team, error) {
+	const query = `
+		SELECT
+			t.team_name, t.created_at, t.updated_at,
+			u.user_id, u.username, u.is_active, u.created_at, u.updated_at
+		FROM teams t
+		LEFT JOIN users u ON u.team_name = t.team_name
+		WHERE t.team_name = ANY($1)
+		ORDER BY t.team_name, u.username
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, teamNames)
+	if err != nil {
+		r.log(ctx).Error("Failed to list teams by names",
+			zap.Int("teams", len(teamNames)),
+			zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []*entities.Team
+	var team *entities.Team
+
+	for rows.Next() {
+		var (
+			tName              string
+			tCreated           time.Time
+			tUpdated           time.Time
+			userID, username   sql.NullString
+			isActive           sql.NullBool
+			uCreated, uUpdated sql.NullTime
+		)
+
+		if err := rows.Scan(&tName, &tCreated, &tUpdated, &userID, &username, &isActive, &uCreated, &uUpdated); err != nil {
+			r.log(ctx).Error("Failed to scan team row", zap.Error(err))
+			return nil, err
+		}
+
+		if team == nil || team.Name != tName {
+			team = entities.NewTeam(tName, tCreated, tUpdated)
+			teams = append(teams, team)
+		}
+
+		if userID.Valid {
+			user := entities.NewUser(userID.String, username.String, tName, isActive.Bool, uCreated.Time, uUpdated.Time)
+			team.Members[user.ID] = user
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Team rows iteration failed while listing by names", zap.Error(err))
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+func (r *TeamRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
+
 func (r *TeamRepository) dbFor(ctx context.Context) DB {
 	if tx := DBFromContext(ctx); tx != nil {
 		return tx
@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+type TeamRoleRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewTeamRoleRepository(db DB, logger *zap.Logger) *TeamRoleRepository {
+	return &TeamRoleRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *TeamRoleRepository) Set(ctx context.Context, role *entities.TeamRole) error {
+	const query = `
+		INSERT INTO team_roles (team_name, user_id, role, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (team_name, user_id) DO UPDATE
+		SET role = EXCLUDED.role
+	`
+
+	if _, err := r.dbFor(ctx).Exec(ctx, query, role.TeamName, role.UserID, role.Role.String(), role.CreatedAt); err != nil {
+		if isPgError(err, pgCodeForeignKeyViolation) {
+			r.log(ctx).Warn("Team or user not found while setting role",
+				zap.String("team_name", role.TeamName),
+				zap.String("user_id", role.UserID))
+			return domainErrors.NotFound(fmt.Sprintf("team %s or user %s", role.TeamName, role.UserID))
+		}
+
+		r.log(ctx).Error("Failed to set team role",
+			zap.String("team_name", role.TeamName),
+			zap.String("user_id", role.UserID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *TeamRoleRepository) Remove(ctx context.Context, teamName, userID string) error {
+	const query = `
+		DELETE FROM team_roles
+		WHERE team_name = $1 AND user_id = $2
+	`
+
+	tag, err := r.dbFor(ctx).Exec(ctx, query, teamName, userID)
+	if err != nil {
+		r.log(ctx).Error("Failed to remove team role",
+			zap.String("team_name", teamName),
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domainErrors.NotFound(fmt.Sprintf("role of user %s in team %s", userID, teamName))
+	}
+
+	return nil
+}
+
+func (r *TeamRoleRepository) ListByTeam(ctx context.Context, teamName string) ([]*entities.TeamRole, error) {
+	const query = `
+		SELECT team_name, user_id, role, created_at
+		FROM team_roles
+		WHERE team_name = $1
+		ORDER BY user_id ASC
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, teamName)
+	if err != nil {
+		r.log(ctx).Error("Failed to list team roles",
+			zap.String("team_name", teamName),
+			zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []*entities.TeamRole
+
+	for rows.Next() {
+		var (
+			role    entities.TeamRole
+			rawRole string
+		)
+
+		if err := rows.Scan(&role.TeamName, &role.UserID, &rawRole, &role.CreatedAt); err != nil {
+			r.log(ctx).Error("Failed to scan team role row", zap.Error(err))
+			return nil, err
+		}
+
+		role.Role = types.Role(rawRole)
+		roles = append(roles, &role)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing team roles", zap.Error(err))
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *TeamRoleRepository) HasRole(ctx context.Context, teamName, userID string, role types.Role) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1 FROM team_roles
+			WHERE team_name = $1 AND user_id = $2 AND role = $3
+		)
+	`
+
+	var exists bool
+	if err := r.dbFor(ctx).QueryRow(ctx, query, teamName, userID, role.String()).Scan(&exists); err != nil {
+		r.log(ctx).Error("Failed to check team role",
+			zap.String("team_name", teamName),
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (r *TeamRoleRepository) dbFor(ctx context.Context) DB {
+	if tx := DBFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *TeamRoleRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
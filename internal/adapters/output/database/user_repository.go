@@ -8,6 +8,7 @@ import (
 
 	"pr-reviewer-assignment/internal/core/domain/entities"
 	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/logger"
 
 	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
@@ -32,8 +33,8 @@ func NewUserRepository(db DB, logger *zap.Logger) *UserRepository {
 func (r *UserRepository) Count(ctx context.Context) (int, error) {
 	const query = `SELECT COUNT(*) FROM users`
 	var count int
-	if err := r.db.QueryRow(ctx, query).Scan(&count); err != nil {
-		r.logger.Error("Failed to count users", zap.Error(err))
+	if err := r.dbFor(ctx).QueryRow(ctx, query).Scan(&count); err != nil {
+		r.log(ctx).Error("Failed to count users", zap.Error(err))
 		return 0, err
 	}
 	return count, nil
@@ -81,13 +82,13 @@ func (r *UserRepository) UpsertMany(ctx context.Context, users []*entities.User)
 			updatedAt,
 		); err != nil {
 			if isPgError(err, pgCodeForeignKeyViolation) {
-				r.logger.Warn("Team not found while upserting user",
+				r.log(ctx).Warn("Team not found while upserting user",
 					zap.String("user_id", user.ID),
 					zap.String("team_name", user.TeamName))
 				return domainErrors.NotFound(fmt.Sprintf("team %s", user.TeamName))
 			}
 
-			r.logger.Error("Failed to upsert user",
+			r.log(ctx).Error("Failed to upsert user",
 				zap.String("user_id", user.ID),
 				zap.Error(err))
 			return err
@@ -99,7 +100,7 @@ func (r *UserRepository) UpsertMany(ctx context.Context, users []*entities.User)
 
 func (r *UserRepository) GetByID(ctx context.Context, userID string) (*entities.User, error) {
 	const query = `
-		SELECT user_id, username, team_name, is_active, created_at, updated_at
+		SELECT user_id, username, team_name, is_active, email, timezone, digest_enabled, created_at, updated_at
 		FROM users
 		WHERE user_id = $1
 	`
@@ -109,12 +110,12 @@ func (r *UserRepository) GetByID(ctx context.Context, userID string) (*entities.
 	user, err := scanUser(db.QueryRow(ctx, query, userID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			r.logger.Warn("User not found",
+			r.log(ctx).Warn("User not found",
 				zap.String("user_id", userID))
 			return nil, domainErrors.NotFound(fmt.Sprintf("user %s", userID))
 		}
 
-		r.logger.Error("Failed to get user",
+		r.log(ctx).Error("Failed to get user",
 			zap.String("user_id", userID),
 			zap.Error(err))
 		return nil, err
@@ -123,9 +124,46 @@ func (r *UserRepository) GetByID(ctx context.Context, userID string) (*entities.
 	return user, nil
 }
 
+func (r *UserRepository) ListByIDs(ctx context.Context, userIDs []string) ([]*entities.User, error) {
+	const query = `
+		SELECT user_id, username, team_name, is_active, email, timezone, digest_enabled, created_at, updated_at
+		FROM users
+		WHERE user_id = ANY($1)
+		ORDER BY user_id ASC
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, userIDs)
+	if err != nil {
+		r.log(ctx).Error("Failed to list users by IDs",
+			zap.Int("users", len(userIDs)),
+			zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*entities.User
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			r.log(ctx).Error("Failed to scan user row", zap.Error(err))
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing users by IDs", zap.Error(err))
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func (r *UserRepository) ListByTeam(ctx context.Context, teamName string) ([]*entities.User, error) {
 	const query = `
-		SELECT user_id, username, team_name, is_active, created_at, updated_at
+		SELECT user_id, username, team_name, is_active, email, timezone, digest_enabled, created_at, updated_at
 		FROM users
 		WHERE team_name = $1
 		ORDER BY username ASC, user_id ASC
@@ -135,7 +173,7 @@ func (r *UserRepository) ListByTeam(ctx context.Context, teamName string) ([]*en
 
 	rows, err := db.Query(ctx, query, teamName)
 	if err != nil {
-		r.logger.Error("Failed to list users by team",
+		r.log(ctx).Error("Failed to list users by team",
 			zap.String("team_name", teamName),
 			zap.Error(err))
 		return nil, err
@@ -147,7 +185,7 @@ func (r *UserRepository) ListByTeam(ctx context.Context, teamName string) ([]*en
 	for rows.Next() {
 		user, err := scanUser(rows)
 		if err != nil {
-			r.logger.Error("Failed to scan user row",
+			r.log(ctx).Error("Failed to scan user row",
 				zap.String("team_name", teamName),
 				zap.Error(err))
 			return nil, err
@@ -157,7 +195,7 @@ func (r *UserRepository) ListByTeam(ctx context.Context, teamName string) ([]*en
 	}
 
 	if err := rows.Err(); err != nil {
-		r.logger.Error("Rows iteration failed while listing team users",
+		r.log(ctx).Error("Rows iteration failed while listing team users",
 			zap.String("team_name", teamName),
 			zap.Error(err))
 		return nil, err
@@ -172,7 +210,7 @@ func (r *UserRepository) SetActivity(ctx context.Context, userID string, isActiv
 		SET is_active = $2,
 		    updated_at = $3
 		WHERE user_id = $1
-		RETURNING user_id, username, team_name, is_active, created_at, updated_at
+		RETURNING user_id, username, team_name, is_active, email, timezone, digest_enabled, created_at, updated_at
 	`
 
 	updatedAt := time.Now().UTC()
@@ -182,12 +220,12 @@ func (r *UserRepository) SetActivity(ctx context.Context, userID string, isActiv
 	user, err := scanUser(db.QueryRow(ctx, query, userID, isActive, updatedAt))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			r.logger.Warn("User not found while updating activity",
+			r.log(ctx).Warn("User not found while updating activity",
 				zap.String("user_id", userID))
 			return nil, domainErrors.NotFound(fmt.Sprintf("user %s", userID))
 		}
 
-		r.logger.Error("Failed to set user activity",
+		r.log(ctx).Error("Failed to set user activity",
 			zap.String("user_id", userID),
 			zap.Error(err))
 		return nil, err
@@ -196,21 +234,67 @@ func (r *UserRepository) SetActivity(ctx context.Context, userID string, isActiv
 	return user, nil
 }
 
+func (r *UserRepository) UpdatePreferences(ctx context.Context, user *entities.User) (*entities.User, error) {
+	const query = `
+		UPDATE users
+		SET email = $2,
+		    timezone = $3,
+		    digest_enabled = $4,
+		    updated_at = $5
+		WHERE user_id = $1
+		RETURNING user_id, username, team_name, is_active, email, timezone, digest_enabled, created_at, updated_at
+	`
+
+	updated, err := scanUser(r.dbFor(ctx).QueryRow(ctx, query,
+		user.ID,
+		optionalString(user.Email),
+		user.Timezone,
+		user.DigestEnabled,
+		user.UpdatedAt,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.NotFound(fmt.Sprintf("user %s", user.ID))
+		}
+
+		r.log(ctx).Error("Failed to update user preferences",
+			zap.String("user_id", user.ID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return updated, nil
+}
+
 func scanUser(row rowScanner) (*entities.User, error) {
 	var (
-		id        string
-		username  string
-		teamName  string
-		isActive  bool
-		createdAt time.Time
-		updatedAt time.Time
+		id            string
+		username      string
+		teamName      string
+		isActive      bool
+		email         *string
+		timezone      string
+		digestEnabled bool
+		createdAt     time.Time
+		updatedAt     time.Time
 	)
 
-	if err := row.Scan(&id, &username, &teamName, &isActive, &createdAt, &updatedAt); err != nil {
+	if err := row.Scan(&id, &username, &teamName, &isActive, &email, &timezone, &digestEnabled, &createdAt, &updatedAt); err != nil {
 		return nil, err
 	}
 
-	return entities.NewUser(id, username, teamName, isActive, createdAt, updatedAt), nil
+	user := entities.NewUser(id, username, teamName, isActive, createdAt, updatedAt)
+	if email != nil {
+		user.Email = *email
+	}
+	user.Timezone = timezone
+	user.DigestEnabled = digestEnabled
+
+	return user, nil
+}
+
+func (r *UserRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
 }
 
 func (r *UserRepository) dbFor(ctx context.Context) DB {
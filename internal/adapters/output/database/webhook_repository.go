@@ -0,0 +1,384 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	"pr-reviewer-assignment/internal/logger"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const defaultDeliveryListLimit = 100
+
+type WebhookRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewWebhookRepository(db DB, logger *zap.Logger) *WebhookRepository {
+	return &WebhookRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, sub *entities.WebhookSubscription) error {
+	const query = `
+		INSERT INTO webhook_subscriptions (webhook_id, url, secret, event_types, team_name, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	if _, err := r.dbFor(ctx).Exec(ctx, query,
+		sub.ID,
+		sub.URL,
+		sub.Secret,
+		eventTypesToStrings(sub.EventTypes),
+		optionalString(sub.TeamName),
+		sub.CreatedAt,
+	); err != nil {
+		if isPgError(err, pgCodeForeignKeyViolation) {
+			return domainErrors.NotFound(fmt.Sprintf("team %s", sub.TeamName))
+		}
+
+		r.log(ctx).Error("Failed to create webhook subscription",
+			zap.String("url", sub.URL),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id string) (*entities.WebhookSubscription, error) {
+	const query = `
+		SELECT webhook_id, url, secret, event_types, team_name, created_at
+		FROM webhook_subscriptions
+		WHERE webhook_id = $1
+	`
+
+	sub, err := scanWebhookSubscription(r.dbFor(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.NotFound(fmt.Sprintf("webhook %s", id))
+		}
+
+		r.log(ctx).Error("Failed to get webhook subscription",
+			zap.String("webhook_id", id),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	const query = `
+		SELECT webhook_id, url, secret, event_types, team_name, created_at
+		FROM webhook_subscriptions
+		ORDER BY created_at, webhook_id
+	`
+
+	rows, err := r.dbFor(ctx).Query(ctx, query)
+	if err != nil {
+		r.log(ctx).Error("Failed to list webhook subscriptions", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []*entities.WebhookSubscription
+
+	for rows.Next() {
+		sub, err := scanWebhookSubscription(rows)
+		if err != nil {
+			r.log(ctx).Error("Failed to scan webhook subscription row", zap.Error(err))
+			return nil, err
+		}
+
+		subs = append(subs, sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log(ctx).Error("Rows iteration failed while listing webhook subscriptions", zap.Error(err))
+		return nil, err
+	}
+
+	return subs, nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id string) error {
+	tag, err := r.dbFor(ctx).Exec(ctx, `DELETE FROM webhook_subscriptions WHERE webhook_id = $1`, id)
+	if err != nil {
+		r.log(ctx).Error("Failed to delete webhook subscription",
+			zap.String("webhook_id", id),
+			zap.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domainErrors.NotFound(fmt.Sprintf("webhook %s", id))
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	const query = `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+		RETURNING delivery_id
+	`
+
+	db := r.dbFor(ctx)
+
+	for _, delivery := range deliveries {
+		err := db.QueryRow(ctx, query,
+			delivery.SubscriptionID,
+			delivery.EventID,
+			delivery.EventType.String(),
+			delivery.Payload,
+			delivery.Status.String(),
+			delivery.NextAttemptAt,
+			delivery.CreatedAt,
+		).Scan(&delivery.ID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			r.log(ctx).Error("Failed to create webhook delivery",
+				zap.String("webhook_id", delivery.SubscriptionID),
+				zap.Int64("event_id", delivery.EventID),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	const query = `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $2
+		WHERE delivery_id IN (
+			SELECT delivery_id
+			FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, delivery_id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		r.log(ctx).Error("Failed to claim webhook deliveries", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries, err := collectWebhookDeliveries(rows)
+	if err != nil {
+		r.log(ctx).Error("Failed to read claimed webhook deliveries", zap.Error(err))
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	const query = `
+		UPDATE webhook_deliveries
+		SET status = $2,
+		    attempts = $3,
+		    next_attempt_at = $4,
+		    last_status_code = $5,
+		    last_error = $6,
+		    delivered_at = $7
+		WHERE delivery_id = $1
+	`
+
+	var statusCode any
+	if delivery.LastStatusCode != 0 {
+		statusCode = delivery.LastStatusCode
+	}
+
+	tag, err := r.dbFor(ctx).Exec(ctx, query,
+		delivery.ID,
+		delivery.Status.String(),
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		statusCode,
+		optionalString(delivery.LastError),
+		optionalTime(delivery.DeliveredAt),
+	)
+	if err != nil {
+		r.log(ctx).Error("Failed to update webhook delivery",
+			zap.Int64("delivery_id", delivery.ID),
+			zap.Error(err))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domainErrors.NotFound(fmt.Sprintf("webhook delivery %d", delivery.ID))
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id int64) (*entities.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE delivery_id = $1`
+
+	delivery, err := scanWebhookDelivery(r.dbFor(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.NotFound(fmt.Sprintf("webhook delivery %d", id))
+		}
+
+		r.log(ctx).Error("Failed to get webhook delivery",
+			zap.Int64("delivery_id", id),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, filter repo.WebhookDeliveryFilter) ([]*entities.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE ($1 = '' OR webhook_id = $1)
+		  AND ($2 = '' OR status = $2)
+		ORDER BY delivery_id DESC
+		LIMIT $3
+	`
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultDeliveryListLimit
+	}
+
+	rows, err := r.dbFor(ctx).Query(ctx, query, filter.SubscriptionID, filter.Status.String(), limit)
+	if err != nil {
+		r.log(ctx).Error("Failed to list webhook deliveries", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries, err := collectWebhookDeliveries(rows)
+	if err != nil {
+		r.log(ctx).Error("Failed to read webhook deliveries", zap.Error(err))
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+const webhookDeliveryColumns = `
+	delivery_id, webhook_id, event_id, event_type, payload, status, attempts,
+	next_attempt_at, last_status_code, last_error, created_at, delivered_at`
+
+func collectWebhookDeliveries(rows pgx.Rows) ([]*entities.WebhookDelivery, error) {
+	var deliveries []*entities.WebhookDelivery
+
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func scanWebhookDelivery(row rowScanner) (*entities.WebhookDelivery, error) {
+	var (
+		delivery    entities.WebhookDelivery
+		eventType   string
+		status      string
+		statusCode  sql.NullInt32
+		lastError   sql.NullString
+		deliveredAt sql.NullTime
+	)
+
+	if err := row.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&eventType,
+		&delivery.Payload,
+		&status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&statusCode,
+		&lastError,
+		&delivery.CreatedAt,
+		&deliveredAt,
+	); err != nil {
+		return nil, err
+	}
+
+	delivery.EventType = types.EventType(eventType)
+	delivery.Status = types.DeliveryStatus(status)
+	delivery.LastStatusCode = int(statusCode.Int32)
+	delivery.LastError = lastError.String
+
+	if deliveredAt.Valid {
+		t := deliveredAt.Time.UTC()
+		delivery.DeliveredAt = &t
+	}
+
+	return &delivery, nil
+}
+
+func scanWebhookSubscription(row rowScanner) (*entities.WebhookSubscription, error) {
+	var (
+		sub        entities.WebhookSubscription
+		eventTypes []string
+		teamName   sql.NullString
+	)
+
+	if err := row.Scan(&sub.ID, &sub.URL, &sub.Secret, &eventTypes, &teamName, &sub.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	sub.TeamName = teamName.String
+	sub.EventTypes = make([]types.EventType, 0, len(eventTypes))
+	for _, raw := range eventTypes {
+		if eventType, ok := types.ParseEventType(raw); ok {
+			sub.EventTypes = append(sub.EventTypes, eventType)
+		}
+	}
+
+	return &sub, nil
+}
+
+func eventTypesToStrings(eventTypes []types.EventType) []string {
+	result := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		result = append(result, eventType.String())
+	}
+
+	return result
+}
+
+func (r *WebhookRepository) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
+
+func (r *WebhookRepository) dbFor(ctx context.Context) DB {
+	if tx := DBFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	return r.db
+}
@@ -0,0 +1,137 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
+)
+
+// SMTPSettings: STARTTLS is used whenever the server offers it.
+type SMTPSettings struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+type SMTPSender struct {
+	settings SMTPSettings
+}
+
+func NewSMTPSender(settings SMTPSettings) *SMTPSender {
+	if settings.Timeout <= 0 {
+		settings.Timeout = 10 * time.Second
+	}
+
+	return &SMTPSender{settings: settings}
+}
+
+func (s *SMTPSender) SendDigest(ctx context.Context, digest *entities.ReviewDigest) (bool, error) {
+	if digest.User.Email == "" {
+		return false, nil
+	}
+
+	if err := s.send(ctx, digest.User.Email, digest.Subject, digest.Text, digest.GeneratedAt); err != nil {
+		return false, fmt.Errorf("send digest email: %w", err)
+	}
+
+	return true, nil
+}
+
+func (s *SMTPSender) send(ctx context.Context, to, subject, text string, at time.Time) error {
+	addr := net.JoinHostPort(s.settings.Host, strconv.Itoa(s.settings.Port))
+
+	dialer := net.Dialer{Timeout: s.settings.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(s.settings.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, s.settings.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.settings.Host}); err != nil {
+			return err
+		}
+	}
+
+	if s.settings.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.settings.Username, s.settings.Password, s.settings.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.settings.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(s.message(to, subject, text, at)); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (s *SMTPSender) message(to, subject, text string, at time.Time) []byte {
+	var msg strings.Builder
+
+	headers := [][2]string{
+		{"From", s.settings.From},
+		{"To", to},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", at.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, header := range headers {
+		msg.WriteString(header[0] + ": " + header[1] + "\r\n")
+	}
+	msg.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&msg)
+	_, _ = qp.Write([]byte(strings.ReplaceAll(text, "\n", "\r\n")))
+	_ = qp.Close()
+
+	return []byte(msg.String())
+}
+
+var _ eventports.DigestSender = (*SMTPSender)(nil)
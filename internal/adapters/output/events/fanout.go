@@ -0,0 +1,27 @@
+package events
+
+import (
+	"context"
+	"errors"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
+)
+
+// Fanout publishes every event to all publishers. If any of them fails the
+// event is reported as failed and will be offered to all of them again, so
+// each publisher must tolerate duplicates.
+type Fanout []eventports.Publisher
+
+func (f Fanout) Publish(ctx context.Context, event *entities.Event) error {
+	var errs []error
+	for _, publisher := range f {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+var _ eventports.Publisher = Fanout(nil)
@@ -0,0 +1,34 @@
+package events
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
+	"pr-reviewer-assignment/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+// LogPublisher writes events to the service log; it is the default sink when
+// no external subscriber is configured.
+type LogPublisher struct {
+	logger *zap.Logger
+}
+
+func NewLogPublisher(logger *zap.Logger) *LogPublisher {
+	return &LogPublisher{logger: logger}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, event *entities.Event) error {
+	logger.FromContext(ctx, p.logger).Info("Domain event",
+		zap.Int64("event_id", event.ID),
+		zap.String("event_type", event.Type.String()),
+		zap.String("team_name", event.TeamName),
+		zap.String("pr_id", event.Data.PullRequestID),
+		zap.String("reviewer_id", event.Data.ReviewerID),
+		zap.String("user_id", event.Data.UserID))
+	return nil
+}
+
+var _ eventports.Publisher = (*LogPublisher)(nil)
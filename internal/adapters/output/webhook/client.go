@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
+)
+
+// maxResponseDrain bounds how much of a receiver's response is read so the
+// connection can be reused without trusting the receiver's body size.
+const maxResponseDrain = 64 << 10
+
+type HTTPClient struct {
+	client *http.Client
+}
+
+func NewHTTPClient(timeout time.Duration) *HTTPClient {
+	return &HTTPClient{client: &http.Client{Timeout: timeout}}
+}
+
+func (c *HTTPClient) Send(ctx context.Context, req eventports.WebhookRequest) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	for key, value := range req.Headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseDrain))
+	return resp.StatusCode, nil
+}
+
+var _ eventports.WebhookClient = (*HTTPClient)(nil)
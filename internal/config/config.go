@@ -6,18 +6,211 @@ import (
 	"io/fs"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
+	Server       ServerConfig
+	GRPC         GRPCConfig
+	Database     DatabaseConfig
+	Stats        StatsConfig
+	Tracing      TracingConfig
+	Health       HealthConfig
+	Auth         AuthConfig
+	RateLimit    RateLimitConfig
+	Idempotency  IdempotencyConfig
+	Outbox       OutboxConfig
+	Webhooks     WebhookConfig
+	GitHub       GitHubConfig
+	GitLab       GitLabConfig
+	CodeHostSync CodeHostSyncConfig
+	Notifier     NotifierConfig
+	Escalation   EscalationConfig
+	Digest       DigestConfig
+	SMTP         SMTPConfig
+	EventStream  EventStreamConfig
+	GraphQL      GraphQLConfig
 }
 
 type ServerConfig struct {
 	Port string
 	Mode string
+	// TrustedProxies limits whose X-Forwarded-For is believed when resolving
+	// client IPs; empty keeps gin's default of trusting every hop.
+	TrustedProxies []string
+}
+
+// GRPCConfig: the gRPC API listens on its own port; an empty Port disables
+// it.
+type GRPCConfig struct {
+	Port string
+}
+
+// RateLimitConfig uses the spec format of ratelimit.ParseRules.
+type RateLimitConfig struct {
+	Enabled bool
+	Default string
+	Routes  string
+	PerIP   string
+}
+
+// IdempotencyConfig: LockTimeout is how long a key stays reserved by a request
+// that never finished (e.g. the instance crashed) before a retry may take it.
+type IdempotencyConfig struct {
+	TTL             time.Duration
+	LockTimeout     time.Duration
+	CleanupInterval time.Duration
+}
+
+// OutboxConfig: published events are kept for Retention before cleanup.
+type OutboxConfig struct {
+	PollInterval    time.Duration
+	BatchSize       int
+	Retention       time.Duration
+	CleanupInterval time.Duration
+}
+
+// WebhookConfig: failed deliveries are retried after BaseBackoff, doubling up
+// to MaxBackoff, and dead-lettered after MaxAttempts.
+type WebhookConfig struct {
+	PollInterval time.Duration
+	Timeout      time.Duration
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	BatchSize    int
+}
+
+// GitHubConfig: the inbound webhook is served only when WebhookSecret is set,
+// since it is the endpoint's only authentication.
+type GitHubConfig struct {
+	WebhookSecret string
+	APIToken      string
+	APIURL        string
+	WebURL        string
+}
+
+// GitLabConfig: like GitHubConfig, the inbound webhook is served only when
+// WebhookToken is set.
+type GitLabConfig struct {
+	WebhookToken string
+	WebURL       string
+}
+
+type CodeHostSyncConfig struct {
+	PollInterval time.Duration
+	Timeout      time.Duration
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	BatchSize    int
+}
+
+// NotifierConfig: chat notifications of a team raised within BatchWindow of
+// each other go out as one message; failed sends are retried like webhook
+// deliveries. Empty templates use the built-in ones.
+type NotifierConfig struct {
+	PollInterval     time.Duration
+	Timeout          time.Duration
+	MaxAttempts      int
+	BaseBackoff      time.Duration
+	MaxBackoff       time.Duration
+	BatchSize        int
+	BatchWindow      time.Duration
+	AssignedTemplate string
+	ReplacedTemplate string
+	ReminderTemplate string
+	DigestTemplate   string
+	PullRequestURL   string
+}
+
+type EscalationConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+type DigestConfig struct {
+	Enabled      bool
+	Hour         int
+	PollInterval time.Duration
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+type EventStreamConfig struct {
+	PollInterval time.Duration
+	Heartbeat    time.Duration
+	Buffer       int
+}
+
+type GraphQLConfig struct {
+	MaxDepth int
+	MaxCost  int
+}
+
+type StatsConfig struct {
+	SnapshotInterval time.Duration
+}
+
+type HealthConfig struct {
+	CheckTimeout            time.Duration
+	PoolSaturationThreshold float64
+	ShutdownDrainDelay      time.Duration
+}
+
+// AuthConfig toggles API key authentication. BootstrapKey, when set, is
+// accepted as a token carrying every scope so the first real keys can be
+// issued.
+type AuthConfig struct {
+	Enabled      bool
+	BootstrapKey string
+	JWT          JWTConfig
+}
+
+// validate refuses an enabled auth setup nobody could log in to: without a
+// bootstrap key or an IdP there is no way to issue the first API key.
+func (c AuthConfig) validate() error {
+	if c.Enabled && c.BootstrapKey == "" && !c.JWT.Enabled() {
+		return errors.New("AUTH_BOOTSTRAP_KEY is required when AUTH_ENABLED is true and no JWKS is configured")
+	}
+
+	return nil
+}
+
+// JWTConfig enables bearer JWTs when either JWKSFile or JWKSURL is set.
+// RolesClaim may be a dotted path such as "realm_access.roles"; RoleMapping
+// renames IdP values to roles, e.g. "pr-admins=admin,pr-leads=team-lead".
+type JWTConfig struct {
+	JWKSFile            string
+	JWKSURL             string
+	JWKSRefreshInterval time.Duration
+	Issuer              string
+	Audience            string
+	RolesClaim          string
+	RoleMapping         string
+	ClockSkew           time.Duration
+}
+
+func (c JWTConfig) Enabled() bool {
+	return c.JWKSFile != "" || c.JWKSURL != ""
+}
+
+type TracingConfig struct {
+	Exporter     string
+	OTLPEndpoint string
+	FilePath     string
+	ServiceName  string
+	SampleRatio  float64
 }
 
 type DatabaseConfig struct {
@@ -27,6 +220,13 @@ type DatabaseConfig struct {
 	User     string
 	Password string
 	SSLMode  string
+	MaxConns int
+	MinConns int
+	// TxMaxRetries bounds how often a transaction aborted with a serialization
+	// failure or deadlock is replayed.
+	TxMaxRetries     int
+	TxRetryBaseDelay time.Duration
+	TxRetryMaxDelay  time.Duration
 }
 
 func Load() (*Config, error) {
@@ -34,10 +234,15 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("error loading .env file: %w", err)
 	}
 
-	return &Config{
+	cfg := &Config{
 		Server: ServerConfig{
 			Port: getEnv("PORT", "8080"),
 			Mode: getEnv("GIN_MODE", "debug"),
+
+			TrustedProxies: getEnvList("TRUSTED_PROXIES"),
+		},
+		GRPC: GRPCConfig{
+			Port: getEnv("GRPC_PORT", "9090"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -46,8 +251,132 @@ func Load() (*Config, error) {
 			User:     getEnv("DB_USER", "postgres"),
 			Password: getEnv("DB_PASSWORD", "password"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			MaxConns: getEnvInt("DB_MAX_CONNS", 25),
+			MinConns: getEnvInt("DB_MIN_CONNS", 5),
+
+			TxMaxRetries:     getEnvInt("DB_TX_MAX_RETRIES", 5),
+			TxRetryBaseDelay: getEnvDuration("DB_TX_RETRY_BASE_DELAY", 10*time.Millisecond),
+			TxRetryMaxDelay:  getEnvDuration("DB_TX_RETRY_MAX_DELAY", 500*time.Millisecond),
+		},
+		Stats: StatsConfig{
+			SnapshotInterval: getEnvDuration("STATS_SNAPSHOT_INTERVAL", 5*time.Minute),
+		},
+		Health: HealthConfig{
+			CheckTimeout:            getEnvDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+			PoolSaturationThreshold: getEnvFloat("HEALTH_POOL_SATURATION_THRESHOLD", 0),
+			ShutdownDrainDelay:      getEnvDuration("SHUTDOWN_DRAIN_DELAY", 5*time.Second),
+		},
+		Auth: AuthConfig{
+			Enabled:      getEnvBool("AUTH_ENABLED", true),
+			BootstrapKey: getEnv("AUTH_BOOTSTRAP_KEY", ""),
+			JWT: JWTConfig{
+				JWKSFile:            getEnv("JWT_JWKS_FILE", ""),
+				JWKSURL:             getEnv("JWT_JWKS_URL", ""),
+				JWKSRefreshInterval: getEnvDuration("JWT_JWKS_REFRESH_INTERVAL", 15*time.Minute),
+				Issuer:              getEnv("JWT_ISSUER", ""),
+				Audience:            getEnv("JWT_AUDIENCE", ""),
+				RolesClaim:          getEnv("JWT_ROLES_CLAIM", "roles"),
+				RoleMapping:         getEnv("JWT_ROLE_MAPPING", ""),
+				ClockSkew:           getEnvDuration("JWT_CLOCK_SKEW", 30*time.Second),
+			},
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: getEnvBool("RATE_LIMIT_ENABLED", true),
+			Default: getEnv("RATE_LIMIT_DEFAULT", "600/m:100"),
+			Routes:  getEnv("RATE_LIMIT_ROUTES", "POST /pullRequest/create=60/m:10"),
+			PerIP:   getEnv("RATE_LIMIT_PER_IP", "1200/m:200"),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:             getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+			LockTimeout:     getEnvDuration("IDEMPOTENCY_LOCK_TIMEOUT", time.Minute),
+			CleanupInterval: getEnvDuration("IDEMPOTENCY_CLEANUP_INTERVAL", time.Hour),
+		},
+		Outbox: OutboxConfig{
+			PollInterval:    getEnvDuration("OUTBOX_POLL_INTERVAL", time.Second),
+			BatchSize:       getEnvInt("OUTBOX_BATCH_SIZE", 100),
+			Retention:       getEnvDuration("OUTBOX_RETENTION", 7*24*time.Hour),
+			CleanupInterval: getEnvDuration("OUTBOX_CLEANUP_INTERVAL", time.Hour),
+		},
+		Webhooks: WebhookConfig{
+			PollInterval: getEnvDuration("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+			Timeout:      getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:  getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			BaseBackoff:  getEnvDuration("WEBHOOK_BASE_BACKOFF", 30*time.Second),
+			MaxBackoff:   getEnvDuration("WEBHOOK_MAX_BACKOFF", time.Hour),
+			BatchSize:    getEnvInt("WEBHOOK_BATCH_SIZE", 50),
+		},
+		GitHub: GitHubConfig{
+			WebhookSecret: getEnv("GITHUB_WEBHOOK_SECRET", ""),
+			APIToken:      getEnv("GITHUB_TOKEN", ""),
+			APIURL:        getEnv("GITHUB_API_URL", "https://api.github.com"),
+			WebURL:        getEnv("GITHUB_WEB_URL", "https://github.com"),
 		},
-	}, nil
+		GitLab: GitLabConfig{
+			WebhookToken: getEnv("GITLAB_WEBHOOK_TOKEN", ""),
+			WebURL:       getEnv("GITLAB_WEB_URL", "https://gitlab.com"),
+		},
+		CodeHostSync: CodeHostSyncConfig{
+			PollInterval: getEnvDuration("CODEHOST_SYNC_POLL_INTERVAL", 5*time.Second),
+			Timeout:      getEnvDuration("CODEHOST_SYNC_TIMEOUT", 10*time.Second),
+			MaxAttempts:  getEnvInt("CODEHOST_SYNC_MAX_ATTEMPTS", 8),
+			BaseBackoff:  getEnvDuration("CODEHOST_SYNC_BASE_BACKOFF", 30*time.Second),
+			MaxBackoff:   getEnvDuration("CODEHOST_SYNC_MAX_BACKOFF", time.Hour),
+			BatchSize:    getEnvInt("CODEHOST_SYNC_BATCH_SIZE", 50),
+		},
+		Notifier: NotifierConfig{
+			PollInterval:     getEnvDuration("NOTIFY_POLL_INTERVAL", 5*time.Second),
+			Timeout:          getEnvDuration("NOTIFY_TIMEOUT", 10*time.Second),
+			MaxAttempts:      getEnvInt("NOTIFY_MAX_ATTEMPTS", 5),
+			BaseBackoff:      getEnvDuration("NOTIFY_BASE_BACKOFF", 30*time.Second),
+			MaxBackoff:       getEnvDuration("NOTIFY_MAX_BACKOFF", 30*time.Minute),
+			BatchSize:        getEnvInt("NOTIFY_BATCH_SIZE", 100),
+			BatchWindow:      getEnvDuration("NOTIFY_BATCH_WINDOW", 10*time.Second),
+			AssignedTemplate: getEnv("NOTIFY_ASSIGNED_TEMPLATE", ""),
+			ReplacedTemplate: getEnv("NOTIFY_REPLACED_TEMPLATE", ""),
+			ReminderTemplate: getEnv("NOTIFY_REMINDER_TEMPLATE", ""),
+			DigestTemplate:   getEnv("NOTIFY_DIGEST_TEMPLATE", ""),
+			PullRequestURL:   getEnv("NOTIFY_PR_URL", ""),
+		},
+		Escalation: EscalationConfig{
+			PollInterval: getEnvDuration("ESCALATION_POLL_INTERVAL", time.Minute),
+			BatchSize:    getEnvInt("ESCALATION_BATCH_SIZE", 100),
+		},
+		Digest: DigestConfig{
+			Enabled:      getEnvBool("DIGEST_ENABLED", false),
+			Hour:         getEnvInt("DIGEST_HOUR", 9),
+			PollInterval: getEnvDuration("DIGEST_POLL_INTERVAL", 5*time.Minute),
+		},
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnvInt("SMTP_PORT", 587),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", "pr-reviewer@localhost"),
+			Timeout:  getEnvDuration("SMTP_TIMEOUT", 10*time.Second),
+		},
+		EventStream: EventStreamConfig{
+			PollInterval: getEnvDuration("EVENTS_STREAM_POLL_INTERVAL", time.Second),
+			Heartbeat:    getEnvDuration("EVENTS_STREAM_HEARTBEAT", 15*time.Second),
+			Buffer:       getEnvInt("EVENTS_STREAM_BUFFER", 256),
+		},
+		GraphQL: GraphQLConfig{
+			MaxDepth: getEnvInt("GRAPHQL_MAX_DEPTH", 6),
+			MaxCost:  getEnvInt("GRAPHQL_MAX_COST", 25000),
+		},
+		Tracing: TracingConfig{
+			Exporter:     getEnv("TRACING_EXPORTER", "none"),
+			OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+			FilePath:     getEnv("TRACING_FILE", ""),
+			ServiceName:  getEnv("OTEL_SERVICE_NAME", "pr-reviewer-assignment"),
+			SampleRatio:  getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+		},
+	}
+
+	if err := cfg.Auth.validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
 }
 
 func (d *DatabaseConfig) GetDSN() string {
@@ -70,3 +399,40 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+type APIKey struct {
+	ID         string
+	Name       string
+	Hash       string
+	Scopes     []types.Scope
+	Teams      []string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k *APIKey) Revoke(at time.Time) {
+	if k.RevokedAt != nil {
+		return
+	}
+
+	k.RevokedAt = &at
+}
+
+type PrincipalKind string
+
+const (
+	PrincipalAPIKey    PrincipalKind = "api_key"
+	PrincipalBootstrap PrincipalKind = "bootstrap"
+	PrincipalJWT       PrincipalKind = "jwt"
+)
+
+// Principal is the authenticated caller. UserID links it to a row in users
+// and is empty for API keys, which instead act as leads of the teams they are
+// bound to.
+type Principal struct {
+	Subject  string
+	UserID   string
+	Kind     PrincipalKind
+	Scopes   []types.Scope
+	Roles    []types.Role
+	LedTeams []string
+}
+
+func (p *Principal) HasScope(scope types.Scope) bool {
+	return p != nil && slices.Contains(p.Scopes, scope)
+}
+
+func (p *Principal) HasRole(role types.Role) bool {
+	return p != nil && slices.Contains(p.Roles, role)
+}
+
+// CanManageTeam reports whether the principal may change the team's
+// membership or its members' activity without a team_roles lookup.
+func (p *Principal) CanManageTeam(teamName string) bool {
+	return p != nil && (p.HasRole(types.RoleAdmin) || slices.Contains(p.LedTeams, teamName))
+}
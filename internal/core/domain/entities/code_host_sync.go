@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+// CodeHostSyncJob: there is one job per pull request, re-armed by new changes.
+// Revision grows with every re-arm so a worker finishing an older revision
+// does not overwrite the newer one.
+type CodeHostSyncJob struct {
+	PullRequestID    string
+	RemovedReviewers []string
+	Status           types.DeliveryStatus
+	Attempts         int
+	NextAttemptAt    time.Time
+	LastError        string
+	Revision         int64
+	UpdatedAt        time.Time
+}
+
+func (j *CodeHostSyncJob) MarkSynced(at time.Time) {
+	j.Attempts++
+	j.Status = types.DeliveryDelivered
+	j.RemovedReviewers = nil
+	j.LastError = ""
+	j.UpdatedAt = at
+}
+
+func (j *CodeHostSyncJob) MarkFailed(reason string, permanent bool, maxAttempts int, retryAt, at time.Time) {
+	j.Attempts++
+	j.LastError = reason
+	j.UpdatedAt = at
+
+	if permanent || j.Attempts >= maxAttempts {
+		j.Status = types.DeliveryDead
+		return
+	}
+
+	j.Status = types.DeliveryPending
+	j.NextAttemptAt = retryAt
+}
@@ -0,0 +1,55 @@
+package entities
+
+import "time"
+
+// DigestRecipient.LastSentOn is the local day of the last digest, zero if
+// none was sent yet.
+type DigestRecipient struct {
+	User       *User
+	LastSentOn time.Time
+}
+
+// DueOn reports the user's local day at now and whether the digest for it is
+// due: the local hour has reached hour and no digest went out that day.
+func (r *DigestRecipient) DueOn(now time.Time, hour int) (time.Time, bool) {
+	local := now.In(r.User.Location())
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+	if local.Hour() < hour {
+		return day, false
+	}
+
+	return day, r.LastSentOn.Before(day)
+}
+
+type PendingReview struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	AuthorName      string
+	CreatedAt       time.Time
+	AssignedAt      time.Time
+	Link            string
+}
+
+type ReviewDigest struct {
+	User        *User
+	Day         time.Time
+	Reviews     []*PendingReview
+	GeneratedAt time.Time
+	Subject     string
+	Text        string
+}
+
+func NewReviewDigest(user *User, day time.Time, reviews []*PendingReview, at time.Time) *ReviewDigest {
+	return &ReviewDigest{
+		User:        user,
+		Day:         day,
+		Reviews:     reviews,
+		GeneratedAt: at,
+	}
+}
+
+func (d *ReviewDigest) Age(review *PendingReview) time.Duration {
+	return d.GeneratedAt.Sub(review.CreatedAt)
+}
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+// ReviewSLA: a zero EscalateAfter never replaces anyone.
+type ReviewSLA struct {
+	TeamName      string
+	RemindAfter   time.Duration
+	EscalateAfter time.Duration
+	UpdatedAt     time.Time
+}
+
+type OverdueReview struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	TeamName        string
+	ReviewerID      string
+	AssignedAt      time.Time
+	Reminded        bool
+	SLA             ReviewSLA
+}
+
+// Due reports which action the review needs at now, if any. A reviewer is
+// always reminded before being replaced, even when the escalation threshold
+// has already passed.
+func (r *OverdueReview) Due(now time.Time) (types.EscalationAction, bool) {
+	waited := now.Sub(r.AssignedAt)
+
+	if !r.Reminded {
+		return types.EscalationReminded, waited >= r.SLA.RemindAfter
+	}
+
+	if r.SLA.EscalateAfter > 0 && waited >= r.SLA.EscalateAfter {
+		return types.EscalationReassigned, true
+	}
+
+	return "", false
+}
+
+type ReviewEscalation struct {
+	ID            int64
+	PullRequestID string
+	TeamName      string
+	ReviewerID    string
+	AssignedAt    time.Time
+	Action        types.EscalationAction
+	ReplacedBy    string
+	CreatedAt     time.Time
+}
+
+func NewReviewEscalation(review *OverdueReview, action types.EscalationAction, replacedBy string, at time.Time) *ReviewEscalation {
+	return &ReviewEscalation{
+		PullRequestID: review.PullRequestID,
+		TeamName:      review.TeamName,
+		ReviewerID:    review.ReviewerID,
+		AssignedAt:    review.AssignedAt,
+		Action:        action,
+		ReplacedBy:    replacedBy,
+		CreatedAt:     at,
+	}
+}
@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+// Event is a domain fact recorded in the outbox alongside the change that
+// caused it. ID is assigned by the outbox and orders delivery. Position is
+// assigned on publication and, unlike ID, never appears below one a reader has
+// already seen, so it makes a resumable cursor.
+type Event struct {
+	ID         int64
+	Position   int64
+	Type       types.EventType
+	TeamName   string
+	Data       EventData
+	OccurredAt time.Time
+}
+
+// EventData carries the fields relevant to the event type; the rest stay
+// empty. Reviewers is the pull request's reviewer list after the change.
+type EventData struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	Status          types.PRStatus
+	Reviewers       []string
+	CreatedAt       time.Time
+	MergedAt        *time.Time
+	Version         int
+	ReviewerID      string
+	OldReviewerID   string
+	UserID          string
+}
+
+func NewPullRequestCreated(pr *PullRequest, teamName string, at time.Time) *Event {
+	return newPullRequestEvent(types.EventPullRequestCreated, pr, teamName, at)
+}
+
+func NewReviewerAssigned(pr *PullRequest, teamName, reviewerID string, at time.Time) *Event {
+	event := newPullRequestEvent(types.EventReviewerAssigned, pr, teamName, at)
+	event.Data.ReviewerID = reviewerID
+	return event
+}
+
+// NewReviewerReplaced records oldReviewerID leaving the pull request;
+// newReviewerID is empty when no replacement was available.
+func NewReviewerReplaced(pr *PullRequest, teamName, oldReviewerID, newReviewerID string, at time.Time) *Event {
+	event := newPullRequestEvent(types.EventReviewerReplaced, pr, teamName, at)
+	event.Data.OldReviewerID = oldReviewerID
+	event.Data.ReviewerID = newReviewerID
+	return event
+}
+
+func NewPullRequestMerged(pr *PullRequest, teamName string, at time.Time) *Event {
+	return newPullRequestEvent(types.EventPullRequestMerged, pr, teamName, at)
+}
+
+func NewPullRequestClosed(pr *PullRequest, teamName string, at time.Time) *Event {
+	return newPullRequestEvent(types.EventPullRequestClosed, pr, teamName, at)
+}
+
+func NewPullRequestReopened(pr *PullRequest, teamName string, at time.Time) *Event {
+	return newPullRequestEvent(types.EventPullRequestReopened, pr, teamName, at)
+}
+
+func NewUserDeactivated(user *User, at time.Time) *Event {
+	return &Event{
+		Type:       types.EventUserDeactivated,
+		TeamName:   user.TeamName,
+		Data:       EventData{UserID: user.ID},
+		OccurredAt: at,
+	}
+}
+
+func NewUserActivated(user *User, at time.Time) *Event {
+	return &Event{
+		Type:       types.EventUserActivated,
+		TeamName:   user.TeamName,
+		Data:       EventData{UserID: user.ID},
+		OccurredAt: at,
+	}
+}
+
+func newPullRequestEvent(eventType types.EventType, pr *PullRequest, teamName string, at time.Time) *Event {
+	return &Event{
+		Type:     eventType,
+		TeamName: teamName,
+		Data: EventData{
+			PullRequestID:   pr.ID,
+			PullRequestName: pr.Name,
+			AuthorID:        pr.AuthorID,
+			Status:          pr.Status,
+			Reviewers:       append([]string(nil), pr.AssignedReviewers...),
+			CreatedAt:       pr.CreatedAt,
+			MergedAt:        pr.MergedAt,
+			Version:         pr.Version,
+		},
+		OccurredAt: at,
+	}
+}
+
+func (e *Event) Involves(userID string) bool {
+	if userID == "" {
+		return false
+	}
+
+	d := e.Data
+	return userID == d.AuthorID || userID == d.ReviewerID || userID == d.OldReviewerID || userID == d.UserID ||
+		slices.Contains(d.Reviewers, userID)
+}
+
+// PullRequest rebuilds the pull request as it was right after the event; it
+// is nil for events that are not about a pull request.
+func (e *Event) PullRequest() *PullRequest {
+	if e.Data.PullRequestID == "" {
+		return nil
+	}
+
+	pr := &PullRequest{
+		ID:        e.Data.PullRequestID,
+		Name:      e.Data.PullRequestName,
+		AuthorID:  e.Data.AuthorID,
+		Status:    e.Data.Status,
+		CreatedAt: e.Data.CreatedAt,
+		MergedAt:  e.Data.MergedAt,
+		Version:   e.Data.Version,
+	}
+	pr.SetReviewers(append([]string(nil), e.Data.Reviewers...))
+	return pr
+}
@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+type MemberShare struct {
+	UserID      string
+	Username    string
+	Assignments int
+	Share       float64
+	Deviation   float64
+}
+
+type FairnessReport struct {
+	TeamName         string
+	From             *time.Time
+	To               *time.Time
+	TotalAssignments int
+	IdealShare       float64
+	Gini             float64
+	Members          []MemberShare
+	LargestDeviation []MemberShare
+}
+
+const FairnessTopDeviations = 3
+
+func NewFairnessReport(team *Team, assignments map[string]int, from, to *time.Time) *FairnessReport {
+	report := &FairnessReport{
+		TeamName: team.Name,
+		From:     from,
+		To:       to,
+	}
+
+	active := team.ActiveMembersExcluding("")
+	if len(active) == 0 {
+		return report
+	}
+
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].ID < active[j].ID
+	})
+
+	counts := make([]int, 0, len(active))
+	for _, member := range active {
+		count := assignments[member.ID]
+		report.TotalAssignments += count
+		counts = append(counts, count)
+	}
+
+	report.IdealShare = 1 / float64(len(active))
+	report.Gini = giniCoefficient(counts)
+
+	report.Members = make([]MemberShare, 0, len(active))
+	for _, member := range active {
+		count := assignments[member.ID]
+
+		var share float64
+		if report.TotalAssignments > 0 {
+			share = float64(count) / float64(report.TotalAssignments)
+		}
+
+		report.Members = append(report.Members, MemberShare{
+			UserID:      member.ID,
+			Username:    member.Username,
+			Assignments: count,
+			Share:       share,
+			Deviation:   share - report.IdealShare,
+		})
+	}
+
+	if report.TotalAssignments == 0 {
+		return report
+	}
+
+	byDeviation := append([]MemberShare(nil), report.Members...)
+	sort.SliceStable(byDeviation, func(i, j int) bool {
+		return math.Abs(byDeviation[i].Deviation) > math.Abs(byDeviation[j].Deviation)
+	})
+
+	report.LargestDeviation = byDeviation[:min(FairnessTopDeviations, len(byDeviation))]
+	return report
+}
+
+// giniCoefficient returns 0 for a perfectly even distribution and approaches 1
+// as assignments concentrate on a single member.
+func giniCoefficient(values []int) float64 {
+	n := len(values)
+	if n == 0 {
+		return 0
+	}
+
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+
+	var total, weighted float64
+	for i, value := range sorted {
+		total += float64(value)
+		weighted += float64(i+1) * float64(value)
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return (2*weighted)/(float64(n)*total) - float64(n+1)/float64(n)
+}
@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// IdempotencyRecord remembers the outcome of a request sent with an
+// Idempotency-Key. StatusCode stays zero while the first request is running.
+type IdempotencyRecord struct {
+	Owner        string
+	Key          string
+	RequestHash  string
+	StatusCode   int
+	ContentType  string
+	ETag         string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+// ExternalAccount links a code host login to a user here. Logins are stored
+// lower-cased since both GitHub and GitLab treat them case-insensitively.
+type ExternalAccount struct {
+	Provider  types.Provider
+	Login     string
+	UserID    string
+	CreatedAt time.Time
+}
+
+// CodeHostRef.ID renders e.g. "github:acme/widgets#42" or
+// "gitlab:platform/billing!7".
+type CodeHostRef struct {
+	Provider   types.Provider
+	Repository string
+	Number     int
+}
+
+func (r CodeHostRef) ID() string {
+	return fmt.Sprintf("%s:%s%s%d", r.Provider, r.Repository, codeHostNumberSeparator(r.Provider), r.Number)
+}
+
+func ParseCodeHostRef(prID string) (CodeHostRef, bool) {
+	rawProvider, rest, ok := strings.Cut(prID, ":")
+	if !ok {
+		return CodeHostRef{}, false
+	}
+
+	provider, ok := types.ParseProvider(rawProvider)
+	if !ok || string(provider) != rawProvider {
+		return CodeHostRef{}, false
+	}
+
+	index := strings.LastIndex(rest, codeHostNumberSeparator(provider))
+	if index <= 0 {
+		return CodeHostRef{}, false
+	}
+
+	number, err := strconv.Atoi(rest[index+1:])
+	if err != nil || number <= 0 {
+		return CodeHostRef{}, false
+	}
+
+	return CodeHostRef{Provider: provider, Repository: rest[:index], Number: number}, true
+}
+
+func codeHostNumberSeparator(provider types.Provider) string {
+	if provider == types.ProviderGitLab {
+		return "!"
+	}
+
+	return "#"
+}
+
+// CodeHostPullRequestEvent is a provider-neutral pull request webhook.
+// PullRequestID is derived from the provider, repository and number so the
+// same pull request maps to the same ID on every delivery.
+type CodeHostPullRequestEvent struct {
+	Provider      types.Provider
+	Action        types.CodeHostAction
+	PullRequestID string
+	Title         string
+	AuthorLogin   string
+	Draft         bool
+}
+
+// SyncOutcome is what a code host event did: the pull request is nil when the
+// event was ignored before one was found or created. ReviewerLogins maps the
+// pull request's reviewers to their logins on the event's code host so a bot
+// can mirror the assignment there; unlinked reviewers are absent.
+type SyncOutcome struct {
+	Result         types.SyncResult
+	Reason         string
+	PullRequest    *PullRequest
+	ReviewerLogins map[string]string
+}
@@ -0,0 +1,132 @@
+package entities
+
+import (
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+// ChatChannel is where a team's notifications go: a Slack-compatible
+// incoming webhook, optionally overriding its default channel.
+type ChatChannel struct {
+	TeamName   string
+	WebhookURL string
+	Channel    string
+	UpdatedAt  time.Time
+}
+
+// ChatNotification is one line of a chat message. Notifications of a team
+// that are due together are sent as a single message. Reminders and digests
+// have no event; AssignedAt is the start of the assignment they are about, and
+// a digest names its oldest review and counts the rest in ReviewCount.
+type ChatNotification struct {
+	ID              int64
+	TeamName        string
+	EventID         int64
+	Kind            types.NotificationKind
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	ReviewerID      string
+	OldReviewerID   string
+	AssignedAt      *time.Time
+	ReviewCount     int
+	Status          types.DeliveryStatus
+	Attempts        int
+	NextAttemptAt   time.Time
+	LastError       string
+	CreatedAt       time.Time
+	SentAt          *time.Time
+}
+
+// NewChatNotification turns a reviewer event into a notification; ok is false
+// for events that do not notify anyone.
+func NewChatNotification(event *Event, sendAt time.Time) (*ChatNotification, bool) {
+	var kind types.NotificationKind
+
+	switch event.Type {
+	case types.EventReviewerAssigned:
+		kind = types.NotificationAssigned
+	case types.EventReviewerReplaced:
+		kind = types.NotificationReplaced
+	default:
+		return nil, false
+	}
+
+	return &ChatNotification{
+		TeamName:        event.TeamName,
+		EventID:         event.ID,
+		Kind:            kind,
+		PullRequestID:   event.Data.PullRequestID,
+		PullRequestName: event.Data.PullRequestName,
+		AuthorID:        event.Data.AuthorID,
+		ReviewerID:      event.Data.ReviewerID,
+		OldReviewerID:   event.Data.OldReviewerID,
+		Status:          types.DeliveryPending,
+		NextAttemptAt:   sendAt,
+		CreatedAt:       event.OccurredAt,
+	}, true
+}
+
+func NewReminderNotification(review *OverdueReview, at, sendAt time.Time) *ChatNotification {
+	assignedAt := review.AssignedAt
+
+	return &ChatNotification{
+		TeamName:        review.TeamName,
+		Kind:            types.NotificationReminder,
+		PullRequestID:   review.PullRequestID,
+		PullRequestName: review.PullRequestName,
+		AuthorID:        review.AuthorID,
+		ReviewerID:      review.ReviewerID,
+		AssignedAt:      &assignedAt,
+		Status:          types.DeliveryPending,
+		NextAttemptAt:   sendAt,
+		CreatedAt:       at,
+	}
+}
+
+func NewDigestNotification(digest *ReviewDigest, sendAt time.Time) *ChatNotification {
+	oldest := digest.Reviews[0]
+	for _, review := range digest.Reviews[1:] {
+		if review.AssignedAt.Before(oldest.AssignedAt) {
+			oldest = review
+		}
+	}
+	assignedAt := oldest.AssignedAt
+
+	return &ChatNotification{
+		TeamName:        digest.User.TeamName,
+		Kind:            types.NotificationDigest,
+		PullRequestID:   oldest.PullRequestID,
+		PullRequestName: oldest.PullRequestName,
+		AuthorID:        oldest.AuthorID,
+		ReviewerID:      digest.User.ID,
+		AssignedAt:      &assignedAt,
+		ReviewCount:     len(digest.Reviews),
+		Status:          types.DeliveryPending,
+		NextAttemptAt:   sendAt,
+		CreatedAt:       digest.GeneratedAt,
+	}
+}
+
+func (n *ChatNotification) MarkSent(at time.Time) {
+	n.Attempts++
+	n.Status = types.DeliveryDelivered
+	n.LastError = ""
+	n.SentAt = &at
+}
+
+// MarkFailed schedules a retry at retryAt, or gives up once maxAttempts is
+// reached.
+func (n *ChatNotification) MarkFailed(reason string, maxAttempts int, retryAt time.Time) {
+	n.Attempts++
+	n.LastError = reason
+
+	if n.Attempts >= maxAttempts {
+		n.Status = types.DeliveryDead
+		return
+	}
+
+	n.Status = types.DeliveryPending
+	n.NextAttemptAt = retryAt
+}
@@ -17,6 +17,12 @@ type PullRequest struct {
 	NeedMoreReviewers bool
 	CreatedAt         time.Time
 	MergedAt          *time.Time
+	// ChangedAt is when the change being saved was made; reviewers it adds
+	// are assigned at that time. It is not loaded back.
+	ChangedAt time.Time
+	// Version is bumped by every persisted update; an update carrying a stale
+	// version is rejected instead of overwriting a concurrent change.
+	Version int
 }
 
 const MaxReviewers = 2
@@ -34,8 +40,8 @@ func NewPullRequest(id, name, authorID string, createdAt time.Time) *PullRequest
 }
 
 func (p *PullRequest) AssignReviewers(reviewers []string) error {
-	if p.Status == types.PRStatusMerged {
-		return domainErrors.PRMerged(p.ID)
+	if err := p.requireOpen(); err != nil {
+		return err
 	}
 
 	unique := make(map[string]struct{}, len(reviewers))
@@ -70,8 +76,8 @@ func (p *PullRequest) AssignReviewers(reviewers []string) error {
 }
 
 func (p *PullRequest) ReplaceReviewer(oldReviewer, newReviewer string) (string, error) {
-	if p.Status == types.PRStatusMerged {
-		return "", domainErrors.PRMerged(p.ID)
+	if err := p.requireOpen(); err != nil {
+		return "", err
 	}
 
 	index := p.reviewerIndex(oldReviewer)
@@ -105,6 +111,38 @@ func (p *PullRequest) Merge(at time.Time) {
 	p.MergedAt = &at
 }
 
+// Close marks an open pull request as abandoned; closing a closed one is a
+// no-op.
+func (p *PullRequest) Close() error {
+	if p.Status == types.PRStatusMerged {
+		return domainErrors.PRMerged(p.ID)
+	}
+
+	p.Status = types.PRStatusClosed
+	return nil
+}
+
+// Reopen returns a closed pull request to review with its previous reviewers.
+func (p *PullRequest) Reopen() error {
+	if p.Status == types.PRStatusMerged {
+		return domainErrors.PRMerged(p.ID)
+	}
+
+	p.Status = types.PRStatusOpen
+	return nil
+}
+
+func (p *PullRequest) requireOpen() error {
+	switch p.Status {
+	case types.PRStatusMerged:
+		return domainErrors.PRMerged(p.ID)
+	case types.PRStatusClosed:
+		return domainErrors.PRClosed(p.ID)
+	default:
+		return nil
+	}
+}
+
 func (p *PullRequest) HasReviewer(userID string) bool {
 	return p.reviewerIndex(userID) != -1
 }
@@ -1,8 +1,74 @@
 package entities
 
+import (
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
 type Stats struct {
 	Teams        int
 	Users        int
 	PullRequests int
 	Assignments  int
 }
+
+type StatsSample struct {
+	Metric   types.StatsMetric
+	TeamName string
+	Value    int
+}
+
+type StatsSnapshot struct {
+	TakenAt time.Time
+	Samples []StatsSample
+}
+
+func NewStatsSnapshot(takenAt time.Time, stats *Stats, openByTeam map[string]int) *StatsSnapshot {
+	snapshot := &StatsSnapshot{
+		TakenAt: takenAt,
+		Samples: []StatsSample{
+			{Metric: types.StatsMetricTeams, Value: stats.Teams},
+			{Metric: types.StatsMetricUsers, Value: stats.Users},
+			{Metric: types.StatsMetricPullRequests, Value: stats.PullRequests},
+			{Metric: types.StatsMetricAssignments, Value: stats.Assignments},
+		},
+	}
+
+	var totalOpen int
+	for teamName, open := range openByTeam {
+		totalOpen += open
+		snapshot.Samples = append(snapshot.Samples, StatsSample{
+			Metric:   types.StatsMetricOpenPullRequests,
+			TeamName: teamName,
+			Value:    open,
+		})
+	}
+
+	snapshot.Samples = append(snapshot.Samples, StatsSample{
+		Metric: types.StatsMetricOpenPullRequests,
+		Value:  totalOpen,
+	})
+
+	return snapshot
+}
+
+type StatsHistoryQuery struct {
+	Metric   types.StatsMetric
+	TeamName string
+	From     time.Time
+	To       time.Time
+	Bucket   types.StatsBucket
+}
+
+type StatsPoint struct {
+	Bucket time.Time
+	Last   int
+	Min    int
+	Max    int
+}
+
+type StatsHistory struct {
+	Query  StatsHistoryQuery
+	Points []StatsPoint
+}
@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+// TeamRole grants a user a role within a single team. Admins are global and
+// come from the caller's credentials instead.
+type TeamRole struct {
+	TeamName  string
+	UserID    string
+	Role      types.Role
+	CreatedAt time.Time
+}
@@ -2,22 +2,61 @@ package entities
 
 import "time"
 
+const DefaultTimezone = "UTC"
+
 type User struct {
-	ID        string
-	Username  string
-	TeamName  string
-	IsActive  bool
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID            string
+	Username      string
+	TeamName      string
+	IsActive      bool
+	Email         string
+	Timezone      string
+	DigestEnabled bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 func NewUser(id, username, teamName string, isActive bool, createdAt, updatedAt time.Time) *User {
 	return &User{
-		ID:        id,
-		Username:  username,
-		TeamName:  teamName,
-		IsActive:  isActive,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+		ID:            id,
+		Username:      username,
+		TeamName:      teamName,
+		IsActive:      isActive,
+		Timezone:      DefaultTimezone,
+		DigestEnabled: true,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+	}
+}
+
+// UserPreferences changes the preferences that are set and leaves the rest.
+type UserPreferences struct {
+	Email         *string
+	Timezone      *string
+	DigestEnabled *bool
+}
+
+func (p UserPreferences) IsEmpty() bool {
+	return p.Email == nil && p.Timezone == nil && p.DigestEnabled == nil
+}
+
+func (u *User) ApplyPreferences(p UserPreferences, at time.Time) {
+	if p.Email != nil {
+		u.Email = *p.Email
+	}
+	if p.Timezone != nil {
+		u.Timezone = *p.Timezone
+	}
+	if p.DigestEnabled != nil {
+		u.DigestEnabled = *p.DigestEnabled
+	}
+	u.UpdatedAt = at
+}
+
+// Location is the user's timezone, or UTC when it cannot be loaded.
+func (u *User) Location() *time.Location {
+	if loc, err := time.LoadLocation(u.Timezone); err == nil {
+		return loc
 	}
+	return time.UTC
 }
@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+// WebhookSubscription receives events of EventTypes (all when empty) for
+// TeamName (every team when empty). Secret signs the deliveries.
+type WebhookSubscription struct {
+	ID         string
+	URL        string
+	Secret     string
+	EventTypes []types.EventType
+	TeamName   string
+	CreatedAt  time.Time
+}
+
+func (s *WebhookSubscription) Matches(event *Event) bool {
+	if len(s.EventTypes) > 0 && !slices.Contains(s.EventTypes, event.Type) {
+		return false
+	}
+
+	return s.TeamName == "" || s.TeamName == event.TeamName
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID string
+	EventID        int64
+	EventType      types.EventType
+	Payload        []byte
+	Status         types.DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+func (d *WebhookDelivery) MarkDelivered(statusCode int, at time.Time) {
+	d.Attempts++
+	d.Status = types.DeliveryDelivered
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.DeliveredAt = &at
+}
+
+// MarkFailed records a failed attempt and schedules the next one at retryAt,
+// or dead-letters the delivery once maxAttempts is reached.
+func (d *WebhookDelivery) MarkFailed(statusCode int, reason string, maxAttempts int, retryAt time.Time) {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = reason
+
+	if d.Attempts >= maxAttempts {
+		d.Status = types.DeliveryDead
+		return
+	}
+
+	d.Status = types.DeliveryPending
+	d.NextAttemptAt = retryAt
+}
+
+// Requeue gives a delivery a fresh set of attempts starting at.
+func (d *WebhookDelivery) Requeue(at time.Time) {
+	d.Status = types.DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = at
+	d.DeliveredAt = nil
+}
@@ -8,9 +8,14 @@ const (
 	ErrorCodeTeamExists  ErrorCode = "TEAM_EXISTS"
 	ErrorCodePRExists    ErrorCode = "PR_EXISTS"
 	ErrorCodePRMerged    ErrorCode = "PR_MERGED"
+	ErrorCodePRClosed    ErrorCode = "PR_CLOSED"
 	ErrorCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
 	ErrorCodeNoCandidate ErrorCode = "NO_CANDIDATE"
 	ErrorCodeNotFound    ErrorCode = "NOT_FOUND"
+	ErrorCodeConflict    ErrorCode = "CONFLICT"
+
+	ErrorCodeUnauthorized ErrorCode = "UNAUTHORIZED"
+	ErrorCodeForbidden    ErrorCode = "FORBIDDEN"
 )
 
 type DomainError struct {
@@ -49,6 +54,10 @@ func PRMerged(prID string) error {
 	return NewDomainError(ErrorCodePRMerged, fmt.Sprintf("pull request %s is already merged", prID))
 }
 
+func PRClosed(prID string) error {
+	return NewDomainError(ErrorCodePRClosed, fmt.Sprintf("pull request %s is closed", prID))
+}
+
 func NotAssigned(userID, prID string) error {
 	return NewDomainError(ErrorCodeNotAssigned, fmt.Sprintf("user %s is not assigned to pull request %s", userID, prID))
 }
@@ -60,3 +69,15 @@ func NoCandidate(teamName string) error {
 func NotFound(resource string) error {
 	return NewDomainError(ErrorCodeNotFound, fmt.Sprintf("%s not found", resource))
 }
+
+func Conflict(prID string) error {
+	return NewDomainError(ErrorCodeConflict, fmt.Sprintf("pull request %s was modified concurrently", prID))
+}
+
+func Unauthorized(reason string) error {
+	return NewDomainError(ErrorCodeUnauthorized, reason)
+}
+
+func Forbidden(reason string) error {
+	return NewDomainError(ErrorCodeForbidden, reason)
+}
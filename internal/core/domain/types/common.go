@@ -7,6 +7,9 @@ type PRStatus string
 const (
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	// PRStatusClosed is a pull request abandoned on the code host without
+	// being merged; it can be reopened.
+	PRStatusClosed PRStatus = "CLOSED"
 )
 
 func (s PRStatus) String() string {
@@ -14,7 +17,7 @@ func (s PRStatus) String() string {
 }
 
 func (s PRStatus) IsValid() bool {
-	return s == PRStatusOpen || s == PRStatusMerged
+	return s == PRStatusOpen || s == PRStatusMerged || s == PRStatusClosed
 }
 
 func ParsePRStatus(value string) (PRStatus, bool) {
@@ -23,6 +26,8 @@ func ParsePRStatus(value string) (PRStatus, bool) {
 		return PRStatusOpen, true
 	case PRStatusMerged:
 		return PRStatusMerged, true
+	case PRStatusClosed:
+		return PRStatusClosed, true
 	default:
 		return "", false
 	}
@@ -0,0 +1,14 @@
+package types
+
+type EscalationAction string
+
+const (
+	EscalationReminded   EscalationAction = "reminded"
+	EscalationReassigned EscalationAction = "reassigned"
+	// The reviewer keeps the review and is not escalated again.
+	EscalationNoCandidate EscalationAction = "no_candidate"
+)
+
+func (a EscalationAction) String() string {
+	return string(a)
+}
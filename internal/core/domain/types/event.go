@@ -0,0 +1,44 @@
+package types
+
+import "strings"
+
+type EventType string
+
+const (
+	EventPullRequestCreated  EventType = "pull_request.created"
+	EventReviewerAssigned    EventType = "reviewer.assigned"
+	EventReviewerReplaced    EventType = "reviewer.replaced"
+	EventPullRequestMerged   EventType = "pull_request.merged"
+	EventPullRequestClosed   EventType = "pull_request.closed"
+	EventPullRequestReopened EventType = "pull_request.reopened"
+	EventUserDeactivated     EventType = "user.deactivated"
+	EventUserActivated       EventType = "user.activated"
+)
+
+func AllEventTypes() []EventType {
+	return []EventType{
+		EventPullRequestCreated,
+		EventReviewerAssigned,
+		EventReviewerReplaced,
+		EventPullRequestMerged,
+		EventPullRequestClosed,
+		EventPullRequestReopened,
+		EventUserDeactivated,
+		EventUserActivated,
+	}
+}
+
+func (t EventType) String() string {
+	return string(t)
+}
+
+func ParseEventType(value string) (EventType, bool) {
+	eventType := EventType(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range AllEventTypes() {
+		if eventType == known {
+			return eventType, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,52 @@
+package types
+
+import "strings"
+
+// Provider is a code host that can drive pull requests through its webhooks.
+type Provider string
+
+const (
+	ProviderGitHub Provider = "github"
+	ProviderGitLab Provider = "gitlab"
+)
+
+func (p Provider) String() string {
+	return string(p)
+}
+
+func ParseProvider(value string) (Provider, bool) {
+	switch provider := Provider(strings.ToLower(strings.TrimSpace(value))); provider {
+	case ProviderGitHub, ProviderGitLab:
+		return provider, true
+	default:
+		return "", false
+	}
+}
+
+// CodeHostAction is a pull request lifecycle change reported by a code host,
+// already translated from the provider's own vocabulary.
+type CodeHostAction string
+
+const (
+	CodeHostOpened         CodeHostAction = "opened"
+	CodeHostReadyForReview CodeHostAction = "ready_for_review"
+	CodeHostConvertedDraft CodeHostAction = "converted_to_draft"
+	CodeHostClosed         CodeHostAction = "closed"
+	CodeHostMerged         CodeHostAction = "merged"
+	CodeHostReopened       CodeHostAction = "reopened"
+)
+
+// SyncResult tells the code host what its event did here.
+type SyncResult string
+
+const (
+	SyncCreated  SyncResult = "created"
+	SyncClosed   SyncResult = "closed"
+	SyncMerged   SyncResult = "merged"
+	SyncReopened SyncResult = "reopened"
+	SyncIgnored  SyncResult = "ignored"
+)
+
+func (r SyncResult) String() string {
+	return string(r)
+}
@@ -0,0 +1,15 @@
+package types
+
+// NotificationKind is what a chat notification tells a team about.
+type NotificationKind string
+
+const (
+	NotificationAssigned NotificationKind = "assigned"
+	NotificationReplaced NotificationKind = "replaced"
+	NotificationReminder NotificationKind = "reminder"
+	NotificationDigest   NotificationKind = "digest"
+)
+
+func (k NotificationKind) String() string {
+	return string(k)
+}
@@ -0,0 +1,49 @@
+package types
+
+import "strings"
+
+type Role string
+
+const (
+	RoleViewer    Role = "viewer"
+	RoleDeveloper Role = "developer"
+	RoleTeamLead  Role = "team-lead"
+	RoleAdmin     Role = "admin"
+)
+
+func AllRoles() []Role {
+	return []Role{RoleViewer, RoleDeveloper, RoleTeamLead, RoleAdmin}
+}
+
+func (r Role) String() string {
+	return string(r)
+}
+
+func ParseRole(value string) (Role, bool) {
+	role := Role(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range AllRoles() {
+		if role == known {
+			return role, true
+		}
+	}
+
+	return "", false
+}
+
+// Scopes lists the API scopes a role grants. Services narrow them further:
+// team leads manage only their own teams, and pull request changes are
+// limited to the author, assigned reviewers and leads.
+func (r Role) Scopes() []Scope {
+	switch r {
+	case RoleAdmin:
+		return AllScopes()
+	case RoleTeamLead:
+		return []Scope{ScopeRead, ScopeWritePR, ScopeAdminTeams}
+	case RoleDeveloper:
+		return []Scope{ScopeRead, ScopeWritePR}
+	case RoleViewer:
+		return []Scope{ScopeRead}
+	default:
+		return nil
+	}
+}
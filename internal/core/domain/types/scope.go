@@ -0,0 +1,33 @@
+package types
+
+import "strings"
+
+type Scope string
+
+const (
+	ScopeRead              Scope = "read"
+	ScopeWritePR           Scope = "write:pr"
+	ScopeAdminTeams        Scope = "admin:teams"
+	ScopeAdminKeys         Scope = "admin:keys"
+	ScopeAdminWebhooks     Scope = "admin:webhooks"
+	ScopeAdminIntegrations Scope = "admin:integrations"
+)
+
+func AllScopes() []Scope {
+	return []Scope{ScopeRead, ScopeWritePR, ScopeAdminTeams, ScopeAdminKeys, ScopeAdminWebhooks, ScopeAdminIntegrations}
+}
+
+func (s Scope) String() string {
+	return string(s)
+}
+
+func ParseScope(value string) (Scope, bool) {
+	scope := Scope(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range AllScopes() {
+		if scope == known {
+			return scope, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,54 @@
+package types
+
+import "strings"
+
+type StatsMetric string
+
+const (
+	StatsMetricTeams            StatsMetric = "teams"
+	StatsMetricUsers            StatsMetric = "users"
+	StatsMetricPullRequests     StatsMetric = "pull_requests"
+	StatsMetricAssignments      StatsMetric = "assignments"
+	StatsMetricOpenPullRequests StatsMetric = "open_pull_requests"
+)
+
+func (m StatsMetric) String() string {
+	return string(m)
+}
+
+// IsTeamScoped reports whether the metric is also recorded per team.
+func (m StatsMetric) IsTeamScoped() bool {
+	return m == StatsMetricOpenPullRequests
+}
+
+func ParseStatsMetric(value string) (StatsMetric, bool) {
+	switch metric := StatsMetric(strings.ToLower(value)); metric {
+	case StatsMetricTeams, StatsMetricUsers, StatsMetricPullRequests, StatsMetricAssignments, StatsMetricOpenPullRequests:
+		return metric, true
+	default:
+		return "", false
+	}
+}
+
+type StatsBucket string
+
+const (
+	StatsBucketMinute StatsBucket = "minute"
+	StatsBucketHour   StatsBucket = "hour"
+	StatsBucketDay    StatsBucket = "day"
+	StatsBucketWeek   StatsBucket = "week"
+	StatsBucketMonth  StatsBucket = "month"
+)
+
+func (b StatsBucket) String() string {
+	return string(b)
+}
+
+func ParseStatsBucket(value string) (StatsBucket, bool) {
+	switch bucket := StatsBucket(strings.ToLower(value)); bucket {
+	case StatsBucketMinute, StatsBucketHour, StatsBucketDay, StatsBucketWeek, StatsBucketMonth:
+		return bucket, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,26 @@
+package types
+
+import "strings"
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryDead marks a delivery that ran out of attempts; it is kept for
+	// inspection and can be queued again manually.
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+func (s DeliveryStatus) String() string {
+	return string(s)
+}
+
+func ParseDeliveryStatus(value string) (DeliveryStatus, bool) {
+	switch status := DeliveryStatus(strings.ToLower(strings.TrimSpace(value))); status {
+	case DeliveryPending, DeliveryDelivered, DeliveryDead:
+		return status, true
+	default:
+		return "", false
+	}
+}
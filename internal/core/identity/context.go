@@ -0,0 +1,29 @@
+package identity
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+type principalKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal *entities.Principal) context.Context {
+	if ctx == nil || principal == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) *entities.Principal {
+	if ctx == nil {
+		return nil
+	}
+
+	if principal, ok := ctx.Value(principalKey{}).(*entities.Principal); ok {
+		return principal
+	}
+
+	return nil
+}
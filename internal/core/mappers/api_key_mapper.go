@@ -0,0 +1,34 @@
+package mappers
+
+import (
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/dto"
+)
+
+func APIKeyToDTO(key *entities.APIKey) dto.APIKeyDTO {
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, scope.String())
+	}
+
+	return dto.APIKeyDTO{
+		KeyID:      key.ID,
+		Name:       key.Name,
+		Scopes:     scopes,
+		Teams:      key.Teams,
+		CreatedAt:  key.CreatedAt.UTC().Format(time.RFC3339),
+		LastUsedAt: formatOptionalTime(key.LastUsedAt),
+		RevokedAt:  formatOptionalTime(key.RevokedAt),
+	}
+}
+
+func APIKeysToDTO(keys []*entities.APIKey) []dto.APIKeyDTO {
+	result := make([]dto.APIKeyDTO, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, APIKeyToDTO(key))
+	}
+
+	return result
+}
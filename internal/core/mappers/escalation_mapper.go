@@ -0,0 +1,49 @@
+package mappers
+
+import (
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/dto"
+)
+
+func ReviewSLAToDTO(sla *entities.ReviewSLA) dto.ReviewSLADTO {
+	result := dto.ReviewSLADTO{
+		TeamName:    sla.TeamName,
+		RemindAfter: sla.RemindAfter.String(),
+		UpdatedAt:   sla.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+
+	if sla.EscalateAfter > 0 {
+		result.EscalateAfter = sla.EscalateAfter.String()
+	}
+
+	return result
+}
+
+func ReviewSLAsToDTO(slas []*entities.ReviewSLA) []dto.ReviewSLADTO {
+	result := make([]dto.ReviewSLADTO, 0, len(slas))
+	for _, sla := range slas {
+		result = append(result, ReviewSLAToDTO(sla))
+	}
+
+	return result
+}
+
+func ReviewEscalationsToDTO(escalations []*entities.ReviewEscalation) []dto.ReviewEscalationDTO {
+	result := make([]dto.ReviewEscalationDTO, 0, len(escalations))
+	for _, e := range escalations {
+		result = append(result, dto.ReviewEscalationDTO{
+			EscalationID:  e.ID,
+			PullRequestID: e.PullRequestID,
+			TeamName:      e.TeamName,
+			ReviewerID:    e.ReviewerID,
+			AssignedAt:    e.AssignedAt.UTC().Format(time.RFC3339),
+			Action:        e.Action.String(),
+			ReplacedBy:    e.ReplacedBy,
+			CreatedAt:     e.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return result
+}
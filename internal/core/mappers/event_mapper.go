@@ -0,0 +1,21 @@
+package mappers
+
+import (
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/dto"
+)
+
+func EventToDTO(event *entities.Event) dto.EventDTO {
+	return dto.EventDTO{
+		EventID:       event.ID,
+		EventType:     event.Type.String(),
+		OccurredAt:    event.OccurredAt.UTC().Format(time.RFC3339),
+		TeamName:      event.TeamName,
+		PullRequest:   PullRequestToDTO(event.PullRequest()),
+		ReviewerID:    event.Data.ReviewerID,
+		OldReviewerID: event.Data.OldReviewerID,
+		UserID:        event.Data.UserID,
+	}
+}
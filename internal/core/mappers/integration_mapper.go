@@ -0,0 +1,45 @@
+package mappers
+
+import (
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/dto"
+)
+
+func ExternalAccountToDTO(account *entities.ExternalAccount) dto.ExternalAccountDTO {
+	return dto.ExternalAccountDTO{
+		Provider:  account.Provider.String(),
+		Login:     account.Login,
+		UserID:    account.UserID,
+		CreatedAt: account.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func ExternalAccountsToDTO(accounts []*entities.ExternalAccount) []dto.ExternalAccountDTO {
+	result := make([]dto.ExternalAccountDTO, 0, len(accounts))
+	for _, account := range accounts {
+		result = append(result, ExternalAccountToDTO(account))
+	}
+
+	return result
+}
+
+func SyncOutcomeToDTO(outcome *entities.SyncOutcome) dto.SyncResponse {
+	result := dto.SyncResponse{
+		Result: outcome.Result.String(),
+		Reason: outcome.Reason,
+		PR:     PullRequestToDTO(outcome.PullRequest),
+	}
+
+	if outcome.PullRequest != nil {
+		for _, reviewerID := range outcome.PullRequest.AssignedReviewers {
+			result.Reviewers = append(result.Reviewers, dto.ReviewerAccountDTO{
+				UserID: reviewerID,
+				Login:  outcome.ReviewerLogins[reviewerID],
+			})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,38 @@
+package mappers
+
+import (
+	"net/url"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/dto"
+)
+
+func ChatChannelToDTO(channel *entities.ChatChannel) dto.ChatChannelDTO {
+	return dto.ChatChannelDTO{
+		TeamName:   channel.TeamName,
+		WebhookURL: redactURL(channel.WebhookURL),
+		Channel:    channel.Channel,
+		UpdatedAt:  channel.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func ChatChannelsToDTO(channels []*entities.ChatChannel) []dto.ChatChannelDTO {
+	result := make([]dto.ChatChannelDTO, 0, len(channels))
+	for _, channel := range channels {
+		result = append(result, ChatChannelToDTO(channel))
+	}
+
+	return result
+}
+
+// redactURL keeps only the scheme and host: chat webhook URLs carry their
+// secret in the path.
+func redactURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return "***"
+	}
+
+	return parsed.Scheme + "://" + parsed.Host + "/***"
+}
@@ -31,6 +31,7 @@ func PullRequestToDTO(pr *entities.PullRequest) *dto.PullRequestDTO {
 		AssignedReviewers: append([]string(nil), pr.AssignedReviewers...),
 		CreatedAt:         createdAt,
 		MergedAt:          mergedAt,
+		Version:           pr.Version,
 	}
 }
 
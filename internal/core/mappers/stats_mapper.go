@@ -0,0 +1,88 @@
+package mappers
+
+import (
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/dto"
+)
+
+func StatsToDTO(stats *entities.Stats) dto.StatsDTO {
+	if stats == nil {
+		return dto.StatsDTO{}
+	}
+
+	return dto.StatsDTO{
+		Teams:        stats.Teams,
+		Users:        stats.Users,
+		PullRequests: stats.PullRequests,
+		Assignments:  stats.Assignments,
+	}
+}
+
+func StatsHistoryToDTO(history *entities.StatsHistory) dto.StatsHistoryResponse {
+	if history == nil {
+		return dto.StatsHistoryResponse{}
+	}
+
+	query := history.Query
+	result := make([]dto.StatsPointDTO, 0, len(history.Points))
+	for _, point := range history.Points {
+		result = append(result, dto.StatsPointDTO{
+			Bucket: point.Bucket.UTC().Format(time.RFC3339),
+			Last:   point.Last,
+			Min:    point.Min,
+			Max:    point.Max,
+		})
+	}
+
+	return dto.StatsHistoryResponse{
+		Metric:   query.Metric.String(),
+		TeamName: query.TeamName,
+		Bucket:   query.Bucket.String(),
+		From:     query.From.UTC().Format(time.RFC3339),
+		To:       query.To.UTC().Format(time.RFC3339),
+		Points:   result,
+	}
+}
+
+func FairnessToDTO(report *entities.FairnessReport) dto.FairnessDTO {
+	if report == nil {
+		return dto.FairnessDTO{}
+	}
+
+	return dto.FairnessDTO{
+		TeamName:         report.TeamName,
+		From:             formatOptionalTime(report.From),
+		To:               formatOptionalTime(report.To),
+		TotalAssignments: report.TotalAssignments,
+		IdealShare:       report.IdealShare,
+		Gini:             report.Gini,
+		Members:          memberSharesToDTO(report.Members),
+		LargestDeviation: memberSharesToDTO(report.LargestDeviation),
+	}
+}
+
+func memberSharesToDTO(shares []entities.MemberShare) []dto.MemberShareDTO {
+	result := make([]dto.MemberShareDTO, 0, len(shares))
+	for _, share := range shares {
+		result = append(result, dto.MemberShareDTO{
+			UserID:      share.UserID,
+			Username:    share.Username,
+			Assignments: share.Assignments,
+			Share:       share.Share,
+			Deviation:   share.Deviation,
+		})
+	}
+
+	return result
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
@@ -44,3 +44,20 @@ func TeamMembersFromDTO(teamName string, members []dto.TeamMemberDTO) []*entitie
 
 	return result
 }
+
+func TeamRoleToDTO(role *entities.TeamRole) dto.TeamRoleDTO {
+	return dto.TeamRoleDTO{
+		TeamName: role.TeamName,
+		UserID:   role.UserID,
+		Role:     role.Role.String(),
+	}
+}
+
+func TeamRolesToDTO(roles []*entities.TeamRole) []dto.TeamRoleDTO {
+	result := make([]dto.TeamRoleDTO, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, TeamRoleToDTO(role))
+	}
+
+	return result
+}
@@ -11,10 +11,13 @@ func UserToDTO(user *entities.User) *dto.UserDTO {
 	}
 
 	return &dto.UserDTO{
-		UserID:   user.ID,
-		Username: user.Username,
-		TeamName: user.TeamName,
-		IsActive: user.IsActive,
+		UserID:        user.ID,
+		Username:      user.Username,
+		TeamName:      user.TeamName,
+		IsActive:      user.IsActive,
+		Email:         user.Email,
+		Timezone:      user.Timezone,
+		DigestEnabled: user.DigestEnabled,
 	}
 }
 
@@ -0,0 +1,67 @@
+package mappers
+
+import (
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/dto"
+)
+
+func WebhookToDTO(sub *entities.WebhookSubscription) dto.WebhookDTO {
+	events := make([]string, 0, len(sub.EventTypes))
+	for _, eventType := range sub.EventTypes {
+		events = append(events, eventType.String())
+	}
+
+	return dto.WebhookDTO{
+		WebhookID: sub.ID,
+		URL:       sub.URL,
+		Events:    events,
+		TeamName:  sub.TeamName,
+		CreatedAt: sub.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func WebhooksToDTO(subs []*entities.WebhookSubscription) []dto.WebhookDTO {
+	result := make([]dto.WebhookDTO, 0, len(subs))
+	for _, sub := range subs {
+		result = append(result, WebhookToDTO(sub))
+	}
+
+	return result
+}
+
+func WebhookDeliveryToDTO(delivery *entities.WebhookDelivery) dto.WebhookDeliveryDTO {
+	result := dto.WebhookDeliveryDTO{
+		DeliveryID:  delivery.ID,
+		WebhookID:   delivery.SubscriptionID,
+		EventID:     delivery.EventID,
+		EventType:   delivery.EventType.String(),
+		Status:      delivery.Status.String(),
+		Attempts:    delivery.Attempts,
+		LastError:   delivery.LastError,
+		CreatedAt:   delivery.CreatedAt.UTC().Format(time.RFC3339),
+		DeliveredAt: formatOptionalTime(delivery.DeliveredAt),
+	}
+
+	if delivery.Status == types.DeliveryPending {
+		result.NextAttemptAt = formatOptionalTime(&delivery.NextAttemptAt)
+	}
+
+	if delivery.LastStatusCode != 0 {
+		code := delivery.LastStatusCode
+		result.LastStatusCode = &code
+	}
+
+	return result
+}
+
+func WebhookDeliveriesToDTO(deliveries []*entities.WebhookDelivery) []dto.WebhookDeliveryDTO {
+	result := make([]dto.WebhookDeliveryDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, WebhookDeliveryToDTO(delivery))
+	}
+
+	return result
+}
@@ -0,0 +1,18 @@
+package events
+
+import (
+	"context"
+	"errors"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+// ErrCodeHostRejected wraps failures that retrying cannot fix.
+var ErrCodeHostRejected = errors.New("code host rejected the request")
+
+// CodeHostClient calls must be idempotent: a sync may be retried after a
+// partial success.
+type CodeHostClient interface {
+	RequestReviewers(ctx context.Context, ref entities.CodeHostRef, logins []string) error
+	RemoveReviewers(ctx context.Context, ref entities.CodeHostRef, logins []string) error
+}
@@ -0,0 +1,14 @@
+package events
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+// DigestSender delivers a review digest over one channel. It reports false
+// without an error when it cannot reach the user, e.g. the user has no email
+// address or the team has no chat channel.
+type DigestSender interface {
+	SendDigest(ctx context.Context, digest *entities.ReviewDigest) (bool, error)
+}
@@ -0,0 +1,20 @@
+package events
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+// Publisher delivers outbox events to the outside world. Delivery is
+// at-least-once: an event may be published again if marking it as sent
+// fails, so consumers should deduplicate by event ID.
+type Publisher interface {
+	Publish(ctx context.Context, event *entities.Event) error
+}
+
+type NoopPublisher struct{}
+
+func (NoopPublisher) Publish(context.Context, *entities.Event) error {
+	return nil
+}
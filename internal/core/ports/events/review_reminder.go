@@ -0,0 +1,14 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+// ReviewReminder nudges the reviewer of an overdue review. It is called inside
+// the transaction that records the reminder, so it should only queue work.
+type ReviewReminder interface {
+	Remind(ctx context.Context, review *entities.OverdueReview, at time.Time) error
+}
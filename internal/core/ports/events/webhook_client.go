@@ -0,0 +1,16 @@
+package events
+
+import "context"
+
+type WebhookRequest struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// WebhookClient performs a single delivery attempt. A transport failure is
+// returned as an error; any HTTP response is reported through its status
+// code and judged by the caller.
+type WebhookClient interface {
+	Send(ctx context.Context, req WebhookRequest) (int, error)
+}
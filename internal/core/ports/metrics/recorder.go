@@ -0,0 +1,24 @@
+package metrics
+
+type Recorder interface {
+	ReviewersAssigned(count int)
+	NoCandidate(operation string)
+	ReviewerReassigned()
+	TransactionCommitted()
+	TransactionRolledBack()
+	TransactionRetried()
+}
+
+type NoopRecorder struct{}
+
+func (NoopRecorder) ReviewersAssigned(int) {}
+
+func (NoopRecorder) NoCandidate(string) {}
+
+func (NoopRecorder) ReviewerReassigned() {}
+
+func (NoopRecorder) TransactionCommitted() {}
+
+func (NoopRecorder) TransactionRolledBack() {}
+
+func (NoopRecorder) TransactionRetried() {}
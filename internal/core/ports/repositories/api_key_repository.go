@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	GetByID(ctx context.Context, keyID string) (*entities.APIKey, error)
+	List(ctx context.Context) ([]*entities.APIKey, error)
+	Revoke(ctx context.Context, keyID string, at time.Time) (*entities.APIKey, error)
+	TouchLastUsed(ctx context.Context, keyID string, at time.Time) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+type CodeHostSyncRepository interface {
+	Enqueue(ctx context.Context, prID string, removedReviewers []string, at time.Time) error
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.CodeHostSyncJob, error)
+	// Update reports false when the job was re-armed since it was claimed.
+	Update(ctx context.Context, job *entities.CodeHostSyncJob) (bool, error)
+	Get(ctx context.Context, prID string) (*entities.CodeHostSyncJob, error)
+}
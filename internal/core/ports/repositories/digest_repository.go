@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+type DigestRepository interface {
+	ListRecipients(ctx context.Context) ([]*entities.DigestRecipient, error)
+	ListPendingReviews(ctx context.Context, userID string) ([]*entities.PendingReview, error)
+	// Claim reports false when another instance already sent the day's digest.
+	Claim(ctx context.Context, userID string, day time.Time, reviews int, at time.Time) (bool, error)
+	Release(ctx context.Context, userID string, day time.Time) error
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+type EscalationFilter struct {
+	TeamName      string
+	PullRequestID string
+	Limit         int
+}
+
+type EscalationRepository interface {
+	UpsertSLA(ctx context.Context, sla *entities.ReviewSLA) error
+	ListSLAs(ctx context.Context) ([]*entities.ReviewSLA, error)
+	DeleteSLA(ctx context.Context, teamName string) error
+
+	// ListOverdue judges assignments by the SLA of the author's team.
+	ListOverdue(ctx context.Context, now time.Time, limit int) ([]*entities.OverdueReview, error)
+	// Record reports false when the action was already taken on the assignment.
+	Record(ctx context.Context, escalation *entities.ReviewEscalation) (bool, error)
+	ListEscalations(ctx context.Context, filter EscalationFilter) ([]*entities.ReviewEscalation, error)
+}
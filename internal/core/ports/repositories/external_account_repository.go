@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+type ExternalAccountRepository interface {
+	Link(ctx context.Context, account *entities.ExternalAccount) error
+	Unlink(ctx context.Context, provider types.Provider, login string) error
+	ResolveUser(ctx context.Context, provider types.Provider, login string) (string, error)
+	// LoginsByUsers maps each linked user ID to its login on provider; users
+	// without a link are left out.
+	LoginsByUsers(ctx context.Context, provider types.Provider, userIDs []string) (map[string]string, error)
+	// List returns links for provider, or for every provider when it is empty.
+	List(ctx context.Context, provider types.Provider) ([]*entities.ExternalAccount, error)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+type IdempotencyRepository interface {
+	// Reserve stores a pending record unless a live one exists. Expired
+	// records and pending ones created before staleBefore are replaced.
+	Reserve(ctx context.Context, record *entities.IdempotencyRecord, staleBefore time.Time) (bool, error)
+	Get(ctx context.Context, owner, key string) (*entities.IdempotencyRecord, error)
+	Complete(ctx context.Context, record *entities.IdempotencyRecord) error
+	Release(ctx context.Context, owner, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+type NotificationRepository interface {
+	// UpsertChannel returns NotFound when the team does not exist.
+	UpsertChannel(ctx context.Context, channel *entities.ChatChannel) error
+	GetChannel(ctx context.Context, teamName string) (*entities.ChatChannel, error)
+	ListChannels(ctx context.Context) ([]*entities.ChatChannel, error)
+	// DeleteChannel also drops the team's unsent notifications.
+	DeleteChannel(ctx context.Context, teamName string) error
+
+	// Enqueue skips events that were already queued. A notification joins the
+	// team's open batch — unsent notifications not yet due at its creation —
+	// by taking that batch's send time instead of its own.
+	Enqueue(ctx context.Context, notification *entities.ChatNotification) error
+	// ClaimDue leases up to limit pending notifications due at now, the same
+	// way WebhookRepository.ClaimDue does.
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.ChatNotification, error)
+	Update(ctx context.Context, notification *entities.ChatNotification) error
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+type PublishedEventFilter struct {
+	After    int64
+	Types    []types.EventType
+	TeamName string
+	UserID   string
+}
+
+type OutboxRepository interface {
+	// Append stores events in the caller's transaction and sets their IDs.
+	Append(ctx context.Context, events ...*entities.Event) error
+	// LockRelay takes a transaction-scoped lock so only one relay delivers at
+	// a time; it returns false when another instance holds it.
+	LockRelay(ctx context.Context) (bool, error)
+	ListPending(ctx context.Context, limit int) ([]*entities.Event, error)
+	// MarkPublished also hands the events stream positions in ID order.
+	MarkPublished(ctx context.Context, ids []int64, at time.Time) error
+	ListPublished(ctx context.Context, filter PublishedEventFilter, limit int) ([]*entities.Event, error)
+	LastPosition(ctx context.Context) (int64, error)
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
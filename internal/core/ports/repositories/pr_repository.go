@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"pr-reviewer-assignment/internal/core/domain/entities"
 )
@@ -11,6 +12,11 @@ type PullRequestRepository interface {
 	Update(ctx context.Context, pr *entities.PullRequest) error
 	GetByID(ctx context.Context, prID string) (*entities.PullRequest, error)
 	ListByReviewer(ctx context.Context, reviewerID string) ([]*entities.PullRequest, error)
+	ListByIDs(ctx context.Context, prIDs []string) ([]*entities.PullRequest, error)
+	ListByReviewers(ctx context.Context, reviewerIDs []string) (map[string][]*entities.PullRequest, error)
 	Count(ctx context.Context) (int, error)
 	CountAssignments(ctx context.Context) (int, error)
+	CountOpenByTeam(ctx context.Context) (map[string]int, error)
+	CountAssignmentsByReviewer(ctx context.Context, teamName string, from, to *time.Time) (map[string]int, error)
+	CountOpenAssignmentsByReviewer(ctx context.Context, teamName string) (map[string]int, error)
 }
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+type StatsRepository interface {
+	SaveSnapshot(ctx context.Context, snapshot *entities.StatsSnapshot) error
+	History(ctx context.Context, query entities.StatsHistoryQuery) ([]entities.StatsPoint, error)
+}
@@ -10,5 +10,11 @@ type TeamRepository interface {
 	Create(ctx context.Context, team *entities.Team) error
 	Update(ctx context.Context, team *entities.Team) error
 	Get(ctx context.Context, teamName string) (*entities.Team, error)
+	// GetForUpdate loads the team like Get but first locks its row until the
+	// surrounding transaction ends, so callers selecting reviewers from the
+	// team run one at a time.
+	GetForUpdate(ctx context.Context, teamName string) (*entities.Team, error)
+	ListNames(ctx context.Context) ([]string, error)
+	ListByNames(ctx context.Context, teamNames []string) ([]*entities.Team, error)
 	Count(ctx context.Context) (int, error)
 }
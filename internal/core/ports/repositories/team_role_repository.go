@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+type TeamRoleRepository interface {
+	Set(ctx context.Context, role *entities.TeamRole) error
+	Remove(ctx context.Context, teamName, userID string) error
+	ListByTeam(ctx context.Context, teamName string) ([]*entities.TeamRole, error)
+	HasRole(ctx context.Context, teamName, userID string, role types.Role) (bool, error)
+}
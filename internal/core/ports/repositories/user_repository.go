@@ -9,7 +9,9 @@ import (
 type UserRepository interface {
 	UpsertMany(ctx context.Context, users []*entities.User) error
 	GetByID(ctx context.Context, userID string) (*entities.User, error)
+	ListByIDs(ctx context.Context, userIDs []string) ([]*entities.User, error)
 	ListByTeam(ctx context.Context, teamName string) ([]*entities.User, error)
 	SetActivity(ctx context.Context, userID string, isActive bool) (*entities.User, error)
+	UpdatePreferences(ctx context.Context, user *entities.User) (*entities.User, error)
 	Count(ctx context.Context) (int, error)
 }
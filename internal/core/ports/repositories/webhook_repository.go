@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+type WebhookDeliveryFilter struct {
+	SubscriptionID string
+	Status         types.DeliveryStatus
+	Limit          int
+}
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *entities.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id string) (*entities.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+
+	// CreateDeliveries skips deliveries that already exist for the same
+	// subscription and event, so re-published events are not sent twice.
+	CreateDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error
+	// ClaimDue leases up to limit pending deliveries due at now by pushing
+	// their next attempt to leaseUntil, so other instances skip them while
+	// they are being sent.
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id int64) (*entities.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]*entities.WebhookDelivery, error)
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+type APIKeyService interface {
+	CreateKey(ctx context.Context, name string, scopes []types.Scope, teams []string) (*entities.APIKey, string, error)
+	ListKeys(ctx context.Context) ([]*entities.APIKey, error)
+	RevokeKey(ctx context.Context, keyID string) (*entities.APIKey, error)
+	Authenticate(ctx context.Context, token string) (*entities.Principal, error)
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+// DashboardService: keys that match nothing are absent from the returned maps.
+type DashboardService interface {
+	ListTeamNames(ctx context.Context) ([]string, error)
+	TeamsByName(ctx context.Context, teamNames []string) (map[string]*entities.Team, error)
+	UsersByID(ctx context.Context, userIDs []string) (map[string]*entities.User, error)
+	PullRequestsByID(ctx context.Context, prIDs []string) (map[string]*entities.PullRequest, error)
+	ReviewsByUser(ctx context.Context, userIDs []string) (map[string][]*entities.PullRequest, error)
+}
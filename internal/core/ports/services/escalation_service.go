@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+)
+
+type EscalationService interface {
+	SetSLA(ctx context.Context, teamName string, remindAfter, escalateAfter time.Duration) (*entities.ReviewSLA, error)
+	ListSLAs(ctx context.Context) ([]*entities.ReviewSLA, error)
+	RemoveSLA(ctx context.Context, teamName string) error
+	ListEscalations(ctx context.Context, filter repo.EscalationFilter) ([]*entities.ReviewEscalation, error)
+}
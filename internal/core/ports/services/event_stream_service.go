@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+type EventStreamFilter struct {
+	TeamName string
+	UserID   string
+}
+
+// EventSubscription: when the subscriber falls too far behind or the service
+// shuts down the channel is closed and the client resumes from its last
+// position.
+type EventSubscription interface {
+	Events() <-chan *entities.Event
+	Close()
+}
+
+type EventStreamService interface {
+	Subscribe(ctx context.Context, filter EventStreamFilter) (EventSubscription, error)
+	Replay(ctx context.Context, filter EventStreamFilter, after int64, limit int) ([]*entities.Event, error)
+}
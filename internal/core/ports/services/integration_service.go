@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+)
+
+type IntegrationService interface {
+	LinkAccount(ctx context.Context, provider types.Provider, login, userID string) (*entities.ExternalAccount, error)
+	UnlinkAccount(ctx context.Context, provider types.Provider, login string) error
+	ListAccounts(ctx context.Context, provider types.Provider) ([]*entities.ExternalAccount, error)
+	HandlePullRequestEvent(ctx context.Context, event entities.CodeHostPullRequestEvent) (*entities.SyncOutcome, error)
+}
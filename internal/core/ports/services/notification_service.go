@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+)
+
+type NotificationService interface {
+	// SetChannel creates or replaces the team's chat channel.
+	SetChannel(ctx context.Context, teamName, webhookURL, channel string) (*entities.ChatChannel, error)
+	ListChannels(ctx context.Context) ([]*entities.ChatChannel, error)
+	RemoveChannel(ctx context.Context, teamName string) error
+}
@@ -6,8 +6,13 @@ import (
 	"pr-reviewer-assignment/internal/core/domain/entities"
 )
 
+// PullRequestService updates take the version the caller last saw; a mismatch
+// fails with CONFLICT. An expectedVersion of 0 skips the precondition and lets
+// the service replay the update if it races with another writer.
 type PullRequestService interface {
 	CreatePullRequest(ctx context.Context, pr *entities.PullRequest) (*entities.PullRequest, error)
-	MergePullRequest(ctx context.Context, prID string) (*entities.PullRequest, error)
-	ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*entities.PullRequest, string, error)
+	MergePullRequest(ctx context.Context, prID string, expectedVersion int) (*entities.PullRequest, error)
+	ClosePullRequest(ctx context.Context, prID string, expectedVersion int) (*entities.PullRequest, error)
+	ReopenPullRequest(ctx context.Context, prID string, expectedVersion int) (*entities.PullRequest, error)
+	ReassignReviewer(ctx context.Context, prID, oldReviewerID string, expectedVersion int) (*entities.PullRequest, string, error)
 }
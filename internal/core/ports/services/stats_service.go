@@ -2,10 +2,14 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"pr-reviewer-assignment/internal/core/domain/entities"
 )
 
 type StatsService interface {
 	GetStats(ctx context.Context) (*entities.Stats, error)
+	TakeSnapshot(ctx context.Context) error
+	GetHistory(ctx context.Context, query entities.StatsHistoryQuery) (*entities.StatsHistory, error)
+	GetFairness(ctx context.Context, teamName string, from, to *time.Time) (*entities.FairnessReport, error)
 }
@@ -4,9 +4,13 @@ import (
 	"context"
 
 	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
 )
 
 type TeamService interface {
 	CreateTeam(ctx context.Context, name string, members []*entities.User) (*entities.Team, error)
 	GetTeam(ctx context.Context, name string) (*entities.Team, error)
+	SetMemberRole(ctx context.Context, teamName, userID string, role types.Role) (*entities.TeamRole, error)
+	RemoveMemberRole(ctx context.Context, teamName, userID string) error
+	ListMemberRoles(ctx context.Context, teamName string) ([]*entities.TeamRole, error)
 }
@@ -8,5 +8,6 @@ import (
 
 type UserService interface {
 	SetActivity(ctx context.Context, userID string, isActive bool) (*entities.User, error)
+	SetPreferences(ctx context.Context, userID string, prefs entities.UserPreferences) (*entities.User, error)
 	GetReviewerAssignments(ctx context.Context, userID string) ([]*entities.PullRequest, error)
 }
@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+)
+
+type WebhookService interface {
+	// CreateSubscription generates a signing secret when secret is empty.
+	CreateSubscription(ctx context.Context, url, secret string, eventTypes []types.EventType, teamName string) (*entities.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+	ListDeliveries(ctx context.Context, filter repo.WebhookDeliveryFilter) ([]*entities.WebhookDelivery, error)
+	Redeliver(ctx context.Context, deliveryID int64) (*entities.WebhookDelivery, error)
+}
@@ -2,16 +2,90 @@ package transactions
 
 import "context"
 
+type IsolationLevel string
+
+const (
+	IsolationDefault        IsolationLevel = ""
+	IsolationReadCommitted  IsolationLevel = "read committed"
+	IsolationRepeatableRead IsolationLevel = "repeatable read"
+	IsolationSerializable   IsolationLevel = "serializable"
+)
+
+type Options struct {
+	Isolation IsolationLevel
+	ReadOnly  bool
+}
+
+type Option func(*Options)
+
+func WithIsolation(level IsolationLevel) Option {
+	return func(o *Options) {
+		o.Isolation = level
+	}
+}
+
+func ReadOnly() Option {
+	return func(o *Options) {
+		o.ReadOnly = true
+	}
+}
+
+func ApplyOptions(opts ...Option) Options {
+	var options Options
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&options)
+		}
+	}
+
+	return options
+}
+
+// Manager runs fn inside a transaction. Implementations may call fn more than
+// once when the database aborts it with a retryable error (serialization
+// failure, deadlock), so fn must not have side effects outside the
+// transaction; defer them with AfterCommit. A call made inside another
+// transaction joins it, and its options are ignored.
 type Manager interface {
-	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error
 }
 
 type NoopManager struct{}
 
-func (NoopManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+func (NoopManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, _ ...Option) error {
 	if fn == nil {
 		return nil
 	}
 
 	return fn(ctx)
 }
+
+type commitHooksKey struct{}
+
+type commitHooks struct {
+	fns []func()
+}
+
+// WithCommitHooks returns a context that collects AfterCommit callbacks and a
+// function for managers to call once the transaction or savepoint commits. It
+// hands the callbacks to the enclosing transaction, or runs them when there is
+// none, so those of a rolled back attempt are simply dropped.
+func WithCommitHooks(ctx context.Context) (context.Context, func()) {
+	hooks := &commitHooks{}
+	return context.WithValue(ctx, commitHooksKey{}, hooks), func() {
+		for _, fn := range hooks.fns {
+			AfterCommit(ctx, fn)
+		}
+	}
+}
+
+// AfterCommit runs fn once the outermost transaction carried by ctx commits,
+// or right away when ctx carries none.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(commitHooksKey{}).(*commitHooks); ok {
+		hooks.fns = append(hooks.fns, fn)
+		return
+	}
+
+	fn()
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	"pr-reviewer-assignment/internal/logger"
+	"pr-reviewer-assignment/internal/validation"
+
+	"go.uber.org/zap"
+)
+
+// API key tokens look like "prk_<key id>_<secret>". Only a SHA-256 of the
+// secret is stored; the secret carries 256 bits of entropy, so a slow KDF buys
+// nothing here.
+const (
+	apiKeyTokenPrefix = "prk_"
+	apiKeyIDBytes     = 8
+	apiKeySecretBytes = 32
+)
+
+type APIKeyService struct {
+	keyRepo      repo.APIKeyRepository
+	logger       *zap.Logger
+	bootstrapKey string
+}
+
+func NewAPIKeyService(keyRepo repo.APIKeyRepository, logger *zap.Logger, bootstrapKey string) *APIKeyService {
+	return &APIKeyService{
+		keyRepo:      keyRepo,
+		logger:       logger,
+		bootstrapKey: bootstrapKey,
+	}
+}
+
+func (s *APIKeyService) CreateKey(ctx context.Context, name string, scopes []types.Scope, teams []string) (*entities.APIKey, string, error) {
+	ctx, span := startSpan(ctx, "APIKeyService.CreateKey")
+	defer span.End()
+
+	validatedName, err := validation.RequireString("name", name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(scopes) == 0 {
+		return nil, "", validation.FieldError{Field: "scopes", Reason: validation.ErrRequired}
+	}
+
+	boundTeams := make([]string, 0, len(teams))
+	for _, team := range teams {
+		validatedTeam, err := validation.RequireString("teams", team)
+		if err != nil {
+			return nil, "", err
+		}
+		if !slices.Contains(boundTeams, validatedTeam) {
+			boundTeams = append(boundTeams, validatedTeam)
+		}
+	}
+
+	keyID, err := randomHex(apiKeyIDBytes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret, err := randomToken(apiKeySecretBytes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := &entities.APIKey{
+		ID:        keyID,
+		Name:      validatedName,
+		Hash:      hashSecret(secret),
+		Scopes:    dedupeScopes(scopes),
+		Teams:     boundTeams,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := s.keyRepo.Create(ctx, key); err != nil {
+		s.log(ctx).Error("Failed to create api key", zap.String("name", validatedName), zap.Error(err))
+		return nil, "", err
+	}
+
+	s.log(ctx).Info("Api key created", zap.String("key_id", key.ID), zap.String("name", key.Name))
+	return key, apiKeyTokenPrefix + keyID + "_" + secret, nil
+}
+
+func (s *APIKeyService) ListKeys(ctx context.Context) ([]*entities.APIKey, error) {
+	ctx, span := startSpan(ctx, "APIKeyService.ListKeys")
+	defer span.End()
+
+	return s.keyRepo.List(ctx)
+}
+
+func (s *APIKeyService) RevokeKey(ctx context.Context, keyID string) (*entities.APIKey, error) {
+	ctx, span := startSpan(ctx, "APIKeyService.RevokeKey")
+	defer span.End()
+
+	validatedID, err := validation.RequireString("key_id", keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.keyRepo.Revoke(ctx, validatedID, time.Now().UTC())
+	if err != nil {
+		s.log(ctx).Error("Failed to revoke api key", zap.String("key_id", validatedID), zap.Error(err))
+		return nil, err
+	}
+
+	s.log(ctx).Info("Api key revoked", zap.String("key_id", key.ID))
+	return key, nil
+}
+
+func (s *APIKeyService) Authenticate(ctx context.Context, token string) (*entities.Principal, error) {
+	ctx, span := startSpan(ctx, "APIKeyService.Authenticate")
+	defer span.End()
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, domainErrors.Unauthorized("missing credentials")
+	}
+
+	if s.bootstrapKey != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.bootstrapKey)) == 1 {
+		return &entities.Principal{
+			Subject: "bootstrap",
+			Kind:    entities.PrincipalBootstrap,
+			Scopes:  types.AllScopes(),
+			Roles:   []types.Role{types.RoleAdmin},
+		}, nil
+	}
+
+	keyID, secret, ok := parseAPIKeyToken(token)
+	if !ok {
+		return nil, domainErrors.Unauthorized("malformed api key")
+	}
+
+	key, err := s.keyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		if isDomainError(err, domainErrors.ErrorCodeNotFound) {
+			return nil, domainErrors.Unauthorized("invalid api key")
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(key.Hash)) != 1 {
+		return nil, domainErrors.Unauthorized("invalid api key")
+	}
+
+	if key.IsRevoked() {
+		return nil, domainErrors.Unauthorized("api key revoked")
+	}
+
+	if err := s.keyRepo.TouchLastUsed(ctx, key.ID, time.Now().UTC()); err != nil {
+		s.log(ctx).Warn("Failed to record api key usage", zap.String("key_id", key.ID), zap.Error(err))
+	}
+
+	return &entities.Principal{
+		Subject:  "api_key:" + key.ID,
+		Kind:     entities.PrincipalAPIKey,
+		Scopes:   key.Scopes,
+		LedTeams: key.Teams,
+	}, nil
+}
+
+func (s *APIKeyService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger)
+}
+
+func parseAPIKeyToken(token string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(token, apiKeyTokenPrefix)
+	if !ok {
+		return "", "", false
+	}
+
+	keyID, secret, ok := strings.Cut(rest, "_")
+	if !ok || keyID == "" || secret == "" {
+		return "", "", false
+	}
+
+	return keyID, secret, true
+}
+
+func dedupeScopes(scopes []types.Scope) []types.Scope {
+	seen := make(map[types.Scope]struct{}, len(scopes))
+	result := make([]types.Scope, 0, len(scopes))
+
+	for _, scope := range scopes {
+		if _, exists := seen[scope]; exists {
+			continue
+		}
+		seen[scope] = struct{}{}
+		result = append(result, scope)
+	}
+
+	return result
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate random bytes: %w", err)
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// randomToken uses base64url without '_' so the token can be split on it.
+func randomToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate random bytes: %w", err)
+	}
+
+	return strings.ReplaceAll(base64.RawURLEncoding.EncodeToString(buf), "_", "-"), nil
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/core/identity"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+)
+
+// Authorizer applies team-scoped rules to the caller found in the context.
+// Calls without a principal (auth disabled, background jobs) are allowed, as
+// are all calls through a nil Authorizer.
+type Authorizer struct {
+	roleRepo repo.TeamRoleRepository
+}
+
+func NewAuthorizer(roleRepo repo.TeamRoleRepository) *Authorizer {
+	return &Authorizer{roleRepo: roleRepo}
+}
+
+func (a *Authorizer) principal(ctx context.Context) *entities.Principal {
+	if a == nil {
+		return nil
+	}
+
+	return identity.PrincipalFromContext(ctx)
+}
+
+func (a *Authorizer) isLead(ctx context.Context, principal *entities.Principal, teamName string) (bool, error) {
+	if slices.Contains(principal.LedTeams, teamName) {
+		return true, nil
+	}
+
+	if principal.UserID == "" || a.roleRepo == nil {
+		return false, nil
+	}
+
+	return a.roleRepo.HasRole(ctx, teamName, principal.UserID, types.RoleTeamLead)
+}
+
+// requireTeamManager allows admins and leads of the team to change its
+// membership and its members' activity.
+func (a *Authorizer) requireTeamManager(ctx context.Context, teamName string) error {
+	allowed, err := a.canManageTeam(ctx, teamName)
+	if err != nil {
+		return err
+	}
+
+	if allowed {
+		return nil
+	}
+
+	return domainErrors.Forbidden(fmt.Sprintf("only a lead of team %s or an admin can manage it", teamName))
+}
+
+func (a *Authorizer) canManageTeam(ctx context.Context, teamName string) (bool, error) {
+	principal := a.principal(ctx)
+	if principal == nil || principal.CanManageTeam(teamName) {
+		return true, nil
+	}
+
+	return a.isLead(ctx, principal, teamName)
+}
+
+func (a *Authorizer) requireSelfOrTeamManager(ctx context.Context, user *entities.User) error {
+	principal := a.principal(ctx)
+	if principal == nil || (principal.UserID != "" && principal.UserID == user.ID) {
+		return nil
+	}
+
+	return a.requireTeamManager(ctx, user.TeamName)
+}
+
+// requirePullRequestParticipant allows the author, assigned reviewers and
+// leads of the author's team to reassign or merge a pull request. API keys
+// count as leads of the teams they are bound to.
+func (a *Authorizer) requirePullRequestParticipant(ctx context.Context, pr *entities.PullRequest, authorTeam string) error {
+	principal := a.principal(ctx)
+	if principal == nil || principal.HasRole(types.RoleAdmin) {
+		return nil
+	}
+
+	if principal.UserID != "" && (principal.UserID == pr.AuthorID || pr.HasReviewer(principal.UserID)) {
+		return nil
+	}
+
+	lead, err := a.isLead(ctx, principal, authorTeam)
+	if err != nil {
+		return err
+	}
+
+	if lead {
+		return nil
+	}
+
+	return domainErrors.Forbidden(fmt.Sprintf("only the author, an assigned reviewer or a team lead can change pull request %s", pr.ID))
+}
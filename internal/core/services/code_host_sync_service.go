@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	"pr-reviewer-assignment/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+// CodeHostSyncService: assignment events only arm a job, and the code host is
+// called later by SyncDue, so its outages delay the sync instead of failing
+// the assignment.
+type CodeHostSyncService struct {
+	jobs     repo.CodeHostSyncRepository
+	prRepo   repo.PullRequestRepository
+	accounts repo.ExternalAccountRepository
+	clients  map[types.Provider]eventports.CodeHostClient
+	logger   *zap.Logger
+	policy   DeliveryPolicy
+}
+
+func NewCodeHostSyncService(
+	jobs repo.CodeHostSyncRepository,
+	prRepo repo.PullRequestRepository,
+	accounts repo.ExternalAccountRepository,
+	clients map[types.Provider]eventports.CodeHostClient,
+	logger *zap.Logger,
+	policy DeliveryPolicy,
+) *CodeHostSyncService {
+	return &CodeHostSyncService{
+		jobs:     jobs,
+		prRepo:   prRepo,
+		accounts: accounts,
+		clients:  clients,
+		logger:   logger,
+		policy:   policy.withDefaults(),
+	}
+}
+
+func (s *CodeHostSyncService) Publish(ctx context.Context, event *entities.Event) error {
+	var removed []string
+
+	switch event.Type {
+	case types.EventPullRequestCreated:
+	case types.EventReviewerReplaced:
+		removed = []string{event.Data.OldReviewerID}
+	default:
+		return nil
+	}
+
+	ref, ok := entities.ParseCodeHostRef(event.Data.PullRequestID)
+	if !ok || s.clients[ref.Provider] == nil {
+		return nil
+	}
+
+	return s.jobs.Enqueue(ctx, event.Data.PullRequestID, removed, time.Now().UTC())
+}
+
+func (s *CodeHostSyncService) SyncDue(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "CodeHostSyncService.SyncDue")
+	defer span.End()
+
+	now := time.Now().UTC()
+	jobs, err := s.jobs.ClaimDue(ctx, now, now.Add(s.policy.Lease), s.policy.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	synced := 0
+	for _, job := range jobs {
+		log := s.log(ctx).With(zap.String("pr_id", job.PullRequestID), zap.Int64("revision", job.Revision))
+
+		err := s.sync(ctx, job)
+		now := time.Now().UTC()
+
+		if err == nil {
+			job.MarkSynced(now)
+			synced++
+		} else {
+			permanent := errors.Is(err, eventports.ErrCodeHostRejected)
+			job.MarkFailed(truncate(err.Error(), maxDeliveryErrorLength), permanent, s.policy.MaxAttempts, now.Add(s.policy.backoff(job.Attempts+1)), now)
+
+			if job.Status == types.DeliveryDead {
+				log.Warn("Code host sync abandoned", zap.Int("attempts", job.Attempts), zap.Error(err))
+			} else {
+				log.Info("Code host sync failed, will retry",
+					zap.Int("attempts", job.Attempts),
+					zap.Time("next_attempt_at", job.NextAttemptAt),
+					zap.Error(err))
+			}
+		}
+
+		stored, err := s.jobs.Update(ctx, job)
+		if err != nil {
+			return synced, err
+		}
+		if !stored {
+			log.Debug("Code host sync re-armed while running")
+		}
+	}
+
+	return synced, nil
+}
+
+func (s *CodeHostSyncService) sync(ctx context.Context, job *entities.CodeHostSyncJob) error {
+	ref, ok := entities.ParseCodeHostRef(job.PullRequestID)
+	if !ok {
+		return fmt.Errorf("%w: pull request %s has no code host reference", eventports.ErrCodeHostRejected, job.PullRequestID)
+	}
+
+	client := s.clients[ref.Provider]
+	if client == nil {
+		return fmt.Errorf("%w: no client configured for %s", eventports.ErrCodeHostRejected, ref.Provider)
+	}
+
+	pr, err := s.prRepo.GetByID(ctx, job.PullRequestID)
+	if err != nil {
+		if isDomainError(err, domainErrors.ErrorCodeNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if pr.Status != types.PRStatusOpen {
+		return nil
+	}
+
+	reviewerLogins, err := s.accounts.LoginsByUsers(ctx, ref.Provider, pr.AssignedReviewers)
+	if err != nil {
+		return err
+	}
+
+	requested := make([]string, 0, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		if login, ok := reviewerLogins[reviewerID]; ok {
+			requested = append(requested, login)
+		}
+	}
+
+	withdrawnIDs := make([]string, 0, len(job.RemovedReviewers))
+	for _, userID := range job.RemovedReviewers {
+		if !pr.HasReviewer(userID) {
+			withdrawnIDs = append(withdrawnIDs, userID)
+		}
+	}
+
+	withdrawnLogins, err := s.accounts.LoginsByUsers(ctx, ref.Provider, withdrawnIDs)
+	if err != nil {
+		return err
+	}
+
+	withdrawn := make([]string, 0, len(withdrawnLogins))
+	for _, login := range withdrawnLogins {
+		if !slices.Contains(requested, login) {
+			withdrawn = append(withdrawn, login)
+		}
+	}
+	slices.Sort(withdrawn)
+
+	if len(requested) > 0 {
+		if err := client.RequestReviewers(ctx, ref, requested); err != nil {
+			return err
+		}
+	}
+
+	if len(withdrawn) > 0 {
+		if err := client.RemoveReviewers(ctx, ref, withdrawn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *CodeHostSyncService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger)
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+)
+
+type DashboardService struct {
+	teamRepo repo.TeamRepository
+	userRepo repo.UserRepository
+	prRepo   repo.PullRequestRepository
+}
+
+func NewDashboardService(teamRepo repo.TeamRepository, userRepo repo.UserRepository, prRepo repo.PullRequestRepository) *DashboardService {
+	return &DashboardService{
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+		prRepo:   prRepo,
+	}
+}
+
+func (s *DashboardService) ListTeamNames(ctx context.Context) ([]string, error) {
+	ctx, span := startSpan(ctx, "DashboardService.ListTeamNames")
+	defer span.End()
+
+	return s.teamRepo.ListNames(ctx)
+}
+
+func (s *DashboardService) TeamsByName(ctx context.Context, teamNames []string) (map[string]*entities.Team, error) {
+	ctx, span := startSpan(ctx, "DashboardService.TeamsByName")
+	defer span.End()
+
+	teams, err := s.teamRepo.ListByNames(ctx, teamNames)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*entities.Team, len(teams))
+	for _, team := range teams {
+		result[team.Name] = team
+	}
+
+	return result, nil
+}
+
+func (s *DashboardService) UsersByID(ctx context.Context, userIDs []string) (map[string]*entities.User, error) {
+	ctx, span := startSpan(ctx, "DashboardService.UsersByID")
+	defer span.End()
+
+	users, err := s.userRepo.ListByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*entities.User, len(users))
+	for _, user := range users {
+		result[user.ID] = user
+	}
+
+	return result, nil
+}
+
+func (s *DashboardService) PullRequestsByID(ctx context.Context, prIDs []string) (map[string]*entities.PullRequest, error) {
+	ctx, span := startSpan(ctx, "DashboardService.PullRequestsByID")
+	defer span.End()
+
+	prs, err := s.prRepo.ListByIDs(ctx, prIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*entities.PullRequest, len(prs))
+	for _, pr := range prs {
+		result[pr.ID] = pr
+	}
+
+	return result, nil
+}
+
+func (s *DashboardService) ReviewsByUser(ctx context.Context, userIDs []string) (map[string][]*entities.PullRequest, error) {
+	ctx, span := startSpan(ctx, "DashboardService.ReviewsByUser")
+	defer span.End()
+
+	return s.prRepo.ListByReviewers(ctx, userIDs)
+}
@@ -0,0 +1,39 @@
+package services
+
+import "time"
+
+// DeliveryPolicy governs background deliveries to external systems: a failed
+// attempt is retried after BaseBackoff doubled per attempt (capped at
+// MaxBackoff) until MaxAttempts, then dead-lettered. Lease is how long a
+// claimed item is hidden from other workers.
+type DeliveryPolicy struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Lease       time.Duration
+	BatchSize   int
+}
+
+func (p DeliveryPolicy) withDefaults() DeliveryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 1
+	}
+	if p.BatchSize <= 0 {
+		p.BatchSize = defaultOutboxBatchSize
+	}
+	if p.Lease <= 0 {
+		p.Lease = time.Minute
+	}
+
+	return p
+}
+
+// backoff returns the wait before the next attempt after `attempts` failures.
+func (p DeliveryPolicy) backoff(attempts int) time.Duration {
+	delay := p.BaseBackoff << min(max(attempts-1, 0), 20)
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	return delay
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"pr-reviewer-assignment/internal/clock"
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	"pr-reviewer-assignment/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+const defaultDigestHour = 9
+
+// DigestSettings: the digest goes out on the first run after Hour o'clock in
+// the user's timezone.
+type DigestSettings struct {
+	Hour           int
+	PullRequestURL string
+	CodeHostURLs   map[types.Provider]string
+	Clock          clock.Clock
+}
+
+type DigestService struct {
+	repo     repo.DigestRepository
+	senders  []eventports.DigestSender
+	logger   *zap.Logger
+	settings DigestSettings
+}
+
+func NewDigestService(
+	digestRepo repo.DigestRepository,
+	senders []eventports.DigestSender,
+	logger *zap.Logger,
+	settings DigestSettings,
+) *DigestService {
+	if settings.Hour < 0 || settings.Hour > 23 {
+		settings.Hour = defaultDigestHour
+	}
+	if settings.Clock == nil {
+		settings.Clock = clock.System()
+	}
+
+	return &DigestService{
+		repo:     digestRepo,
+		senders:  senders,
+		logger:   logger,
+		settings: settings,
+	}
+}
+
+func (s *DigestService) Run(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "DigestService.Run")
+	defer span.End()
+
+	now := s.settings.Clock.Now()
+	recipients, err := s.repo.ListRecipients(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, recipient := range recipients {
+		day, due := recipient.DueOn(now, s.settings.Hour)
+		if !due {
+			continue
+		}
+
+		delivered, err := s.deliver(ctx, recipient.User, day, now)
+		if err != nil {
+			s.log(ctx).Warn("Review digest failed, will retry",
+				zap.String("user_id", recipient.User.ID),
+				zap.Error(err))
+			continue
+		}
+
+		if delivered {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// deliver claims the day's digest before sending it, so two instances never
+// both send it. The claim is dropped only when every sender failed.
+func (s *DigestService) deliver(ctx context.Context, user *entities.User, day, now time.Time) (bool, error) {
+	reviews, err := s.repo.ListPendingReviews(ctx, user.ID)
+	if err != nil || len(reviews) == 0 {
+		return false, err
+	}
+
+	for _, review := range reviews {
+		review.Link = pullRequestLink(review.PullRequestID, s.settings.PullRequestURL, s.settings.CodeHostURLs)
+	}
+
+	digest := entities.NewReviewDigest(user, day, reviews, now)
+	digest.Subject, digest.Text = renderDigest(digest)
+
+	claimed, err := s.repo.Claim(ctx, user.ID, day, len(reviews), now)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	delivered := false
+	var errs []error
+	for _, sender := range s.senders {
+		ok, err := sender.SendDigest(ctx, digest)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delivered = delivered || ok
+	}
+
+	if delivered || len(errs) == 0 {
+		if len(errs) > 0 {
+			s.log(ctx).Warn("Review digest reached only some channels",
+				zap.String("user_id", user.ID),
+				zap.Error(errors.Join(errs...)))
+		}
+		return delivered, nil
+	}
+
+	if err := s.repo.Release(ctx, user.ID, day); err != nil {
+		return false, err
+	}
+
+	return false, errors.Join(errs...)
+}
+
+func renderDigest(digest *entities.ReviewDigest) (string, string) {
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "Hi %s,\n\n%s:\n\n", digest.User.Username, digestHeadline(len(digest.Reviews)))
+	for _, review := range digest.Reviews {
+		fmt.Fprintf(&text, "- %s (%s) by %s, open for %s\n",
+			review.PullRequestName, review.PullRequestID, review.AuthorName, formatWaiting(digest.Age(review)))
+		if review.Link != "" {
+			fmt.Fprintf(&text, "  %s\n", review.Link)
+		}
+	}
+
+	return digestHeadline(len(digest.Reviews)), text.String()
+}
+
+func digestHeadline(count int) string {
+	if count == 1 {
+		return "1 pull request awaits your review"
+	}
+	return fmt.Sprintf("%d pull requests await your review", count)
+}
+
+func (s *DigestService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger)
+}
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"errors"
+
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+)
+
+func isDomainError(err error, code domainErrors.ErrorCode) bool {
+	var dErr domainErrors.DomainError
+	return errors.As(err, &dErr) && dErr.Code() == code
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"pr-reviewer-assignment/internal/clock"
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/core/ports/transactions"
+	"pr-reviewer-assignment/internal/logger"
+	"pr-reviewer-assignment/internal/validation"
+
+	"go.uber.org/zap"
+)
+
+const defaultEscalationBatchSize = 100
+
+// EscalationService records every action once per assignment, so several
+// instances can run the scheduler side by side.
+type EscalationService struct {
+	repo         repo.EscalationRepository
+	pullRequests serviceports.PullRequestService
+	reminder     eventports.ReviewReminder
+	txManager    transactions.Manager
+	clock        clock.Clock
+	logger       *zap.Logger
+	authz        *Authorizer
+	batchSize    int
+}
+
+func NewEscalationService(
+	escalationRepo repo.EscalationRepository,
+	pullRequests serviceports.PullRequestService,
+	reminder eventports.ReviewReminder,
+	txManager transactions.Manager,
+	clk clock.Clock,
+	logger *zap.Logger,
+	authz *Authorizer,
+	batchSize int,
+) *EscalationService {
+	if txManager == nil {
+		txManager = transactions.NoopManager{}
+	}
+	if clk == nil {
+		clk = clock.System()
+	}
+	if batchSize <= 0 {
+		batchSize = defaultEscalationBatchSize
+	}
+
+	return &EscalationService{
+		repo:         escalationRepo,
+		pullRequests: pullRequests,
+		reminder:     reminder,
+		txManager:    txManager,
+		clock:        clk,
+		logger:       logger,
+		authz:        authz,
+		batchSize:    batchSize,
+	}
+}
+
+func (s *EscalationService) SetSLA(ctx context.Context, teamName string, remindAfter, escalateAfter time.Duration) (*entities.ReviewSLA, error) {
+	ctx, span := startSpan(ctx, "EscalationService.SetSLA")
+	defer span.End()
+
+	validatedTeam, err := validation.RequireString("team_name", teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if remindAfter < time.Minute {
+		return nil, validation.FieldError{Field: "remind_after", Reason: fmt.Errorf("must be at least 1m")}
+	}
+
+	if escalateAfter != 0 && escalateAfter <= remindAfter {
+		return nil, validation.FieldError{Field: "escalate_after", Reason: fmt.Errorf("must be longer than remind_after")}
+	}
+
+	if err := s.authz.requireTeamManager(ctx, validatedTeam); err != nil {
+		return nil, err
+	}
+
+	sla := &entities.ReviewSLA{
+		TeamName:      validatedTeam,
+		RemindAfter:   remindAfter.Truncate(time.Second),
+		EscalateAfter: escalateAfter.Truncate(time.Second),
+		UpdatedAt:     s.clock.Now(),
+	}
+
+	if err := s.repo.UpsertSLA(ctx, sla); err != nil {
+		return nil, err
+	}
+
+	s.log(ctx).Info("Review SLA set",
+		zap.String("team_name", validatedTeam),
+		zap.Duration("remind_after", sla.RemindAfter),
+		zap.Duration("escalate_after", sla.EscalateAfter))
+	return sla, nil
+}
+
+func (s *EscalationService) ListSLAs(ctx context.Context) ([]*entities.ReviewSLA, error) {
+	ctx, span := startSpan(ctx, "EscalationService.ListSLAs")
+	defer span.End()
+
+	return s.repo.ListSLAs(ctx)
+}
+
+func (s *EscalationService) RemoveSLA(ctx context.Context, teamName string) error {
+	ctx, span := startSpan(ctx, "EscalationService.RemoveSLA")
+	defer span.End()
+
+	validatedTeam, err := validation.RequireString("team_name", teamName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authz.requireTeamManager(ctx, validatedTeam); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteSLA(ctx, validatedTeam)
+}
+
+func (s *EscalationService) ListEscalations(ctx context.Context, filter repo.EscalationFilter) ([]*entities.ReviewEscalation, error) {
+	ctx, span := startSpan(ctx, "EscalationService.ListEscalations")
+	defer span.End()
+
+	return s.repo.ListEscalations(ctx, filter)
+}
+
+func (s *EscalationService) Run(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "EscalationService.Run")
+	defer span.End()
+
+	now := s.clock.Now()
+	reviews, err := s.repo.ListOverdue(ctx, now, s.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	taken := 0
+	for _, review := range reviews {
+		action, due := review.Due(now)
+		if !due {
+			continue
+		}
+
+		var recorded bool
+		if action == types.EscalationReminded {
+			recorded, err = s.remind(ctx, review, now)
+		} else {
+			recorded, err = s.escalate(ctx, review, now)
+		}
+
+		if err != nil {
+			s.log(ctx).Warn("Review escalation failed",
+				zap.String("pr_id", review.PullRequestID),
+				zap.String("reviewer_id", review.ReviewerID),
+				zap.String("action", action.String()),
+				zap.Error(err))
+			continue
+		}
+
+		if recorded {
+			taken++
+		}
+	}
+
+	return taken, nil
+}
+
+// remind records the reminder and queues it in one transaction, so a reminder
+// is neither lost nor sent twice.
+func (s *EscalationService) remind(ctx context.Context, review *entities.OverdueReview, now time.Time) (bool, error) {
+	var recorded bool
+
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		recorded, err = s.repo.Record(txCtx, entities.NewReviewEscalation(review, types.EscalationReminded, "", now))
+		if err != nil || !recorded {
+			return err
+		}
+
+		return s.reminder.Remind(txCtx, review, now)
+	})
+
+	return recorded, err
+}
+
+// escalate replaces the reviewer and records it in one transaction. If
+// another instance got there first the reviewer is no longer assigned and
+// nothing is recorded.
+func (s *EscalationService) escalate(ctx context.Context, review *entities.OverdueReview, now time.Time) (bool, error) {
+	var (
+		action     types.EscalationAction
+		replacedBy string
+		recorded   bool
+	)
+
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		action, replacedBy, recorded = types.EscalationReassigned, "", false
+
+		_, replaced, err := s.pullRequests.ReassignReviewer(txCtx, review.PullRequestID, review.ReviewerID, 0)
+		switch {
+		case err == nil:
+			replacedBy = replaced
+		case isDomainError(err, domainErrors.ErrorCodeNoCandidate):
+			action = types.EscalationNoCandidate
+		case isDomainError(err, domainErrors.ErrorCodeNotAssigned),
+			isDomainError(err, domainErrors.ErrorCodePRMerged),
+			isDomainError(err, domainErrors.ErrorCodePRClosed),
+			isDomainError(err, domainErrors.ErrorCodeNotFound):
+			return nil
+		default:
+			return err
+		}
+
+		recorded, err = s.repo.Record(txCtx, entities.NewReviewEscalation(review, action, replacedBy, now))
+		return err
+	})
+	if err != nil || !recorded {
+		return false, err
+	}
+
+	s.log(ctx).Info("Review escalated",
+		zap.String("pr_id", review.PullRequestID),
+		zap.String("reviewer_id", review.ReviewerID),
+		zap.String("action", action.String()),
+		zap.String("replaced_by", replacedBy))
+	return true, nil
+}
+
+func (s *EscalationService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger)
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultStreamBuffer = 256
+	streamPageSize      = 500
+)
+
+var StreamedEventTypes = []types.EventType{
+	types.EventReviewerAssigned,
+	types.EventReviewerReplaced,
+	types.EventPullRequestMerged,
+	types.EventUserDeactivated,
+	types.EventUserActivated,
+}
+
+func streamMatches(filter serviceports.EventStreamFilter, event *entities.Event) bool {
+	if filter.TeamName != "" && event.TeamName != filter.TeamName {
+		return false
+	}
+
+	return filter.UserID == "" || event.Involves(filter.UserID)
+}
+
+type eventSubscription struct {
+	service *EventStreamService
+	sub     *eventSubscriber
+}
+
+func (s *eventSubscription) Events() <-chan *entities.Event {
+	return s.sub.events
+}
+
+func (s *eventSubscription) Close() {
+	s.service.drop(s.sub)
+}
+
+type eventSubscriber struct {
+	filter serviceports.EventStreamFilter
+	events chan *entities.Event
+}
+
+// EventStreamService: every instance tails the published events in the outbox
+// on its own, so a change made through any instance reaches all clients.
+type EventStreamService struct {
+	outbox   repo.OutboxRepository
+	teamRepo repo.TeamRepository
+	userRepo repo.UserRepository
+	logger   *zap.Logger
+	buffer   int
+
+	mu          sync.Mutex
+	subscribers map[*eventSubscriber]struct{}
+	position    int64
+	started     bool
+	closed      bool
+}
+
+func NewEventStreamService(
+	outbox repo.OutboxRepository,
+	teamRepo repo.TeamRepository,
+	userRepo repo.UserRepository,
+	logger *zap.Logger,
+	buffer int,
+) *EventStreamService {
+	if buffer <= 0 {
+		buffer = defaultStreamBuffer
+	}
+
+	return &EventStreamService{
+		outbox:      outbox,
+		teamRepo:    teamRepo,
+		userRepo:    userRepo,
+		logger:      logger,
+		buffer:      buffer,
+		subscribers: make(map[*eventSubscriber]struct{}),
+	}
+}
+
+func (s *EventStreamService) Subscribe(ctx context.Context, filter serviceports.EventStreamFilter) (serviceports.EventSubscription, error) {
+	ctx, span := startSpan(ctx, "EventStreamService.Subscribe")
+	defer span.End()
+
+	filter, err := s.validate(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &eventSubscriber{filter: filter, events: make(chan *entities.Event, s.buffer)}
+
+	s.mu.Lock()
+	if s.closed {
+		close(sub.events)
+	} else {
+		s.subscribers[sub] = struct{}{}
+	}
+	s.mu.Unlock()
+
+	return &eventSubscription{service: s, sub: sub}, nil
+}
+
+func (s *EventStreamService) Replay(ctx context.Context, filter serviceports.EventStreamFilter, after int64, limit int) ([]*entities.Event, error) {
+	ctx, span := startSpan(ctx, "EventStreamService.Replay")
+	defer span.End()
+
+	if limit <= 0 || limit > streamPageSize {
+		limit = streamPageSize
+	}
+
+	return s.outbox.ListPublished(ctx, repo.PublishedEventFilter{
+		After:    after,
+		Types:    StreamedEventTypes,
+		TeamName: strings.TrimSpace(filter.TeamName),
+		UserID:   strings.TrimSpace(filter.UserID),
+	}, limit)
+}
+
+// Poll: the first poll only finds the current position, so a fresh instance
+// does not replay history to clients that did not ask for it.
+func (s *EventStreamService) Poll(ctx context.Context) error {
+	s.mu.Lock()
+	started, position := s.started, s.position
+	s.mu.Unlock()
+
+	if !started {
+		last, err := s.outbox.LastPosition(ctx)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		s.position, s.started = last, true
+		s.mu.Unlock()
+		return nil
+	}
+
+	for {
+		events, err := s.outbox.ListPublished(ctx, repo.PublishedEventFilter{
+			After: position,
+			Types: StreamedEventTypes,
+		}, streamPageSize)
+		if err != nil {
+			return err
+		}
+
+		if len(events) > 0 {
+			position = events[len(events)-1].Position
+			s.broadcast(ctx, events, position)
+		}
+
+		if len(events) < streamPageSize {
+			return nil
+		}
+	}
+}
+
+func (s *EventStreamService) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for sub := range s.subscribers {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+func (s *EventStreamService) broadcast(ctx context.Context, events []*entities.Event, position int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.position = position
+
+	for sub := range s.subscribers {
+		for _, event := range events {
+			if !streamMatches(sub.filter, event) {
+				continue
+			}
+
+			select {
+			case sub.events <- event:
+				continue
+			default:
+			}
+
+			// A subscriber that cannot keep up is cut off rather than allowed
+			// to hold the others back; it resumes from the table.
+			s.log(ctx).Warn("Event stream subscriber fell behind, disconnecting",
+				zap.Int64("position", event.Position))
+			delete(s.subscribers, sub)
+			close(sub.events)
+			break
+		}
+	}
+}
+
+func (s *EventStreamService) drop(sub *eventSubscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+func (s *EventStreamService) validate(ctx context.Context, filter serviceports.EventStreamFilter) (serviceports.EventStreamFilter, error) {
+	filter.TeamName = strings.TrimSpace(filter.TeamName)
+	filter.UserID = strings.TrimSpace(filter.UserID)
+
+	if filter.TeamName != "" {
+		if _, err := s.teamRepo.Get(ctx, filter.TeamName); err != nil {
+			return filter, err
+		}
+	}
+
+	if filter.UserID != "" {
+		if _, err := s.userRepo.GetByID(ctx, filter.UserID); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, nil
+}
+
+func (s *EventStreamService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger)
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/logger"
+	"pr-reviewer-assignment/internal/validation"
+
+	"go.uber.org/zap"
+)
+
+// IntegrationService turns code host webhooks into pull request operations.
+// Code hosts redeliver and reorder events, so every action is idempotent and
+// events about pull requests that were never tracked here are ignored rather
+// than rejected.
+type IntegrationService struct {
+	accounts     repo.ExternalAccountRepository
+	prRepo       repo.PullRequestRepository
+	pullRequests serviceports.PullRequestService
+	logger       *zap.Logger
+}
+
+func NewIntegrationService(
+	accounts repo.ExternalAccountRepository,
+	prRepo repo.PullRequestRepository,
+	pullRequests serviceports.PullRequestService,
+	logger *zap.Logger,
+) *IntegrationService {
+	return &IntegrationService{
+		accounts:     accounts,
+		prRepo:       prRepo,
+		pullRequests: pullRequests,
+		logger:       logger,
+	}
+}
+
+func (s *IntegrationService) LinkAccount(ctx context.Context, provider types.Provider, login, userID string) (*entities.ExternalAccount, error) {
+	ctx, span := startSpan(ctx, "IntegrationService.LinkAccount")
+	defer span.End()
+
+	validatedLogin, err := validation.RequireString("login", login)
+	if err != nil {
+		return nil, err
+	}
+
+	validatedUserID, err := validation.RequireString("user_id", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &entities.ExternalAccount{
+		Provider:  provider,
+		Login:     normalizeLogin(validatedLogin),
+		UserID:    validatedUserID,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := s.accounts.Link(ctx, account); err != nil {
+		return nil, err
+	}
+
+	s.log(ctx).Info("External account linked",
+		zap.String("provider", provider.String()),
+		zap.String("login", account.Login),
+		zap.String("user_id", account.UserID))
+	return account, nil
+}
+
+func (s *IntegrationService) UnlinkAccount(ctx context.Context, provider types.Provider, login string) error {
+	ctx, span := startSpan(ctx, "IntegrationService.UnlinkAccount")
+	defer span.End()
+
+	validatedLogin, err := validation.RequireString("login", login)
+	if err != nil {
+		return err
+	}
+
+	return s.accounts.Unlink(ctx, provider, normalizeLogin(validatedLogin))
+}
+
+func (s *IntegrationService) ListAccounts(ctx context.Context, provider types.Provider) ([]*entities.ExternalAccount, error) {
+	ctx, span := startSpan(ctx, "IntegrationService.ListAccounts")
+	defer span.End()
+
+	return s.accounts.List(ctx, provider)
+}
+
+func (s *IntegrationService) HandlePullRequestEvent(ctx context.Context, event entities.CodeHostPullRequestEvent) (*entities.SyncOutcome, error) {
+	ctx, span := startSpan(ctx, "IntegrationService.HandlePullRequestEvent")
+	defer span.End()
+
+	outcome, err := s.handle(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	if outcome.PullRequest != nil && len(outcome.PullRequest.AssignedReviewers) > 0 {
+		logins, err := s.accounts.LoginsByUsers(ctx, event.Provider, outcome.PullRequest.AssignedReviewers)
+		if err != nil {
+			return nil, err
+		}
+		outcome.ReviewerLogins = logins
+	}
+
+	return outcome, nil
+}
+
+func (s *IntegrationService) handle(ctx context.Context, event entities.CodeHostPullRequestEvent) (*entities.SyncOutcome, error) {
+	validatedID, err := validation.RequireString("pull_request_id", event.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	event.PullRequestID = validatedID
+
+	existing, err := s.prRepo.GetByID(ctx, event.PullRequestID)
+	if err != nil && !isDomainError(err, domainErrors.ErrorCodeNotFound) {
+		return nil, err
+	}
+
+	log := s.log(ctx).With(
+		zap.String("provider", event.Provider.String()),
+		zap.String("action", string(event.Action)),
+		zap.String("pr_id", event.PullRequestID))
+
+	switch event.Action {
+	case types.CodeHostOpened, types.CodeHostReadyForReview:
+		if existing != nil {
+			return ignored("pull request is already tracked", existing), nil
+		}
+		return s.create(ctx, event, log)
+
+	case types.CodeHostReopened:
+		if existing == nil {
+			return s.create(ctx, event, log)
+		}
+		return s.apply(ctx, types.SyncReopened, event.PullRequestID, s.pullRequests.ReopenPullRequest)
+
+	case types.CodeHostClosed:
+		if existing == nil {
+			return ignored("pull request is not tracked", nil), nil
+		}
+		return s.apply(ctx, types.SyncClosed, event.PullRequestID, s.pullRequests.ClosePullRequest)
+
+	case types.CodeHostMerged:
+		if existing == nil {
+			return ignored("pull request is not tracked", nil), nil
+		}
+		return s.apply(ctx, types.SyncMerged, event.PullRequestID, s.pullRequests.MergePullRequest)
+
+	default:
+		return ignored("action is not handled", existing), nil
+	}
+}
+
+// create starts tracking a pull request once it is ready for review; drafts
+// are picked up later by ready_for_review.
+func (s *IntegrationService) create(ctx context.Context, event entities.CodeHostPullRequestEvent, log *zap.Logger) (*entities.SyncOutcome, error) {
+	if event.Draft {
+		return ignored("pull request is a draft", nil), nil
+	}
+
+	login := strings.TrimSpace(event.AuthorLogin)
+	if login == "" {
+		log.Warn("Pull request author is not known from the event")
+		return ignored("event does not identify the pull request author", nil), nil
+	}
+
+	authorID, err := s.accounts.ResolveUser(ctx, event.Provider, normalizeLogin(login))
+	if err != nil {
+		log.Warn("Pull request author is not linked", zap.String("login", login), zap.Error(err))
+		return nil, err
+	}
+
+	pr, err := s.pullRequests.CreatePullRequest(ctx, entities.NewPullRequest(event.PullRequestID, event.Title, authorID, time.Now().UTC()))
+	if isDomainError(err, domainErrors.ErrorCodePRExists) {
+		// A concurrent redelivery of the same event won the race.
+		existing, getErr := s.prRepo.GetByID(ctx, event.PullRequestID)
+		if getErr != nil {
+			return nil, getErr
+		}
+		return ignored("pull request is already tracked", existing), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Pull request created from code host", zap.Strings("reviewers", pr.AssignedReviewers))
+	return &entities.SyncOutcome{Result: types.SyncCreated, PullRequest: pr}, nil
+}
+
+func (s *IntegrationService) apply(
+	ctx context.Context,
+	result types.SyncResult,
+	prID string,
+	operation func(ctx context.Context, prID string, expectedVersion int) (*entities.PullRequest, error),
+) (*entities.SyncOutcome, error) {
+	pr, err := operation(ctx, prID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.SyncOutcome{Result: result, PullRequest: pr}, nil
+}
+
+func ignored(reason string, pr *entities.PullRequest) *entities.SyncOutcome {
+	return &entities.SyncOutcome{Result: types.SyncIgnored, Reason: reason, PullRequest: pr}
+}
+
+func normalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimSpace(login))
+}
+
+func (s *IntegrationService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger)
+}
@@ -0,0 +1,440 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"pr-reviewer-assignment/internal/clock"
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	"pr-reviewer-assignment/internal/logger"
+	"pr-reviewer-assignment/internal/validation"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultAssignedTemplate = `{{.Reviewer}} was asked to review {{.PullRequest}} by {{.Author}}`
+	DefaultReplacedTemplate = `{{if .Reviewer}}{{.Reviewer}} replaces {{.OldReviewer}} as reviewer of {{.PullRequest}} by {{.Author}}` +
+		`{{else}}{{.OldReviewer}} was removed from {{.PullRequest}} by {{.Author}}, no replacement was available{{end}}`
+	DefaultReminderTemplate = `{{.Reviewer}}, {{.PullRequest}} by {{.Author}} has been waiting for your review for {{.Waiting}}`
+	DefaultDigestTemplate   = `{{.Reviewer}}, {{.Count}} {{if eq .Count 1}}pull request awaits{{else}}pull requests await{{end}} your review, ` +
+		`the oldest is {{.PullRequest}} by {{.Author}}, waiting for {{.Waiting}}`
+)
+
+// NotificationSettings shapes chat messages. Templates are text/template
+// sources executed with a NotificationView; empty ones fall back to the
+// defaults. CodeHostURLs are the web addresses used to link code host pull
+// requests; PullRequestURL links the rest, with "{id}" standing for the pull
+// request ID. Notifications of a team raised within BatchWindow of each other
+// are sent as one message. Clock defaults to the system clock.
+type NotificationSettings struct {
+	AssignedTemplate string
+	ReplacedTemplate string
+	ReminderTemplate string
+	DigestTemplate   string
+	PullRequestURL   string
+	CodeHostURLs     map[types.Provider]string
+	BatchWindow      time.Duration
+	Clock            clock.Clock
+}
+
+// NotificationView is what message templates see. Names are already escaped
+// for Slack, and PullRequest is the pull request name linked when a link is
+// known. Waiting is how long a reminded review, or the oldest review of a
+// digest, has been waiting; Count is the number of reviews in a digest.
+type NotificationView struct {
+	Team            string
+	PullRequestID   string
+	PullRequestName string
+	PullRequest     string
+	Link            string
+	Author          string
+	Reviewer        string
+	OldReviewer     string
+	Waiting         string
+	Count           int
+}
+
+type chatMessage struct {
+	Text    string `json:"text"`
+	Channel string `json:"channel,omitempty"`
+}
+
+// NotificationService tells teams in chat when reviewers are assigned or
+// replaced. Like WebhookService it queues from the outbox relay and sends
+// from a background worker.
+type NotificationService struct {
+	repo      repo.NotificationRepository
+	userRepo  repo.UserRepository
+	client    eventports.WebhookClient
+	logger    *zap.Logger
+	authz     *Authorizer
+	policy    DeliveryPolicy
+	settings  NotificationSettings
+	templates map[types.NotificationKind]*template.Template
+}
+
+func NewNotificationService(
+	notificationRepo repo.NotificationRepository,
+	userRepo repo.UserRepository,
+	client eventports.WebhookClient,
+	logger *zap.Logger,
+	authz *Authorizer,
+	policy DeliveryPolicy,
+	settings NotificationSettings,
+) (*NotificationService, error) {
+	sources := map[types.NotificationKind]string{
+		types.NotificationAssigned: settings.AssignedTemplate,
+		types.NotificationReplaced: settings.ReplacedTemplate,
+		types.NotificationReminder: settings.ReminderTemplate,
+		types.NotificationDigest:   settings.DigestTemplate,
+	}
+	defaults := map[types.NotificationKind]string{
+		types.NotificationAssigned: DefaultAssignedTemplate,
+		types.NotificationReplaced: DefaultReplacedTemplate,
+		types.NotificationReminder: DefaultReminderTemplate,
+		types.NotificationDigest:   DefaultDigestTemplate,
+	}
+
+	templates := make(map[types.NotificationKind]*template.Template, len(sources))
+	for kind, source := range sources {
+		if strings.TrimSpace(source) == "" {
+			source = defaults[kind]
+		}
+
+		tmpl, err := template.New(kind.String()).Option("missingkey=error").Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s notification template: %w", kind, err)
+		}
+		templates[kind] = tmpl
+	}
+
+	if settings.Clock == nil {
+		settings.Clock = clock.System()
+	}
+
+	return &NotificationService{
+		repo:      notificationRepo,
+		userRepo:  userRepo,
+		client:    client,
+		logger:    logger,
+		authz:     authz,
+		policy:    policy.withDefaults(),
+		settings:  settings,
+		templates: templates,
+	}, nil
+}
+
+func (s *NotificationService) SetChannel(ctx context.Context, teamName, webhookURL, channel string) (*entities.ChatChannel, error) {
+	ctx, span := startSpan(ctx, "NotificationService.SetChannel")
+	defer span.End()
+
+	validatedTeam, err := validation.RequireString("team_name", teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	validatedURL, err := requireHTTPURL("webhook_url", webhookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authz.requireTeamManager(ctx, validatedTeam); err != nil {
+		return nil, err
+	}
+
+	chatChannel := &entities.ChatChannel{
+		TeamName:   validatedTeam,
+		WebhookURL: validatedURL,
+		Channel:    strings.TrimSpace(channel),
+		UpdatedAt:  s.settings.Clock.Now(),
+	}
+
+	if err := s.repo.UpsertChannel(ctx, chatChannel); err != nil {
+		return nil, err
+	}
+
+	s.log(ctx).Info("Chat channel set", zap.String("team_name", validatedTeam))
+	return chatChannel, nil
+}
+
+func (s *NotificationService) ListChannels(ctx context.Context) ([]*entities.ChatChannel, error) {
+	ctx, span := startSpan(ctx, "NotificationService.ListChannels")
+	defer span.End()
+
+	channels, err := s.repo.ListChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	managed := make([]*entities.ChatChannel, 0, len(channels))
+	for _, channel := range channels {
+		allowed, err := s.authz.canManageTeam(ctx, channel.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			managed = append(managed, channel)
+		}
+	}
+
+	return managed, nil
+}
+
+func (s *NotificationService) RemoveChannel(ctx context.Context, teamName string) error {
+	ctx, span := startSpan(ctx, "NotificationService.RemoveChannel")
+	defer span.End()
+
+	validatedTeam, err := validation.RequireString("team_name", teamName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authz.requireTeamManager(ctx, validatedTeam); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteChannel(ctx, validatedTeam)
+}
+
+// Publish implements events.Publisher by queueing a notification for teams
+// that have a chat channel. It runs in the outbox relay's transaction.
+func (s *NotificationService) Publish(ctx context.Context, event *entities.Event) error {
+	notification, ok := entities.NewChatNotification(event, s.settings.Clock.Now().Add(s.settings.BatchWindow))
+	if !ok {
+		return nil
+	}
+
+	_, err := s.enqueue(ctx, notification)
+	return err
+}
+
+func (s *NotificationService) Remind(ctx context.Context, review *entities.OverdueReview, at time.Time) error {
+	_, err := s.enqueue(ctx, entities.NewReminderNotification(review, at, at.Add(s.settings.BatchWindow)))
+	return err
+}
+
+// SendDigest implements events.DigestSender by queueing one line for the
+// user's team, so the digests of a team due together share a message.
+func (s *NotificationService) SendDigest(ctx context.Context, digest *entities.ReviewDigest) (bool, error) {
+	return s.enqueue(ctx, entities.NewDigestNotification(digest, digest.GeneratedAt.Add(s.settings.BatchWindow)))
+}
+
+// enqueue reports false for a team without a chat channel.
+func (s *NotificationService) enqueue(ctx context.Context, notification *entities.ChatNotification) (bool, error) {
+	if _, err := s.repo.GetChannel(ctx, notification.TeamName); err != nil {
+		if isDomainError(err, domainErrors.ErrorCodeNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if err := s.repo.Enqueue(ctx, notification); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// SendDue sends the notifications whose time has come, one message per team,
+// and returns how many notifications went out.
+func (s *NotificationService) SendDue(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "NotificationService.SendDue")
+	defer span.End()
+
+	now := s.settings.Clock.Now()
+	notifications, err := s.repo.ClaimDue(ctx, now, now.Add(s.policy.Lease), s.policy.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var teams []string
+	byTeam := make(map[string][]*entities.ChatNotification)
+	for _, n := range notifications {
+		if _, ok := byTeam[n.TeamName]; !ok {
+			teams = append(teams, n.TeamName)
+		}
+		byTeam[n.TeamName] = append(byTeam[n.TeamName], n)
+	}
+
+	sent := 0
+	for _, teamName := range teams {
+		batch := byTeam[teamName]
+
+		err := s.send(ctx, teamName, batch)
+		now := s.settings.Clock.Now()
+
+		for _, n := range batch {
+			if err == nil {
+				n.MarkSent(now)
+			} else {
+				n.MarkFailed(truncate(err.Error(), maxDeliveryErrorLength), s.policy.MaxAttempts, now.Add(s.policy.backoff(n.Attempts+1)))
+			}
+
+			if err := s.repo.Update(ctx, n); err != nil {
+				return sent, err
+			}
+		}
+
+		if err == nil {
+			sent += len(batch)
+			continue
+		}
+
+		log := s.log(ctx).With(zap.String("team_name", teamName), zap.Int("notifications", len(batch)), zap.Error(err))
+		if batch[0].Status == types.DeliveryDead {
+			log.Warn("Chat notification abandoned")
+		} else {
+			log.Info("Chat notification failed, will retry", zap.Time("next_attempt_at", batch[0].NextAttemptAt))
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *NotificationService) send(ctx context.Context, teamName string, batch []*entities.ChatNotification) error {
+	channel, err := s.repo.GetChannel(ctx, teamName)
+	if err != nil {
+		return err
+	}
+
+	text, err := s.render(ctx, teamName, batch)
+	if err != nil {
+		return err
+	}
+
+	return s.post(ctx, channel, text)
+}
+
+func (s *NotificationService) post(ctx context.Context, channel *entities.ChatChannel, text string) error {
+	body, err := json.Marshal(chatMessage{Text: text, Channel: channel.Channel})
+	if err != nil {
+		return err
+	}
+
+	statusCode, err := s.client.Send(ctx, eventports.WebhookRequest{URL: channel.WebhookURL, Body: body})
+	if err != nil {
+		return err
+	}
+
+	if statusCode < 200 || statusCode >= 300 {
+		return fmt.Errorf("chat webhook responded with status %d", statusCode)
+	}
+
+	return nil
+}
+
+func (s *NotificationService) render(ctx context.Context, teamName string, batch []*entities.ChatNotification) (string, error) {
+	members, err := s.userRepo.ListByTeam(ctx, teamName)
+	if err != nil {
+		return "", err
+	}
+
+	names := make(map[string]string, len(members))
+	for _, member := range members {
+		names[member.ID] = member.Username
+	}
+
+	nameOf := func(userID string) string {
+		if userID == "" {
+			return ""
+		}
+		if name, ok := names[userID]; ok && name != "" {
+			return escapeChat(name)
+		}
+		return escapeChat(userID)
+	}
+
+	lines := make([]string, 0, len(batch))
+	for _, n := range batch {
+		view := NotificationView{
+			Team:            escapeChat(teamName),
+			PullRequestID:   escapeChat(n.PullRequestID),
+			PullRequestName: escapeChat(n.PullRequestName),
+			Link:            s.link(n.PullRequestID),
+			Author:          nameOf(n.AuthorID),
+			Reviewer:        nameOf(n.ReviewerID),
+			OldReviewer:     nameOf(n.OldReviewerID),
+			Count:           n.ReviewCount,
+		}
+
+		if n.AssignedAt != nil {
+			view.Waiting = formatWaiting(n.CreatedAt.Sub(*n.AssignedAt))
+		}
+
+		view.PullRequest = view.PullRequestName
+		if view.Link != "" {
+			view.PullRequest = "<" + view.Link + "|" + view.PullRequestName + ">"
+		}
+
+		var line strings.Builder
+		if err := s.templates[n.Kind].Execute(&line, view); err != nil {
+			return "", fmt.Errorf("render %s notification %d: %w", n.Kind, n.ID, err)
+		}
+		lines = append(lines, line.String())
+	}
+
+	if len(lines) == 1 {
+		return lines[0], nil
+	}
+
+	return fmt.Sprintf("%d review updates for %s:\n• %s", len(lines), escapeChat(teamName), strings.Join(lines, "\n• ")), nil
+}
+
+func (s *NotificationService) link(prID string) string {
+	return pullRequestLink(prID, s.settings.PullRequestURL, s.settings.CodeHostURLs)
+}
+
+func pullRequestLink(prID, pullRequestURL string, codeHostURLs map[types.Provider]string) string {
+	if ref, ok := entities.ParseCodeHostRef(prID); ok {
+		base := strings.TrimRight(codeHostURLs[ref.Provider], "/")
+		if base == "" {
+			return ""
+		}
+
+		if ref.Provider == types.ProviderGitLab {
+			return fmt.Sprintf("%s/%s/-/merge_requests/%d", base, ref.Repository, ref.Number)
+		}
+		return fmt.Sprintf("%s/%s/pull/%d", base, ref.Repository, ref.Number)
+	}
+
+	if pullRequestURL == "" {
+		return ""
+	}
+
+	return strings.ReplaceAll(pullRequestURL, "{id}", url.PathEscape(prID))
+}
+
+func formatWaiting(d time.Duration) string {
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(max(d, 0)/time.Minute))
+	}
+
+	days, hours := int(d/(24*time.Hour)), int(d%(24*time.Hour)/time.Hour)
+	switch {
+	case days == 0:
+		return fmt.Sprintf("%dh", hours)
+	case hours == 0:
+		return fmt.Sprintf("%dd", days)
+	default:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	}
+}
+
+// escapeChat escapes the characters Slack treats as markup.
+func escapeChat(value string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(value)
+}
+
+func (s *NotificationService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger)
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	"pr-reviewer-assignment/internal/core/ports/transactions"
+	"pr-reviewer-assignment/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+const defaultOutboxBatchSize = 100
+
+// OutboxRelay hands events recorded by the services to a Publisher. Events
+// go out in ID order and the relay stops at the first failed one, so later
+// events never overtake it; it is retried on the next run.
+type OutboxRelay struct {
+	outbox    repo.OutboxRepository
+	publisher eventports.Publisher
+	txManager transactions.Manager
+	logger    *zap.Logger
+	batchSize int
+}
+
+func NewOutboxRelay(
+	outbox repo.OutboxRepository,
+	publisher eventports.Publisher,
+	txManager transactions.Manager,
+	logger *zap.Logger,
+	batchSize int,
+) *OutboxRelay {
+	if publisher == nil {
+		publisher = eventports.NoopPublisher{}
+	}
+	if txManager == nil {
+		txManager = transactions.NoopManager{}
+	}
+	if batchSize <= 0 {
+		batchSize = defaultOutboxBatchSize
+	}
+
+	return &OutboxRelay{
+		outbox:    outbox,
+		publisher: publisher,
+		txManager: txManager,
+		logger:    logger,
+		batchSize: batchSize,
+	}
+}
+
+// RelayPending publishes everything pending and returns how many events were
+// delivered. It returns early without error when another instance is
+// relaying.
+func (r *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "OutboxRelay.RelayPending")
+	defer span.End()
+
+	total := 0
+	for {
+		published, more, err := r.relayBatch(ctx)
+		total += published
+		if err != nil || !more {
+			return total, err
+		}
+	}
+}
+
+func (r *OutboxRelay) relayBatch(ctx context.Context) (int, bool, error) {
+	var (
+		published  int
+		more       bool
+		publishErr error
+	)
+
+	// The batch stays in one transaction so the advisory lock is held while
+	// publishing; a crash before commit re-delivers the batch (at-least-once).
+	if err := r.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		published, more, publishErr = 0, false, nil
+
+		locked, err := r.outbox.LockRelay(txCtx)
+		if err != nil || !locked {
+			return err
+		}
+
+		events, err := r.outbox.ListPending(txCtx, r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]int64, 0, len(events))
+		for _, event := range events {
+			if err := r.publisher.Publish(txCtx, event); err != nil {
+				r.log(ctx).Warn("Failed to publish outbox event",
+					zap.Int64("event_id", event.ID),
+					zap.String("event_type", event.Type.String()),
+					zap.Error(err))
+				publishErr = err
+				break
+			}
+			ids = append(ids, event.ID)
+		}
+
+		if err := r.outbox.MarkPublished(txCtx, ids, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		published = len(ids)
+		more = publishErr == nil && len(events) == r.batchSize
+		return nil
+	}); err != nil {
+		r.log(ctx).Error("Failed to relay outbox events", zap.Error(err))
+		return 0, false, err
+	}
+
+	return published, more, publishErr
+}
+
+// PurgePublished drops delivered events older than the retention window.
+func (r *OutboxRelay) PurgePublished(ctx context.Context, retention time.Duration) error {
+	deleted, err := r.outbox.DeletePublishedBefore(ctx, time.Now().UTC().Add(-retention))
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		r.log(ctx).Info("Purged published outbox events", zap.Int64("deleted", deleted))
+	}
+
+	return nil
+}
+
+func (r *OutboxRelay) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, r.logger)
+}
+
+// recordEvents appends events to the outbox in the caller's transaction; a
+// nil outbox (tests, tools) drops them.
+func recordEvents(ctx context.Context, outbox repo.OutboxRepository, events ...*entities.Event) error {
+	if outbox == nil || len(events) == 0 {
+		return nil
+	}
+
+	return outbox.Append(ctx, events...)
+}
@@ -2,13 +2,17 @@ package services
 
 import (
 	"context"
+	"sort"
 	"time"
 
+	"pr-reviewer-assignment/internal/clock"
 	"pr-reviewer-assignment/internal/core/domain/entities"
 	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
 	"pr-reviewer-assignment/internal/core/domain/types"
+	metricsports "pr-reviewer-assignment/internal/core/ports/metrics"
 	repo "pr-reviewer-assignment/internal/core/ports/repositories"
 	"pr-reviewer-assignment/internal/core/ports/transactions"
+	"pr-reviewer-assignment/internal/logger"
 	"pr-reviewer-assignment/internal/validation"
 
 	"go.uber.org/zap"
@@ -18,94 +22,156 @@ type PullRequestService struct {
 	prRepo    repo.PullRequestRepository
 	userRepo  repo.UserRepository
 	teamRepo  repo.TeamRepository
+	outbox    repo.OutboxRepository
 	logger    *zap.Logger
 	txManager transactions.Manager
+	clock     clock.Clock
+	recorder  metricsports.Recorder
+	authz     *Authorizer
 }
 
+const (
+	operationCreate   = "create"
+	operationReassign = "reassign"
+)
+
+// reviewerSelectionTx: selection locks the team row (GetForUpdate), so
+// transactions picking reviewers from one team already run one at a time.
+// READ COMMITTED lets the reads after the lock see what the previous holder
+// committed; a SERIALIZABLE snapshot taken before the lock wait would not,
+// and would abort on every contended create.
+var reviewerSelectionTx = transactions.WithIsolation(transactions.IsolationReadCommitted)
+
+// maxConflictAttempts bounds how often an update without an expected version
+// is replayed after another writer bumped the pull request first.
+const maxConflictAttempts = 3
+
 func NewPullRequestService(
 	prRepo repo.PullRequestRepository,
 	userRepo repo.UserRepository,
 	teamRepo repo.TeamRepository,
+	outbox repo.OutboxRepository,
 	logger *zap.Logger,
 	txManager transactions.Manager,
+	clk clock.Clock,
+	recorder metricsports.Recorder,
+	authz *Authorizer,
 ) *PullRequestService {
 	if txManager == nil {
 		txManager = transactions.NoopManager{}
 	}
+	if clk == nil {
+		clk = clock.System()
+	}
+	if recorder == nil {
+		recorder = metricsports.NoopRecorder{}
+	}
 	return &PullRequestService{
 		prRepo:    prRepo,
 		userRepo:  userRepo,
 		teamRepo:  teamRepo,
+		outbox:    outbox,
 		logger:    logger,
 		txManager: txManager,
+		clock:     clk,
+		recorder:  recorder,
+		authz:     authz,
 	}
 }
 
 func (s *PullRequestService) CreatePullRequest(ctx context.Context, pr *entities.PullRequest) (*entities.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PullRequestService.CreatePullRequest")
+	defer span.End()
+
 	if err := validation.RequireNotNil("pull_request", pr); err != nil {
-		s.logger.Error("Invalid pull request payload", zap.Error(err))
+		s.log(ctx).Error("Invalid pull request payload", zap.Error(err))
 		return nil, err
 	}
 
 	validatedID, err := validation.RequireString("pull_request_id", pr.ID)
 	if err != nil {
-		s.logger.Error("Invalid pull request id", zap.String("pull_request_id", pr.ID), zap.Error(err))
+		s.log(ctx).Error("Invalid pull request id", zap.String("pull_request_id", pr.ID), zap.Error(err))
 		return nil, err
 	}
 	pr.ID = validatedID
 
 	validatedName, err := validation.RequireString("pull_request_name", pr.Name)
 	if err != nil {
-		s.logger.Error("Invalid pull request name", zap.String("pull_request_name", pr.Name), zap.Error(err))
+		s.log(ctx).Error("Invalid pull request name", zap.String("pull_request_name", pr.Name), zap.Error(err))
 		return nil, err
 	}
 	pr.Name = validatedName
 
 	validatedAuthorID, err := validation.RequireString("author_id", pr.AuthorID)
 	if err != nil {
-		s.logger.Error("Invalid author id", zap.String("author_id", pr.AuthorID), zap.Error(err))
+		s.log(ctx).Error("Invalid author id", zap.String("author_id", pr.AuthorID), zap.Error(err))
 		return nil, err
 	}
 	pr.AuthorID = validatedAuthorID
 
+	now := s.clock.Now()
 	if pr.CreatedAt.IsZero() {
-		pr.CreatedAt = time.Now().UTC()
+		pr.CreatedAt = now
 	}
+	pr.ChangedAt = now
 
 	if err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
 		author, err := s.userRepo.GetByID(txCtx, pr.AuthorID)
 		if err != nil {
-			s.logger.Error("Failed to load author", zap.String("author_id", pr.AuthorID), zap.Error(err))
+			s.log(ctx).Error("Failed to load author", zap.String("author_id", pr.AuthorID), zap.Error(err))
+			return err
+		}
+
+		if err := s.authz.requirePullRequestParticipant(txCtx, pr, author.TeamName); err != nil {
+			return err
+		}
+
+		team, err := s.teamRepo.GetForUpdate(txCtx, author.TeamName)
+		if err != nil {
+			s.log(ctx).Error("Failed to load team for author", zap.String("team_name", author.TeamName), zap.Error(err))
 			return err
 		}
 
-		team, err := s.teamRepo.Get(txCtx, author.TeamName)
+		load, err := s.prRepo.CountOpenAssignmentsByReviewer(txCtx, team.Name)
 		if err != nil {
-			s.logger.Error("Failed to load team for author", zap.String("team_name", author.TeamName), zap.Error(err))
+			s.log(ctx).Error("Failed to load reviewer load", zap.String("team_name", team.Name), zap.Error(err))
 			return err
 		}
 
-		candidateIDs := s.buildReviewerPool(team.ActiveMembersExcluding(pr.AuthorID))
+		candidateIDs := s.buildReviewerPool(team.ActiveMembersExcluding(pr.AuthorID), load)
 
 		if err := pr.AssignReviewers(candidateIDs); err != nil {
-			s.logger.Error("Failed to assign reviewers", zap.String("pr_id", pr.ID), zap.Error(err))
+			s.log(ctx).Error("Failed to assign reviewers", zap.String("pr_id", pr.ID), zap.Error(err))
 			return err
 		}
 
 		if err := s.prRepo.Create(txCtx, pr); err != nil {
-			s.logger.Error("Failed to persist pull request", zap.String("pr_id", pr.ID), zap.Error(err))
+			s.log(ctx).Error("Failed to persist pull request", zap.String("pr_id", pr.ID), zap.Error(err))
 			return err
 		}
 
-		return nil
-	}); err != nil {
+		events := []*entities.Event{entities.NewPullRequestCreated(pr, team.Name, now)}
+		for _, reviewerID := range pr.AssignedReviewers {
+			events = append(events, entities.NewReviewerAssigned(pr, team.Name, reviewerID, now))
+		}
+
+		return s.recordEvents(txCtx, events...)
+	}, reviewerSelectionTx); err != nil {
 		return nil, err
 	}
 
+	if len(pr.AssignedReviewers) == 0 {
+		s.recorder.NoCandidate(operationCreate)
+	}
+	s.recorder.ReviewersAssigned(len(pr.AssignedReviewers))
+
 	return pr, nil
 }
 
-func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string) (*entities.PullRequest, error) {
+func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string, expectedVersion int) (*entities.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PullRequestService.MergePullRequest")
+	defer span.End()
+
 	validatedID, err := validation.RequireString("pull_request_id", prID)
 	if err != nil {
 		return nil, err
@@ -114,20 +180,33 @@ func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string)
 
 	var mergedPR *entities.PullRequest
 
-	if err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
-		pr, err := s.prRepo.GetByID(txCtx, prID)
+	if err := s.retryOnConflict(ctx, prID, expectedVersion, func(txCtx context.Context) error {
+		pr, err := s.loadVersioned(txCtx, prID, expectedVersion)
 		if err != nil {
-			s.logger.Error("Failed to load pull request", zap.String("pr_id", prID), zap.Error(err))
 			return err
 		}
 
-		pr.Merge(time.Now().UTC())
+		authorTeam, err := s.authorizeParticipant(txCtx, pr)
+		if err != nil {
+			return err
+		}
+
+		wasOpen := pr.Status == types.PRStatusOpen
+		now := s.clock.Now()
+		pr.Merge(now)
+		pr.ChangedAt = now
 
 		if err := s.prRepo.Update(txCtx, pr); err != nil {
-			s.logger.Error("Failed to update pull request", zap.String("pr_id", pr.ID), zap.Error(err))
+			s.log(ctx).Error("Failed to update pull request", zap.String("pr_id", pr.ID), zap.Error(err))
 			return err
 		}
 
+		if wasOpen {
+			if err := s.recordEvents(txCtx, entities.NewPullRequestMerged(pr, authorTeam, now)); err != nil {
+				return err
+			}
+		}
+
 		mergedPR = pr
 		return nil
 	}); err != nil {
@@ -137,17 +216,95 @@ func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string)
 	return mergedPR, nil
 }
 
-func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*entities.PullRequest, string, error) {
+// ClosePullRequest takes an abandoned pull request out of its reviewers'
+// queues. Closing a closed pull request returns it unchanged.
+func (s *PullRequestService) ClosePullRequest(ctx context.Context, prID string, expectedVersion int) (*entities.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PullRequestService.ClosePullRequest")
+	defer span.End()
+
+	return s.changeStatus(ctx, prID, expectedVersion, types.PRStatusClosed, (*entities.PullRequest).Close, entities.NewPullRequestClosed)
+}
+
+// ReopenPullRequest puts a closed pull request back in review with the
+// reviewers it had when it was closed.
+func (s *PullRequestService) ReopenPullRequest(ctx context.Context, prID string, expectedVersion int) (*entities.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PullRequestService.ReopenPullRequest")
+	defer span.End()
+
+	return s.changeStatus(ctx, prID, expectedVersion, types.PRStatusOpen, (*entities.PullRequest).Reopen, entities.NewPullRequestReopened)
+}
+
+// changeStatus applies a status transition and records its event, skipping
+// both when the pull request is already in the target status.
+func (s *PullRequestService) changeStatus(
+	ctx context.Context,
+	prID string,
+	expectedVersion int,
+	target types.PRStatus,
+	apply func(*entities.PullRequest) error,
+	newEvent func(*entities.PullRequest, string, time.Time) *entities.Event,
+) (*entities.PullRequest, error) {
+	validatedID, err := validation.RequireString("pull_request_id", prID)
+	if err != nil {
+		return nil, err
+	}
+	prID = validatedID
+
+	var updatedPR *entities.PullRequest
+
+	if err := s.retryOnConflict(ctx, prID, expectedVersion, func(txCtx context.Context) error {
+		pr, err := s.loadVersioned(txCtx, prID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		authorTeam, err := s.authorizeParticipant(txCtx, pr)
+		if err != nil {
+			return err
+		}
+
+		if pr.Status == target {
+			updatedPR = pr
+			return nil
+		}
+
+		if err := apply(pr); err != nil {
+			return err
+		}
+		pr.ChangedAt = s.clock.Now()
+
+		if err := s.prRepo.Update(txCtx, pr); err != nil {
+			s.log(ctx).Error("Failed to update pull request", zap.String("pr_id", pr.ID), zap.Error(err))
+			return err
+		}
+
+		if err := s.recordEvents(txCtx, newEvent(pr, authorTeam, pr.ChangedAt)); err != nil {
+			return err
+		}
+
+		updatedPR = pr
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return updatedPR, nil
+}
+
+func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldReviewerID string, expectedVersion int) (*entities.PullRequest, string, error) {
+	ctx, span := startSpan(ctx, "PullRequestService.ReassignReviewer")
+	defer span.End()
+
 	validatedPrID, err := validation.RequireString("pull_request_id", prID)
 	if err != nil {
-		s.logger.Error("Invalid pull request id", zap.String("pull_request_id", prID), zap.Error(err))
+		s.log(ctx).Error("Invalid pull request id", zap.String("pull_request_id", prID), zap.Error(err))
 		return nil, "", err
 	}
 	prID = validatedPrID
 
 	validatedOldReviewerID, err := validation.RequireString("reviewer_id", oldReviewerID)
 	if err != nil {
-		s.logger.Error("Invalid reviewer id", zap.String("reviewer_id", oldReviewerID), zap.Error(err))
+		s.log(ctx).Error("Invalid reviewer id", zap.String("reviewer_id", oldReviewerID), zap.Error(err))
 		return nil, "", err
 	}
 	oldReviewerID = validatedOldReviewerID
@@ -157,56 +314,148 @@ func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldRevi
 		newReviewerID string
 	)
 
-	if err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
-		pr, err := s.prRepo.GetByID(txCtx, prID)
+	if err := s.retryOnConflict(ctx, prID, expectedVersion, func(txCtx context.Context) error {
+		pr, err := s.loadVersioned(txCtx, prID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		authorTeam, err := s.authorizeParticipant(txCtx, pr)
 		if err != nil {
-			s.logger.Error("Failed to load pull request", zap.String("pr_id", prID), zap.Error(err))
 			return err
 		}
 
-		if pr.Status == types.PRStatusMerged {
+		switch pr.Status {
+		case types.PRStatusMerged:
 			return domainErrors.PRMerged(pr.ID)
+		case types.PRStatusClosed:
+			return domainErrors.PRClosed(pr.ID)
 		}
 
 		reviewer, err := s.userRepo.GetByID(txCtx, oldReviewerID)
 		if err != nil {
-			s.logger.Error("Failed to load reviewer", zap.String("reviewer_id", oldReviewerID), zap.Error(err))
+			s.log(ctx).Error("Failed to load reviewer", zap.String("reviewer_id", oldReviewerID), zap.Error(err))
 			return err
 		}
 
-		team, err := s.teamRepo.Get(txCtx, reviewer.TeamName)
+		team, err := s.teamRepo.GetForUpdate(txCtx, reviewer.TeamName)
 		if err != nil {
-			s.logger.Error("Failed to load reviewer team", zap.String("team_name", reviewer.TeamName), zap.Error(err))
+			s.log(ctx).Error("Failed to load reviewer team", zap.String("team_name", reviewer.TeamName), zap.Error(err))
 			return err
 		}
 
-		replacementID, err := s.pickReplacement(team, pr, oldReviewerID)
+		load, err := s.prRepo.CountOpenAssignmentsByReviewer(txCtx, team.Name)
 		if err != nil {
-			s.logger.Error("Failed to pick replacement reviewer", zap.String("pr_id", prID), zap.Error(err))
+			s.log(ctx).Error("Failed to load reviewer load", zap.String("team_name", team.Name), zap.Error(err))
+			return err
+		}
+
+		replacementID, err := s.pickReplacement(team, pr, oldReviewerID, load)
+		if err != nil {
+			s.log(ctx).Error("Failed to pick replacement reviewer", zap.String("pr_id", prID), zap.Error(err))
+			if isDomainError(err, domainErrors.ErrorCodeNoCandidate) {
+				s.recorder.NoCandidate(operationReassign)
+			}
 			return err
 		}
 
 		newReviewerID, err = pr.ReplaceReviewer(oldReviewerID, replacementID)
 		if err != nil {
-			s.logger.Error("Failed to replace reviewer", zap.String("pr_id", prID), zap.Error(err))
+			s.log(ctx).Error("Failed to replace reviewer", zap.String("pr_id", prID), zap.Error(err))
 			return err
 		}
+		pr.ChangedAt = s.clock.Now()
 
 		if err := s.prRepo.Update(txCtx, pr); err != nil {
-			s.logger.Error("Failed to update pull request", zap.String("pr_id", prID), zap.Error(err))
+			s.log(ctx).Error("Failed to update pull request", zap.String("pr_id", prID), zap.Error(err))
+			return err
+		}
+
+		event := entities.NewReviewerReplaced(pr, authorTeam, oldReviewerID, newReviewerID, pr.ChangedAt)
+		if err := s.recordEvents(txCtx, event); err != nil {
 			return err
 		}
 
+		transactions.AfterCommit(txCtx, s.recorder.ReviewerReassigned)
 		updatedPR = pr
 		return nil
-	}); err != nil {
+	}, reviewerSelectionTx); err != nil {
 		return nil, "", err
 	}
 
 	return updatedPR, newReviewerID, nil
 }
 
-func (s *PullRequestService) buildReviewerPool(members []*entities.User) []string {
+// retryOnConflict runs fn in a fresh transaction, replaying it when the update
+// lost a version race. Callers that pinned a version get the conflict back
+// immediately since a replay could never satisfy their precondition.
+func (s *PullRequestService) retryOnConflict(ctx context.Context, prID string, expectedVersion int, fn func(txCtx context.Context) error, opts ...transactions.Option) error {
+	attempts := maxConflictAttempts
+	if expectedVersion > 0 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = s.txManager.WithinTransaction(ctx, fn, opts...)
+		if !isDomainError(err, domainErrors.ErrorCodeConflict) {
+			return err
+		}
+
+		s.log(ctx).Info("Pull request modified concurrently",
+			zap.String("pr_id", prID),
+			zap.Int("attempt", attempt))
+	}
+
+	return err
+}
+
+func (s *PullRequestService) loadVersioned(ctx context.Context, prID string, expectedVersion int) (*entities.PullRequest, error) {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		s.log(ctx).Error("Failed to load pull request", zap.String("pr_id", prID), zap.Error(err))
+		return nil, err
+	}
+
+	if expectedVersion > 0 && pr.Version != expectedVersion {
+		s.log(ctx).Warn("Pull request version precondition failed",
+			zap.String("pr_id", prID),
+			zap.Int("expected_version", expectedVersion),
+			zap.Int("version", pr.Version))
+		return nil, domainErrors.Conflict(prID)
+	}
+
+	return pr, nil
+}
+
+// authorizeParticipant checks the caller may change pr and returns the
+// author's team, which events about the pull request are attributed to.
+func (s *PullRequestService) authorizeParticipant(ctx context.Context, pr *entities.PullRequest) (string, error) {
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		s.log(ctx).Error("Failed to load author", zap.String("author_id", pr.AuthorID), zap.Error(err))
+		return "", err
+	}
+
+	if err := s.authz.requirePullRequestParticipant(ctx, pr, author.TeamName); err != nil {
+		return "", err
+	}
+
+	return author.TeamName, nil
+}
+
+func (s *PullRequestService) recordEvents(ctx context.Context, events ...*entities.Event) error {
+	if err := recordEvents(ctx, s.outbox, events...); err != nil {
+		s.log(ctx).Error("Failed to record events", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// buildReviewerPool orders active members by their open review load, least
+// loaded first, breaking ties by user ID so selection is deterministic.
+func (s *PullRequestService) buildReviewerPool(members []*entities.User, load map[string]int) []string {
 	if len(members) == 0 {
 		return nil
 	}
@@ -232,10 +481,17 @@ func (s *PullRequestService) buildReviewerPool(members []*entities.User) []strin
 		pool = append(pool, id)
 	}
 
+	sort.Slice(pool, func(i, j int) bool {
+		if load[pool[i]] != load[pool[j]] {
+			return load[pool[i]] < load[pool[j]]
+		}
+		return pool[i] < pool[j]
+	})
+
 	return pool
 }
 
-func (s *PullRequestService) pickReplacement(team *entities.Team, pr *entities.PullRequest, oldReviewerID string) (string, error) {
+func (s *PullRequestService) pickReplacement(team *entities.Team, pr *entities.PullRequest, oldReviewerID string, load map[string]int) (string, error) {
 	candidates := team.ActiveMembersExcluding(pr.AuthorID)
 	if len(candidates) == 0 {
 		return "", domainErrors.NoCandidate(team.Name)
@@ -251,16 +507,7 @@ func (s *PullRequestService) pickReplacement(team *entities.Team, pr *entities.P
 	excluded[oldReviewerID] = struct{}{}
 	excluded[pr.AuthorID] = struct{}{}
 
-	for _, candidate := range candidates {
-		if candidate == nil {
-			continue
-		}
-
-		id, err := validation.RequireString("user_id", candidate.ID)
-		if err != nil {
-			continue
-		}
-
+	for _, id := range s.buildReviewerPool(candidates, load) {
 		if _, exists := excluded[id]; exists {
 			continue
 		}
@@ -270,3 +517,7 @@ func (s *PullRequestService) pickReplacement(team *entities.Team, pr *entities.P
 
 	return "", domainErrors.NoCandidate(team.Name)
 }
+
+func (s *PullRequestService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger)
+}
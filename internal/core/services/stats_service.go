@@ -2,26 +2,43 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
 	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	"pr-reviewer-assignment/internal/core/ports/transactions"
+	"pr-reviewer-assignment/internal/validation"
 )
 
 type StatsService struct {
-	teamRepo repo.TeamRepository
-	userRepo repo.UserRepository
-	prRepo   repo.PullRequestRepository
+	teamRepo  repo.TeamRepository
+	userRepo  repo.UserRepository
+	prRepo    repo.PullRequestRepository
+	statsRepo repo.StatsRepository
+	txManager transactions.Manager
 }
 
-func NewStatsService(teamRepo repo.TeamRepository, userRepo repo.UserRepository, prRepo repo.PullRequestRepository) *StatsService {
+const defaultStatsHistoryWindow = 24 * time.Hour
+
+func NewStatsService(teamRepo repo.TeamRepository, userRepo repo.UserRepository, prRepo repo.PullRequestRepository, statsRepo repo.StatsRepository, txManager transactions.Manager) *StatsService {
+	if txManager == nil {
+		txManager = transactions.NoopManager{}
+	}
 	return &StatsService{
-		teamRepo: teamRepo,
-		userRepo: userRepo,
-		prRepo:   prRepo,
+		teamRepo:  teamRepo,
+		userRepo:  userRepo,
+		prRepo:    prRepo,
+		statsRepo: statsRepo,
+		txManager: txManager,
 	}
 }
 
 func (s *StatsService) GetStats(ctx context.Context) (*entities.Stats, error) {
+	ctx, span := startSpan(ctx, "StatsService.GetStats")
+	defer span.End()
+
 	teams, err := s.teamRepo.Count(ctx)
 	if err != nil {
 		return nil, err
@@ -49,3 +66,86 @@ func (s *StatsService) GetStats(ctx context.Context) (*entities.Stats, error) {
 		Assignments:  assignments,
 	}, nil
 }
+
+func (s *StatsService) GetFairness(ctx context.Context, teamName string, from, to *time.Time) (*entities.FairnessReport, error) {
+	ctx, span := startSpan(ctx, "StatsService.GetFairness")
+	defer span.End()
+
+	validatedName, err := validation.RequireString("team_name", teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, validation.FieldError{Field: "from", Reason: fmt.Errorf("must be before to")}
+	}
+
+	team, err := s.teamRepo.Get(ctx, validatedName)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.prRepo.CountAssignmentsByReviewer(ctx, validatedName, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewFairnessReport(team, assignments, from, to), nil
+}
+
+func (s *StatsService) TakeSnapshot(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "StatsService.TakeSnapshot")
+	defer span.End()
+
+	// REPEATABLE READ makes every count in the snapshot see the same data.
+	return s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		stats, err := s.GetStats(txCtx)
+		if err != nil {
+			return err
+		}
+
+		openByTeam, err := s.prRepo.CountOpenByTeam(txCtx)
+		if err != nil {
+			return err
+		}
+
+		snapshot := entities.NewStatsSnapshot(time.Now().UTC(), stats, openByTeam)
+		return s.statsRepo.SaveSnapshot(txCtx, snapshot)
+	}, transactions.WithIsolation(transactions.IsolationRepeatableRead))
+}
+
+func (s *StatsService) GetHistory(ctx context.Context, query entities.StatsHistoryQuery) (*entities.StatsHistory, error) {
+	ctx, span := startSpan(ctx, "StatsService.GetHistory")
+	defer span.End()
+
+	if _, ok := types.ParseStatsMetric(query.Metric.String()); !ok {
+		return nil, validation.FieldError{Field: "metric", Reason: fmt.Errorf("unknown metric %q", query.Metric)}
+	}
+
+	if query.TeamName != "" && !query.Metric.IsTeamScoped() {
+		return nil, validation.FieldError{Field: "team_name", Reason: fmt.Errorf("metric %s is not recorded per team", query.Metric)}
+	}
+
+	if query.Bucket == "" {
+		query.Bucket = types.StatsBucketHour
+	}
+
+	if query.To.IsZero() {
+		query.To = time.Now().UTC()
+	}
+
+	if query.From.IsZero() {
+		query.From = query.To.Add(-defaultStatsHistoryWindow)
+	}
+
+	if !query.From.Before(query.To) {
+		return nil, validation.FieldError{Field: "from", Reason: fmt.Errorf("must be before to")}
+	}
+
+	points, err := s.statsRepo.History(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.StatsHistory{Query: query, Points: points}, nil
+}
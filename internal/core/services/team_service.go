@@ -2,12 +2,16 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
 	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
 	repo "pr-reviewer-assignment/internal/core/ports/repositories"
 	"pr-reviewer-assignment/internal/core/ports/transactions"
+	"pr-reviewer-assignment/internal/logger"
 	"pr-reviewer-assignment/internal/validation"
 
 	"go.uber.org/zap"
@@ -16,11 +20,20 @@ import (
 type TeamService struct {
 	teamRepo  repo.TeamRepository
 	userRepo  repo.UserRepository
+	roleRepo  repo.TeamRoleRepository
 	logger    *zap.Logger
 	txManager transactions.Manager
+	authz     *Authorizer
 }
 
-func NewTeamService(teamRepo repo.TeamRepository, userRepo repo.UserRepository, logger *zap.Logger, txManager transactions.Manager) *TeamService {
+func NewTeamService(
+	teamRepo repo.TeamRepository,
+	userRepo repo.UserRepository,
+	roleRepo repo.TeamRoleRepository,
+	logger *zap.Logger,
+	txManager transactions.Manager,
+	authz *Authorizer,
+) *TeamService {
 	if txManager == nil {
 		panic("txManager is required")
 	}
@@ -28,15 +41,24 @@ func NewTeamService(teamRepo repo.TeamRepository, userRepo repo.UserRepository,
 	return &TeamService{
 		teamRepo:  teamRepo,
 		userRepo:  userRepo,
+		roleRepo:  roleRepo,
 		logger:    logger,
 		txManager: txManager,
+		authz:     authz,
 	}
 }
 
 func (s *TeamService) CreateTeam(ctx context.Context, name string, members []*entities.User) (*entities.Team, error) {
+	ctx, span := startSpan(ctx, "TeamService.CreateTeam")
+	defer span.End()
+
 	validatedName, err := validation.RequireString("team_name", name)
 	if err != nil {
-		s.logger.Warn("Invalid team name", zap.String("team_name", name), zap.Error(err))
+		s.log(ctx).Warn("Invalid team name", zap.String("team_name", name), zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.authz.requireTeamManager(ctx, validatedName); err != nil {
 		return nil, err
 	}
 
@@ -48,14 +70,18 @@ func (s *TeamService) CreateTeam(ctx context.Context, name string, members []*en
 	addedMembers := team.AddMembers(validMembers, now)
 
 	if err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.authorizeMemberMoves(txCtx, validatedName, addedMembers); err != nil {
+			return err
+		}
+
 		if err := s.teamRepo.Create(txCtx, team); err != nil {
-			s.logger.Error("Failed to create team", zap.String("team_name", validatedName), zap.Error(err))
+			s.log(ctx).Error("Failed to create team", zap.String("team_name", validatedName), zap.Error(err))
 			return err
 		}
 
 		if len(addedMembers) > 0 {
 			if err := s.userRepo.UpsertMany(txCtx, addedMembers); err != nil {
-				s.logger.Error("Failed to upsert team members", zap.String("team_name", validatedName), zap.Error(err))
+				s.log(ctx).Error("Failed to upsert team members", zap.String("team_name", validatedName), zap.Error(err))
 				return err
 			}
 		}
@@ -69,21 +95,151 @@ func (s *TeamService) CreateTeam(ctx context.Context, name string, members []*en
 }
 
 func (s *TeamService) GetTeam(ctx context.Context, name string) (*entities.Team, error) {
+	ctx, span := startSpan(ctx, "TeamService.GetTeam")
+	defer span.End()
+
 	validatedName, err := validation.RequireString("team_name", name)
 	if err != nil {
-		s.logger.Warn("Invalid team name", zap.String("team_name", name), zap.Error(err))
+		s.log(ctx).Warn("Invalid team name", zap.String("team_name", name), zap.Error(err))
 		return nil, err
 	}
 
 	team, err := s.teamRepo.Get(ctx, validatedName)
 	if err != nil {
-		s.logger.Error("Failed to get team", zap.String("team_name", validatedName), zap.Error(err))
+		s.log(ctx).Error("Failed to get team", zap.String("team_name", validatedName), zap.Error(err))
 		return nil, err
 	}
 
 	return team, nil
 }
 
+func (s *TeamService) SetMemberRole(ctx context.Context, teamName, userID string, role types.Role) (*entities.TeamRole, error) {
+	ctx, span := startSpan(ctx, "TeamService.SetMemberRole")
+	defer span.End()
+
+	validatedName, err := validation.RequireString("team_name", teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	validatedUserID, err := validation.RequireString("user_id", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role != types.RoleViewer && role != types.RoleTeamLead {
+		return nil, validation.FieldError{Field: "role", Reason: fmt.Errorf("must be %s or %s", types.RoleViewer, types.RoleTeamLead)}
+	}
+
+	if err := s.authz.requireTeamManager(ctx, validatedName); err != nil {
+		return nil, err
+	}
+
+	member, err := s.userRepo.GetByID(ctx, validatedUserID)
+	if err != nil {
+		s.log(ctx).Warn("Failed to load user for team role", zap.String("user_id", validatedUserID), zap.Error(err))
+		return nil, err
+	}
+
+	if member.TeamName != validatedName {
+		return nil, domainErrors.NotFound(fmt.Sprintf("user %s in team %s", validatedUserID, validatedName))
+	}
+
+	teamRole := &entities.TeamRole{
+		TeamName:  validatedName,
+		UserID:    validatedUserID,
+		Role:      role,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := s.roleRepo.Set(ctx, teamRole); err != nil {
+		s.log(ctx).Error("Failed to set team role", zap.String("team_name", validatedName), zap.String("user_id", validatedUserID), zap.Error(err))
+		return nil, err
+	}
+
+	s.log(ctx).Info("Team role set",
+		zap.String("team_name", validatedName),
+		zap.String("user_id", validatedUserID),
+		zap.String("role", role.String()))
+	return teamRole, nil
+}
+
+func (s *TeamService) RemoveMemberRole(ctx context.Context, teamName, userID string) error {
+	ctx, span := startSpan(ctx, "TeamService.RemoveMemberRole")
+	defer span.End()
+
+	validatedName, err := validation.RequireString("team_name", teamName)
+	if err != nil {
+		return err
+	}
+
+	validatedUserID, err := validation.RequireString("user_id", userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authz.requireTeamManager(ctx, validatedName); err != nil {
+		return err
+	}
+
+	if err := s.roleRepo.Remove(ctx, validatedName, validatedUserID); err != nil {
+		s.log(ctx).Warn("Failed to remove team role", zap.String("team_name", validatedName), zap.String("user_id", validatedUserID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *TeamService) ListMemberRoles(ctx context.Context, teamName string) ([]*entities.TeamRole, error) {
+	ctx, span := startSpan(ctx, "TeamService.ListMemberRoles")
+	defer span.End()
+
+	validatedName, err := validation.RequireString("team_name", teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.teamRepo.Get(ctx, validatedName); err != nil {
+		return nil, err
+	}
+
+	return s.roleRepo.ListByTeam(ctx, validatedName)
+}
+
+// authorizeMemberMoves makes sure that pulling an existing user into the team
+// is also allowed for the team the user is leaving.
+func (s *TeamService) authorizeMemberMoves(ctx context.Context, teamName string, members []*entities.User) error {
+	if s.authz.principal(ctx) == nil {
+		return nil
+	}
+
+	checked := make(map[string]struct{})
+	for _, member := range members {
+		existing, err := s.userRepo.GetByID(ctx, member.ID)
+		if err != nil {
+			if isDomainError(err, domainErrors.ErrorCodeNotFound) {
+				continue
+			}
+			return err
+		}
+
+		if existing.TeamName == teamName {
+			continue
+		}
+
+		if _, done := checked[existing.TeamName]; done {
+			continue
+		}
+		checked[existing.TeamName] = struct{}{}
+
+		if err := s.authz.requireTeamManager(ctx, existing.TeamName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *TeamService) validateMembers(members []*entities.User) []*entities.User {
 	if len(members) == 0 {
 		return nil
@@ -119,3 +275,7 @@ func (s *TeamService) validateMembers(members []*entities.User) []*entities.User
 
 	return valid
 }
+
+func (s *TeamService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger)
+}
@@ -2,40 +2,158 @@ package services
 
 import (
 	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
 
 	"pr-reviewer-assignment/internal/core/domain/entities"
 	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	"pr-reviewer-assignment/internal/core/ports/transactions"
+	"pr-reviewer-assignment/internal/logger"
 	"pr-reviewer-assignment/internal/validation"
 
 	"go.uber.org/zap"
 )
 
 type UserService struct {
-	userRepo repo.UserRepository
-	prRepo   repo.PullRequestRepository
-	logger   *zap.Logger
+	userRepo  repo.UserRepository
+	prRepo    repo.PullRequestRepository
+	outbox    repo.OutboxRepository
+	logger    *zap.Logger
+	txManager transactions.Manager
+	authz     *Authorizer
 }
 
-func NewUserService(userRepo repo.UserRepository, prRepo repo.PullRequestRepository, logger *zap.Logger) *UserService {
+func NewUserService(
+	userRepo repo.UserRepository,
+	prRepo repo.PullRequestRepository,
+	outbox repo.OutboxRepository,
+	logger *zap.Logger,
+	txManager transactions.Manager,
+	authz *Authorizer,
+) *UserService {
+	if txManager == nil {
+		txManager = transactions.NoopManager{}
+	}
 
 	return &UserService{
-		userRepo: userRepo,
-		prRepo:   prRepo,
-		logger:   logger,
+		userRepo:  userRepo,
+		prRepo:    prRepo,
+		outbox:    outbox,
+		logger:    logger,
+		txManager: txManager,
+		authz:     authz,
 	}
 }
 
 func (s *UserService) SetActivity(ctx context.Context, userID string, isActive bool) (*entities.User, error) {
+	ctx, span := startSpan(ctx, "UserService.SetActivity")
+	defer span.End()
+
 	validatedID, err := validation.RequireString("user_id", userID)
 	if err != nil {
-		s.logger.Error("Invalid user id", zap.String("user_id", userID), zap.Error(err))
+		s.log(ctx).Error("Invalid user id", zap.String("user_id", userID), zap.Error(err))
 		return nil, err
 	}
 	userID = validatedID
 
-	user, err := s.userRepo.SetActivity(ctx, userID, isActive)
+	var user *entities.User
+
+	if err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		target, err := s.userRepo.GetByID(txCtx, userID)
+		if err != nil {
+			s.log(ctx).Warn("Failed to load user", zap.String("user_id", userID), zap.Error(err))
+			return err
+		}
+
+		if s.authz.principal(ctx) != nil {
+			if err := s.authz.requireTeamManager(txCtx, target.TeamName); err != nil {
+				return err
+			}
+		}
+
+		user, err = s.userRepo.SetActivity(txCtx, userID, isActive)
+		if err != nil {
+			s.log(ctx).Error("Failed to set user activity", zap.String("user_id", userID), zap.Error(err))
+			return err
+		}
+
+		var event *entities.Event
+		switch {
+		case target.IsActive && !user.IsActive:
+			event = entities.NewUserDeactivated(user, time.Now().UTC())
+		case !target.IsActive && user.IsActive:
+			event = entities.NewUserActivated(user, time.Now().UTC())
+		}
+
+		if event != nil {
+			if err := recordEvents(txCtx, s.outbox, event); err != nil {
+				s.log(ctx).Error("Failed to record events", zap.Error(err))
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *UserService) SetPreferences(ctx context.Context, userID string, prefs entities.UserPreferences) (*entities.User, error) {
+	ctx, span := startSpan(ctx, "UserService.SetPreferences")
+	defer span.End()
+
+	validatedID, err := validation.RequireString("user_id", userID)
 	if err != nil {
-		s.logger.Error("Failed to set user activity", zap.String("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	if prefs.IsEmpty() {
+		return nil, validation.FieldError{Field: "preferences", Reason: errors.New("nothing to change")}
+	}
+
+	if prefs.Email != nil {
+		email := strings.TrimSpace(*prefs.Email)
+		if email != "" {
+			if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+				return nil, validation.FieldError{Field: "email", Reason: errors.New("must be a plain email address")}
+			}
+		}
+		prefs.Email = &email
+	}
+
+	if prefs.Timezone != nil {
+		timezone, err := validation.RequireString("timezone", *prefs.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+			return nil, validation.FieldError{Field: "timezone", Reason: errors.New("must be an IANA timezone such as Europe/Berlin")}
+		}
+		prefs.Timezone = &timezone
+	}
+
+	var user *entities.User
+
+	if err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		target, err := s.userRepo.GetByID(txCtx, validatedID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.authz.requireSelfOrTeamManager(txCtx, target); err != nil {
+			return err
+		}
+
+		target.ApplyPreferences(prefs, time.Now().UTC())
+
+		user, err = s.userRepo.UpdatePreferences(txCtx, target)
+		return err
+	}); err != nil {
+		s.log(ctx).Warn("Failed to set user preferences", zap.String("user_id", validatedID), zap.Error(err))
 		return nil, err
 	}
 
@@ -43,23 +161,30 @@ func (s *UserService) SetActivity(ctx context.Context, userID string, isActive b
 }
 
 func (s *UserService) GetReviewerAssignments(ctx context.Context, userID string) ([]*entities.PullRequest, error) {
+	ctx, span := startSpan(ctx, "UserService.GetReviewerAssignments")
+	defer span.End()
+
 	validatedID, err := validation.RequireString("user_id", userID)
 	if err != nil {
-		s.logger.Error("Invalid user id", zap.String("user_id", userID), zap.Error(err))
+		s.log(ctx).Error("Invalid user id", zap.String("user_id", userID), zap.Error(err))
 		return nil, err
 	}
 	userID = validatedID
 
 	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
-		s.logger.Error("Failed to load user", zap.String("user_id", userID), zap.Error(err))
+		s.log(ctx).Error("Failed to load user", zap.String("user_id", userID), zap.Error(err))
 		return nil, err
 	}
 
 	prs, err := s.prRepo.ListByReviewer(ctx, userID)
 	if err != nil {
-		s.logger.Error("Failed to list reviewer assignments", zap.String("user_id", userID), zap.Error(err))
+		s.log(ctx).Error("Failed to list reviewer assignments", zap.String("user_id", userID), zap.Error(err))
 		return nil, err
 	}
 
 	return prs, nil
 }
+
+func (s *UserService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger)
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/core/mappers"
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	"pr-reviewer-assignment/internal/logger"
+	"pr-reviewer-assignment/internal/validation"
+
+	"go.uber.org/zap"
+)
+
+const (
+	webhookIDBytes     = 8
+	webhookSecretBytes = 32
+
+	// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of the
+	// request body keyed with the subscription secret.
+	SignatureHeader = "X-Signature"
+
+	maxDeliveryErrorLength = 512
+)
+
+type WebhookService struct {
+	repo   repo.WebhookRepository
+	client eventports.WebhookClient
+	logger *zap.Logger
+	policy DeliveryPolicy
+}
+
+func NewWebhookService(webhookRepo repo.WebhookRepository, client eventports.WebhookClient, logger *zap.Logger, policy DeliveryPolicy) *WebhookService {
+	return &WebhookService{
+		repo:   webhookRepo,
+		client: client,
+		logger: logger,
+		policy: policy.withDefaults(),
+	}
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, rawURL, secret string, eventTypes []types.EventType, teamName string) (*entities.WebhookSubscription, error) {
+	ctx, span := startSpan(ctx, "WebhookService.CreateSubscription")
+	defer span.End()
+
+	validatedURL, err := requireHTTPURL("url", rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret == "" {
+		if secret, err = randomToken(webhookSecretBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	id, err := randomHex(webhookIDBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &entities.WebhookSubscription{
+		ID:         id,
+		URL:        validatedURL,
+		Secret:     secret,
+		EventTypes: dedupeEventTypes(eventTypes),
+		TeamName:   teamName,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	if err := s.repo.CreateSubscription(ctx, sub); err != nil {
+		s.log(ctx).Error("Failed to create webhook", zap.String("url", validatedURL), zap.Error(err))
+		return nil, err
+	}
+
+	s.log(ctx).Info("Webhook created", zap.String("webhook_id", sub.ID), zap.String("url", sub.URL))
+	return sub, nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	ctx, span := startSpan(ctx, "WebhookService.ListSubscriptions")
+	defer span.End()
+
+	return s.repo.ListSubscriptions(ctx)
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "WebhookService.DeleteSubscription")
+	defer span.End()
+
+	validatedID, err := validation.RequireString("webhook_id", id)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteSubscription(ctx, validatedID)
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, filter repo.WebhookDeliveryFilter) ([]*entities.WebhookDelivery, error) {
+	ctx, span := startSpan(ctx, "WebhookService.ListDeliveries")
+	defer span.End()
+
+	return s.repo.ListDeliveries(ctx, filter)
+}
+
+// Redeliver queues a delivery again with a fresh set of attempts, typically
+// one that was dead-lettered while the receiver was down.
+func (s *WebhookService) Redeliver(ctx context.Context, deliveryID int64) (*entities.WebhookDelivery, error) {
+	ctx, span := startSpan(ctx, "WebhookService.Redeliver")
+	defer span.End()
+
+	delivery, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Requeue(time.Now().UTC())
+
+	if err := s.repo.UpdateDelivery(ctx, delivery); err != nil {
+		s.log(ctx).Error("Failed to requeue webhook delivery", zap.Int64("delivery_id", deliveryID), zap.Error(err))
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// Publish implements events.Publisher by queueing a delivery for every
+// matching subscription. It runs in the outbox relay's transaction.
+func (s *WebhookService) Publish(ctx context.Context, event *entities.Event) error {
+	subs, err := s.repo.ListSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	deliveries := make([]*entities.WebhookDelivery, 0, len(subs))
+	now := time.Now().UTC()
+
+	for _, sub := range subs {
+		if !sub.Matches(event) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = json.Marshal(mappers.EventToDTO(event)); err != nil {
+				return fmt.Errorf("encode event %d: %w", event.ID, err)
+			}
+		}
+
+		deliveries = append(deliveries, &entities.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+			Status:         types.DeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return s.repo.CreateDeliveries(ctx, deliveries)
+}
+
+// DeliverDue sends deliveries whose next attempt is due and returns how many
+// succeeded.
+func (s *WebhookService) DeliverDue(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "WebhookService.DeliverDue")
+	defer span.End()
+
+	now := time.Now().UTC()
+	deliveries, err := s.repo.ClaimDue(ctx, now, now.Add(s.policy.Lease), s.policy.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	subs := make(map[string]*entities.WebhookSubscription)
+	delivered := 0
+
+	for _, delivery := range deliveries {
+		sub, ok := subs[delivery.SubscriptionID]
+		if !ok {
+			if sub, err = s.repo.GetSubscription(ctx, delivery.SubscriptionID); err != nil {
+				s.log(ctx).Warn("Skipping delivery of unknown webhook",
+					zap.Int64("delivery_id", delivery.ID),
+					zap.Error(err))
+				continue
+			}
+			subs[sub.ID] = sub
+		}
+
+		if s.attempt(ctx, sub, delivery) {
+			delivered++
+		}
+
+		if err := s.repo.UpdateDelivery(ctx, delivery); err != nil {
+			return delivered, err
+		}
+	}
+
+	return delivered, nil
+}
+
+func (s *WebhookService) attempt(ctx context.Context, sub *entities.WebhookSubscription, delivery *entities.WebhookDelivery) bool {
+	statusCode, err := s.client.Send(ctx, eventports.WebhookRequest{
+		URL: sub.URL,
+		Headers: map[string]string{
+			SignatureHeader:      Sign(sub.Secret, delivery.Payload),
+			"X-Webhook-Event":    delivery.EventType.String(),
+			"X-Webhook-Delivery": strconv.FormatInt(delivery.ID, 10),
+		},
+		Body: delivery.Payload,
+	})
+
+	now := time.Now().UTC()
+	if err == nil && statusCode >= 200 && statusCode < 300 {
+		delivery.MarkDelivered(statusCode, now)
+		return true
+	}
+
+	reason := fmt.Sprintf("receiver responded with status %d", statusCode)
+	if err != nil {
+		reason = truncate(err.Error(), maxDeliveryErrorLength)
+	}
+
+	delivery.MarkFailed(statusCode, reason, s.policy.MaxAttempts, now.Add(s.policy.backoff(delivery.Attempts+1)))
+
+	log := s.log(ctx).With(
+		zap.Int64("delivery_id", delivery.ID),
+		zap.String("webhook_id", sub.ID),
+		zap.Int("attempts", delivery.Attempts),
+		zap.String("reason", reason))
+	if delivery.Status == types.DeliveryDead {
+		log.Warn("Webhook delivery dead-lettered")
+	} else {
+		log.Info("Webhook delivery failed, will retry", zap.Time("next_attempt_at", delivery.NextAttemptAt))
+	}
+
+	return false
+}
+
+// Sign returns the X-Signature value for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// requireHTTPURL accepts absolute http and https URLs only.
+func requireHTTPURL(field, rawURL string) (string, error) {
+	validatedURL, err := validation.RequireString(field, rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	parsed, err := url.Parse(validatedURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", validation.FieldError{Field: field, Reason: fmt.Errorf("must be an absolute http or https URL")}
+	}
+
+	return validatedURL, nil
+}
+
+func dedupeEventTypes(eventTypes []types.EventType) []types.EventType {
+	seen := make(map[types.EventType]struct{}, len(eventTypes))
+	result := make([]types.EventType, 0, len(eventTypes))
+
+	for _, eventType := range eventTypes {
+		if _, ok := seen[eventType]; ok {
+			continue
+		}
+		seen[eventType] = struct{}{}
+		result = append(result, eventType)
+	}
+
+	return result
+}
+
+func truncate(value string, limit int) string {
+	if len(value) <= limit {
+		return value
+	}
+
+	return value[:limit]
+}
+
+func (s *WebhookService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger)
+}
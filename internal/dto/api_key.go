@@ -0,0 +1,24 @@
+package dto
+
+type APIKeyDTO struct {
+	KeyID      string   `json:"key_id"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	Teams      []string `json:"teams,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+	LastUsedAt *string  `json:"last_used_at,omitempty"`
+	RevokedAt  *string  `json:"revoked_at,omitempty"`
+}
+
+type CreateAPIKeyResponse struct {
+	APIKey APIKeyDTO `json:"api_key"`
+	Token  string    `json:"token"`
+}
+
+type ListAPIKeysResponse struct {
+	APIKeys []APIKeyDTO `json:"api_keys"`
+}
+
+type APIKeyResponse struct {
+	APIKey APIKeyDTO `json:"api_key"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+type ReviewSLADTO struct {
+	TeamName      string `json:"team_name"`
+	RemindAfter   string `json:"remind_after"`
+	EscalateAfter string `json:"escalate_after,omitempty"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+type ReviewSLAResponse struct {
+	SLA ReviewSLADTO `json:"sla"`
+}
+
+type ListReviewSLAsResponse struct {
+	SLAs []ReviewSLADTO `json:"slas"`
+}
+
+type ReviewEscalationDTO struct {
+	EscalationID  int64  `json:"escalation_id"`
+	PullRequestID string `json:"pull_request_id"`
+	TeamName      string `json:"team_name"`
+	ReviewerID    string `json:"reviewer_id"`
+	AssignedAt    string `json:"assigned_at"`
+	Action        string `json:"action"`
+	ReplacedBy    string `json:"replaced_by,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type ListReviewEscalationsResponse struct {
+	Escalations []ReviewEscalationDTO `json:"escalations"`
+}
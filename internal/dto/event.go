@@ -0,0 +1,12 @@
+package dto
+
+type EventDTO struct {
+	EventID       int64           `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	OccurredAt    string          `json:"occurred_at"`
+	TeamName      string          `json:"team_name,omitempty"`
+	PullRequest   *PullRequestDTO `json:"pull_request,omitempty"`
+	ReviewerID    string          `json:"reviewer_id,omitempty"`
+	OldReviewerID string          `json:"old_reviewer_id,omitempty"`
+	UserID        string          `json:"user_id,omitempty"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+type ExternalAccountDTO struct {
+	Provider  string `json:"provider"`
+	Login     string `json:"login"`
+	UserID    string `json:"user_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ExternalAccountResponse struct {
+	Account ExternalAccountDTO `json:"account"`
+}
+
+type ListExternalAccountsResponse struct {
+	Accounts []ExternalAccountDTO `json:"accounts"`
+}
+
+// ReviewerAccountDTO pairs an assigned reviewer with their login on the code
+// host that sent the event; login is empty when the reviewer is not linked.
+type ReviewerAccountDTO struct {
+	UserID string `json:"user_id"`
+	Login  string `json:"login,omitempty"`
+}
+
+// SyncResponse answers a code host webhook; pr is absent when the event was
+// ignored before a pull request was found.
+type SyncResponse struct {
+	Result    string               `json:"result"`
+	Reason    string               `json:"reason,omitempty"`
+	PR        *PullRequestDTO      `json:"pr,omitempty"`
+	Reviewers []ReviewerAccountDTO `json:"reviewers,omitempty"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+type ChatChannelDTO struct {
+	TeamName   string `json:"team_name"`
+	WebhookURL string `json:"webhook_url"`
+	Channel    string `json:"channel,omitempty"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+type ChatChannelResponse struct {
+	Channel ChatChannelDTO `json:"channel"`
+}
+
+type ListChatChannelsResponse struct {
+	Channels []ChatChannelDTO `json:"channels"`
+}
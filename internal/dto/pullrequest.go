@@ -8,6 +8,7 @@ type PullRequestDTO struct {
 	AssignedReviewers []string `json:"assigned_reviewers"`
 	CreatedAt         string   `json:"createdAt,omitempty"`
 	MergedAt          *string  `json:"mergedAt,omitempty"`
+	Version           int      `json:"version"`
 }
 
 type PullRequestShortDTO struct {
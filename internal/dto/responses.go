@@ -5,8 +5,9 @@ type ErrorResponse struct {
 }
 
 type ErrorBody struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 type ListPullRequestsResponse struct {
@@ -24,3 +25,42 @@ type StatsDTO struct {
 type StatsResponse struct {
 	Stats StatsDTO `json:"stats"`
 }
+
+type StatsPointDTO struct {
+	Bucket string `json:"bucket"`
+	Last   int    `json:"last"`
+	Min    int    `json:"min"`
+	Max    int    `json:"max"`
+}
+
+type StatsHistoryResponse struct {
+	Metric   string          `json:"metric"`
+	TeamName string          `json:"team_name,omitempty"`
+	Bucket   string          `json:"bucket"`
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Points   []StatsPointDTO `json:"points"`
+}
+
+type MemberShareDTO struct {
+	UserID      string  `json:"user_id"`
+	Username    string  `json:"username"`
+	Assignments int     `json:"assignments"`
+	Share       float64 `json:"share"`
+	Deviation   float64 `json:"deviation"`
+}
+
+type FairnessDTO struct {
+	TeamName         string           `json:"team_name"`
+	From             *string          `json:"from,omitempty"`
+	To               *string          `json:"to,omitempty"`
+	TotalAssignments int              `json:"total_assignments"`
+	IdealShare       float64          `json:"ideal_share"`
+	Gini             float64          `json:"gini"`
+	Members          []MemberShareDTO `json:"members"`
+	LargestDeviation []MemberShareDTO `json:"largest_deviation"`
+}
+
+type FairnessResponse struct {
+	Fairness FairnessDTO `json:"fairness"`
+}
@@ -10,3 +10,14 @@ type TeamDTO struct {
 	TeamName string          `json:"team_name"`
 	Members  []TeamMemberDTO `json:"members"`
 }
+
+type TeamRoleDTO struct {
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+	Role     string `json:"role"`
+}
+
+type TeamRolesResponse struct {
+	TeamName string        `json:"team_name"`
+	Roles    []TeamRoleDTO `json:"roles"`
+}
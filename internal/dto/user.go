@@ -1,8 +1,11 @@
 package dto
 
 type UserDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID        string `json:"user_id"`
+	Username      string `json:"username"`
+	TeamName      string `json:"team_name"`
+	IsActive      bool   `json:"is_active"`
+	Email         string `json:"email,omitempty"`
+	Timezone      string `json:"timezone,omitempty"`
+	DigestEnabled bool   `json:"digest_enabled"`
 }
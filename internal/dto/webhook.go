@@ -0,0 +1,40 @@
+package dto
+
+type WebhookDTO struct {
+	WebhookID string   `json:"webhook_id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	TeamName  string   `json:"team_name,omitempty"`
+	CreatedAt string   `json:"created_at"`
+}
+
+type CreateWebhookResponse struct {
+	Webhook WebhookDTO `json:"webhook"`
+	Secret  string     `json:"secret"`
+}
+
+type ListWebhooksResponse struct {
+	Webhooks []WebhookDTO `json:"webhooks"`
+}
+
+type WebhookDeliveryDTO struct {
+	DeliveryID     int64   `json:"delivery_id"`
+	WebhookID      string  `json:"webhook_id"`
+	EventID        int64   `json:"event_id"`
+	EventType      string  `json:"event_type"`
+	Status         string  `json:"status"`
+	Attempts       int     `json:"attempts"`
+	NextAttemptAt  *string `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int    `json:"last_status_code,omitempty"`
+	LastError      string  `json:"last_error,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+	DeliveredAt    *string `json:"delivered_at,omitempty"`
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryDTO `json:"deliveries"`
+}
+
+type WebhookDeliveryResponse struct {
+	Delivery WebhookDeliveryDTO `json:"delivery"`
+}
@@ -0,0 +1,108 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+type Result struct {
+	Status   string         `json:"status"`
+	Error    string         `json:"error,omitempty"`
+	Duration string         `json:"duration"`
+	Details  map[string]any `json:"details,omitempty"`
+}
+
+type Check interface {
+	Name() string
+	Check(ctx context.Context) (map[string]any, error)
+}
+
+type Report struct {
+	Ready  bool
+	Checks map[string]Result
+}
+
+// Checker runs readiness checks concurrently, each bounded by timeout, and
+// reports not ready once shutdown has begun.
+type Checker struct {
+	checks       []Check
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+func (c *Checker) BeginShutdown() {
+	c.shuttingDown.Store(true)
+}
+
+func (c *Checker) Run(ctx context.Context) Report {
+	report := Report{
+		Ready:  true,
+		Checks: make(map[string]Result, len(c.checks)+1),
+	}
+
+	if c.shuttingDown.Load() {
+		report.Ready = false
+		report.Checks["shutdown"] = Result{Status: StatusFail, Error: "server is shutting down"}
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result := c.runCheck(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name()] = result
+			if result.Status != StatusOK {
+				report.Ready = false
+			}
+		}()
+	}
+
+	wg.Wait()
+	return report
+}
+
+func (c *Checker) runCheck(ctx context.Context, check Check) Result {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	details, err := check.Check(ctx)
+
+	result := Result{
+		Status:   StatusOK,
+		Duration: time.Since(start).String(),
+		Details:  details,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -1,25 +1,51 @@
 package infrastructure
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 
+	adaptergraphql "pr-reviewer-assignment/internal/adapters/input/graphql"
+	adaptergrpc "pr-reviewer-assignment/internal/adapters/input/grpc"
 	adapterhttp "pr-reviewer-assignment/internal/adapters/input/http"
+	"pr-reviewer-assignment/internal/adapters/output/codehost"
 	adapterdb "pr-reviewer-assignment/internal/adapters/output/database"
+	"pr-reviewer-assignment/internal/adapters/output/email"
+	"pr-reviewer-assignment/internal/adapters/output/events"
+	"pr-reviewer-assignment/internal/adapters/output/webhook"
+	"pr-reviewer-assignment/internal/clock"
 	"pr-reviewer-assignment/internal/config"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
 	"pr-reviewer-assignment/internal/core/services"
+	"pr-reviewer-assignment/internal/health"
 	"pr-reviewer-assignment/internal/infrastructure/database/postgres"
+	"pr-reviewer-assignment/internal/jwtauth"
+	"pr-reviewer-assignment/internal/metrics"
+	"pr-reviewer-assignment/internal/middleware"
+	"pr-reviewer-assignment/internal/ratelimit"
+	"pr-reviewer-assignment/internal/workers"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
 type App struct {
 	cfg        *config.Config
 	logger     *zap.Logger
 	httpServer *http.Server
+	grpcServer *grpc.Server
 	dbPool     *pgxpool.Pool
+	readiness  *health.Checker
+	workers    []func(ctx context.Context)
+
+	stopWorkers context.CancelFunc
+	workersWG   sync.WaitGroup
 }
 
 func NewApp(cfg *config.Config, logger *zap.Logger) (*App, error) {
@@ -34,44 +60,347 @@ func NewApp(cfg *config.Config, logger *zap.Logger) (*App, error) {
 		return nil, fmt.Errorf("failed to connect database: %w", err)
 	}
 
-	txManager := postgres.NewTransactionManager(dbPool, logger)
+	appMetrics := metrics.New()
+	appMetrics.RegisterPool(dbPool)
+
+	txManager := postgres.NewTransactionManager(dbPool, logger, appMetrics, postgres.RetryPolicy{
+		MaxRetries: cfg.Database.TxMaxRetries,
+		BaseDelay:  cfg.Database.TxRetryBaseDelay,
+		MaxDelay:   cfg.Database.TxRetryMaxDelay,
+	})
 
 	teamRepo := adapterdb.NewTeamRepository(dbPool, logger)
 	userRepo := adapterdb.NewUserRepository(dbPool, logger)
 	prRepo := adapterdb.NewPullRequestRepository(dbPool, logger)
+	statsRepo := adapterdb.NewStatsRepository(dbPool, logger)
+	teamRoleRepo := adapterdb.NewTeamRoleRepository(dbPool, logger)
+	idempotencyRepo := adapterdb.NewIdempotencyRepository(dbPool, logger)
+	apiKeyRepo := adapterdb.NewAPIKeyRepository(dbPool, logger)
+	outboxRepo := adapterdb.NewOutboxRepository(dbPool, logger)
+	webhookRepo := adapterdb.NewWebhookRepository(dbPool, logger)
+	externalAccountRepo := adapterdb.NewExternalAccountRepository(dbPool, logger)
+	codeHostSyncRepo := adapterdb.NewCodeHostSyncRepository(dbPool, logger)
+	notificationRepo := adapterdb.NewNotificationRepository(dbPool, logger)
+	escalationRepo := adapterdb.NewEscalationRepository(dbPool, logger)
+	digestRepo := adapterdb.NewDigestRepository(dbPool, logger)
+
+	authz := services.NewAuthorizer(teamRoleRepo)
+
+	teamService := services.NewTeamService(teamRepo, userRepo, teamRoleRepo, logger, txManager, authz)
+	userService := services.NewUserService(userRepo, prRepo, outboxRepo, logger, txManager, authz)
+	prService := services.NewPullRequestService(prRepo, userRepo, teamRepo, outboxRepo, logger, txManager, nil, appMetrics, authz)
+	statsService := services.NewStatsService(teamRepo, userRepo, prRepo, statsRepo, txManager)
+	dashboardService := services.NewDashboardService(teamRepo, userRepo, prRepo)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, logger, cfg.Auth.BootstrapKey)
+	integrationService := services.NewIntegrationService(externalAccountRepo, prRepo, prService, logger)
+	webhookService := services.NewWebhookService(webhookRepo, webhook.NewHTTPClient(cfg.Webhooks.Timeout), logger, services.DeliveryPolicy{
+		MaxAttempts: cfg.Webhooks.MaxAttempts,
+		BaseBackoff: cfg.Webhooks.BaseBackoff,
+		MaxBackoff:  cfg.Webhooks.MaxBackoff,
+		Lease:       batchLease(cfg.Webhooks.BatchSize, 1, cfg.Webhooks.Timeout),
+		BatchSize:   cfg.Webhooks.BatchSize,
+	})
+
+	codeHostClients := map[types.Provider]eventports.CodeHostClient{}
+	if cfg.GitHub.APIToken != "" {
+		codeHostClients[types.ProviderGitHub] = codehost.NewGitHubClient(cfg.GitHub.APIURL, cfg.GitHub.APIToken, cfg.CodeHostSync.Timeout)
+	}
+	codeHostSyncService := services.NewCodeHostSyncService(codeHostSyncRepo, prRepo, externalAccountRepo, codeHostClients, logger, services.DeliveryPolicy{
+		MaxAttempts: cfg.CodeHostSync.MaxAttempts,
+		BaseBackoff: cfg.CodeHostSync.BaseBackoff,
+		MaxBackoff:  cfg.CodeHostSync.MaxBackoff,
+		Lease:       batchLease(cfg.CodeHostSync.BatchSize, 2, cfg.CodeHostSync.Timeout),
+		BatchSize:   cfg.CodeHostSync.BatchSize,
+	})
+
+	notificationService, err := services.NewNotificationService(notificationRepo, userRepo, webhook.NewHTTPClient(cfg.Notifier.Timeout), logger, authz,
+		services.DeliveryPolicy{
+			MaxAttempts: cfg.Notifier.MaxAttempts,
+			BaseBackoff: cfg.Notifier.BaseBackoff,
+			MaxBackoff:  cfg.Notifier.MaxBackoff,
+			Lease:       batchLease(cfg.Notifier.BatchSize, 1, cfg.Notifier.Timeout),
+			BatchSize:   cfg.Notifier.BatchSize,
+		},
+		services.NotificationSettings{
+			AssignedTemplate: cfg.Notifier.AssignedTemplate,
+			ReplacedTemplate: cfg.Notifier.ReplacedTemplate,
+			ReminderTemplate: cfg.Notifier.ReminderTemplate,
+			DigestTemplate:   cfg.Notifier.DigestTemplate,
+			PullRequestURL:   cfg.Notifier.PullRequestURL,
+			CodeHostURLs: map[types.Provider]string{
+				types.ProviderGitHub: cfg.GitHub.WebURL,
+				types.ProviderGitLab: cfg.GitLab.WebURL,
+			},
+			BatchWindow: cfg.Notifier.BatchWindow,
+		})
+	if err != nil {
+		dbPool.Close()
+		return nil, fmt.Errorf("failed to configure notifications: %w", err)
+	}
+
+	escalationService := services.NewEscalationService(escalationRepo, prService, notificationService, txManager, clock.System(), logger, authz, cfg.Escalation.BatchSize)
+
+	digestSenders := []eventports.DigestSender{notificationService}
+	if cfg.SMTP.Host != "" {
+		digestSenders = append(digestSenders, email.NewSMTPSender(email.SMTPSettings{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+			Timeout:  cfg.SMTP.Timeout,
+		}))
+	}
+	digestService := services.NewDigestService(digestRepo, digestSenders, logger, services.DigestSettings{
+		Hour:           cfg.Digest.Hour,
+		PullRequestURL: cfg.Notifier.PullRequestURL,
+		CodeHostURLs: map[types.Provider]string{
+			types.ProviderGitHub: cfg.GitHub.WebURL,
+			types.ProviderGitLab: cfg.GitLab.WebURL,
+		},
+	})
+
+	publisher := events.Fanout{events.NewLogPublisher(logger), webhookService, codeHostSyncService, notificationService}
+	outboxRelay := services.NewOutboxRelay(outboxRepo, publisher, txManager, logger, cfg.Outbox.BatchSize)
+	eventStream := services.NewEventStreamService(outboxRepo, teamRepo, userRepo, logger, cfg.EventStream.Buffer)
 
-	teamService := services.NewTeamService(teamRepo, userRepo, logger, txManager)
-	userService := services.NewUserService(userRepo, prRepo, logger)
-	prService := services.NewPullRequestService(prRepo, userRepo, teamRepo, logger, txManager)
-	statsService := services.NewStatsService(teamRepo, userRepo, prRepo)
+	readiness := health.NewChecker(cfg.Health.CheckTimeout,
+		postgres.NewPingCheck(dbPool),
+		postgres.NewMigrationCheck(dbPool, postgres.SchemaVersion),
+		postgres.NewPoolSaturationCheck(dbPool, cfg.Health.PoolSaturationThreshold),
+	)
 
-	healthHandler := adapterhttp.NewHealthHandler()
+	healthHandler := adapterhttp.NewHealthHandler(readiness)
 	teamHandler := adapterhttp.NewTeamHandler(teamService, logger)
 	userHandler := adapterhttp.NewUserHandler(userService, logger)
 	prHandler := adapterhttp.NewPullRequestHandler(prService, logger)
 	statsHandler := adapterhttp.NewStatsHandler(statsService, logger)
+	apiKeyHandler := adapterhttp.NewAPIKeyHandler(apiKeyService, logger)
+	webhookHandler := adapterhttp.NewWebhookHandler(webhookService, logger)
+	integrationHandler := adapterhttp.NewIntegrationHandler(integrationService, logger)
+	notificationHandler := adapterhttp.NewNotificationHandler(notificationService, logger)
+	escalationHandler := adapterhttp.NewEscalationHandler(escalationService, logger)
+	eventStreamHandler := adapterhttp.NewEventStreamHandler(eventStream, cfg.EventStream.Heartbeat, logger)
 
-	router := NewRouter(logger, healthHandler, teamHandler, userHandler, prHandler, statsHandler)
+	graphqlHandler, err := adaptergraphql.NewHandler(dashboardService, statsService, adaptergraphql.Limits{
+		MaxDepth: cfg.GraphQL.MaxDepth,
+		MaxCost:  cfg.GraphQL.MaxCost,
+	}, logger)
+	if err != nil {
+		dbPool.Close()
+		return nil, fmt.Errorf("failed to configure graphql: %w", err)
+	}
+
+	var githubHandler *adapterhttp.GitHubWebhookHandler
+	if cfg.GitHub.WebhookSecret != "" {
+		githubHandler = adapterhttp.NewGitHubWebhookHandler(integrationService, cfg.GitHub.WebhookSecret, logger)
+	}
+
+	var gitlabHandler *adapterhttp.GitLabWebhookHandler
+	if cfg.GitLab.WebhookToken != "" {
+		gitlabHandler = adapterhttp.NewGitLabWebhookHandler(integrationService, cfg.GitLab.WebhookToken, logger)
+	}
+
+	var tokenVerifier adapterhttp.TokenVerifier
+	if cfg.Auth.JWT.Enabled() {
+		verifier, err := jwtauth.NewVerifier(context.Background(), cfg.Auth.JWT, nil, logger)
+		if err != nil {
+			dbPool.Close()
+			return nil, fmt.Errorf("failed to configure jwt auth: %w", err)
+		}
+		tokenVerifier = verifier
+	}
+	authenticator := adapterhttp.NewAuthenticator(apiKeyService, tokenVerifier, cfg.Auth.Enabled, logger)
+
+	var (
+		limiter     *middleware.RateLimiter
+		grpcLimiter *adaptergrpc.RateLimiter
+	)
+	if cfg.RateLimit.Enabled {
+		rules, err := ratelimit.ParseRules(cfg.RateLimit.Default, cfg.RateLimit.Routes)
+		if err != nil {
+			dbPool.Close()
+			return nil, fmt.Errorf("failed to configure rate limits: %w", err)
+		}
+		if strings.TrimSpace(cfg.RateLimit.PerIP) != "" {
+			perIP, err := ratelimit.ParseLimit(cfg.RateLimit.PerIP)
+			if err != nil {
+				dbPool.Close()
+				return nil, fmt.Errorf("failed to configure per-ip rate limit: %w", err)
+			}
+			rules.PerIP = &perIP
+		}
+		store := ratelimit.NewMemoryStore(nil)
+		limiter = middleware.NewRateLimiter(store, rules, logger)
+		grpcLimiter = adaptergrpc.NewRateLimiter(store, rules, logger)
+	}
+
+	idempotency := middleware.NewIdempotency(idempotencyRepo, cfg.Idempotency.TTL, cfg.Idempotency.LockTimeout, logger)
+
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Port != "" {
+		grpcServer = adaptergrpc.NewServer(adaptergrpc.ServerDeps{
+			Logger:      logger,
+			Auth:        adaptergrpc.NewAuthenticator(apiKeyService, tokenVerifier, cfg.Auth.Enabled, logger),
+			Limiter:     grpcLimiter,
+			Team:        teamService,
+			User:        userService,
+			PullRequest: prService,
+			Stats:       statsService,
+		})
+	}
+
+	router := NewRouter(RouterDeps{
+		Logger:      logger,
+		Metrics:     appMetrics,
+		Auth:        authenticator,
+		Limiter:     limiter,
+		Idempotency: idempotency,
+		Health:      healthHandler,
+		Team:        teamHandler,
+		User:        userHandler,
+		PullRequest: prHandler,
+		Stats:       statsHandler,
+		APIKeys:     apiKeyHandler,
+		Webhooks:    webhookHandler,
+		Integration: integrationHandler,
+		GitHub:      githubHandler,
+		GitLab:      gitlabHandler,
+
+		Notifications: notificationHandler,
+		Escalations:   escalationHandler,
+		Events:        eventStreamHandler,
+		GraphQL:       graphqlHandler,
+	})
+
+	if len(cfg.Server.TrustedProxies) > 0 {
+		if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+			dbPool.Close()
+			return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+		}
+	}
 
 	server := &http.Server{
 		Addr:    ":" + cfg.Server.Port,
 		Handler: router,
 	}
+	// Shutdown waits for requests to finish and event streams never do on their
+	// own, so they are closed and their clients reconnect to another instance.
+	server.RegisterOnShutdown(eventStream.Close)
 
-	return &App{
+	app := &App{
 		cfg:        cfg,
 		logger:     logger,
 		httpServer: server,
+		grpcServer: grpcServer,
 		dbPool:     dbPool,
-	}, nil
+		readiness:  readiness,
+	}
+
+	app.addWorker(func(ctx context.Context) {
+		workers.RunPeriodic(ctx, "stats-snapshot", cfg.Stats.SnapshotInterval, logger, statsService.TakeSnapshot)
+	})
+	app.addWorker(func(ctx context.Context) {
+		workers.RunPeriodic(ctx, "idempotency-cleanup", cfg.Idempotency.CleanupInterval, logger, idempotency.PurgeExpired)
+	})
+	app.addWorker(func(ctx context.Context) {
+		workers.RunPeriodic(ctx, "outbox-relay", cfg.Outbox.PollInterval, logger, func(ctx context.Context) error {
+			_, err := outboxRelay.RelayPending(ctx)
+			return err
+		})
+	})
+	app.addWorker(func(ctx context.Context) {
+		workers.RunPeriodic(ctx, "event-stream", cfg.EventStream.PollInterval, logger, eventStream.Poll)
+	})
+	app.addWorker(func(ctx context.Context) {
+		workers.RunPeriodic(ctx, "webhook-delivery", cfg.Webhooks.PollInterval, logger, func(ctx context.Context) error {
+			_, err := webhookService.DeliverDue(ctx)
+			return err
+		})
+	})
+	app.addWorker(func(ctx context.Context) {
+		workers.RunPeriodic(ctx, "chat-notifications", cfg.Notifier.PollInterval, logger, func(ctx context.Context) error {
+			_, err := notificationService.SendDue(ctx)
+			return err
+		})
+	})
+	app.addWorker(func(ctx context.Context) {
+		workers.RunPeriodic(ctx, "review-escalation", cfg.Escalation.PollInterval, logger, func(ctx context.Context) error {
+			_, err := escalationService.Run(ctx)
+			return err
+		})
+	})
+	if cfg.Digest.Enabled {
+		app.addWorker(func(ctx context.Context) {
+			workers.RunPeriodic(ctx, "review-digest", cfg.Digest.PollInterval, logger, func(ctx context.Context) error {
+				_, err := digestService.Run(ctx)
+				return err
+			})
+		})
+	}
+	if len(codeHostClients) > 0 {
+		app.addWorker(func(ctx context.Context) {
+			workers.RunPeriodic(ctx, "code-host-sync", cfg.CodeHostSync.PollInterval, logger, func(ctx context.Context) error {
+				_, err := codeHostSyncService.SyncDue(ctx)
+				return err
+			})
+		})
+	}
+	app.addWorker(func(ctx context.Context) {
+		workers.RunPeriodic(ctx, "outbox-cleanup", cfg.Outbox.CleanupInterval, logger, func(ctx context.Context) error {
+			return outboxRelay.PurgePublished(ctx, cfg.Outbox.Retention)
+		})
+	})
+
+	return app, nil
 }
 
 func (a *App) HTTPServer() *http.Server {
 	return a.httpServer
 }
 
+// GRPCServer is nil when GRPC_PORT is empty.
+func (a *App) GRPCServer() *grpc.Server {
+	return a.grpcServer
+}
+
+// BeginShutdown flips /health/ready to 503 so load balancers stop routing new
+// traffic before the HTTP server is drained.
+func (a *App) BeginShutdown() {
+	a.readiness.BeginShutdown()
+}
+
+func (a *App) addWorker(run func(ctx context.Context)) {
+	a.workers = append(a.workers, run)
+}
+
+// StartWorkers launches background jobs; they are stopped by Close.
+func (a *App) StartWorkers(ctx context.Context) {
+	ctx, a.stopWorkers = context.WithCancel(ctx)
+
+	for _, run := range a.workers {
+		a.workersWG.Add(1)
+		go func() {
+			defer a.workersWG.Done()
+			run(ctx)
+		}()
+	}
+}
+
 func (a *App) Close() {
+	if a.stopWorkers != nil {
+		a.stopWorkers()
+	}
+	a.workersWG.Wait()
+
 	if a.dbPool != nil {
 		a.dbPool.Close()
 	}
 }
+
+// batchLease outlasts a worker sending its whole claimed batch one call after
+// another with every call running into its timeout, so no other instance
+// claims the tail of the batch while it is still being sent.
+func batchLease(batchSize, callsPerItem int, timeout time.Duration) time.Duration {
+	return time.Duration(max(batchSize, 1)*callsPerItem+1) * timeout
+}
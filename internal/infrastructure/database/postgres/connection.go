@@ -11,6 +11,8 @@ import (
 	"go.uber.org/zap"
 )
 
+const defaultMaxConns = 25
+
 func NewConnection(cfg *config.DatabaseConfig, logger *zap.Logger) (*pgxpool.Pool, error) {
 	dsn := cfg.GetDSN()
 
@@ -20,11 +22,17 @@ func NewConnection(cfg *config.DatabaseConfig, logger *zap.Logger) (*pgxpool.Poo
 		return nil, err
 	}
 
-	poolConfig.MaxConns = 25
-	poolConfig.MinConns = 5
+	maxConns := cfg.MaxConns
+	if maxConns <= 0 {
+		maxConns = defaultMaxConns
+	}
+
+	poolConfig.MaxConns = int32(maxConns)
+	poolConfig.MinConns = int32(min(max(cfg.MinConns, 0), maxConns))
 	poolConfig.MaxConnLifetime = time.Hour
 	poolConfig.MaxConnIdleTime = 30 * time.Minute
 	poolConfig.HealthCheckPeriod = time.Minute
+	poolConfig.ConnConfig.Tracer = newQueryTracer()
 
 	logger.Debug("Connecting to PostgreSQL",
 		zap.String("host", cfg.Host),
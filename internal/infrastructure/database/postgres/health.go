@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"pr-reviewer-assignment/internal/health"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SchemaVersion is the migration the code expects; bump it with every new
+// file in migrations/.
+const SchemaVersion = 17
+
+type PingCheck struct {
+	pool *pgxpool.Pool
+}
+
+func NewPingCheck(pool *pgxpool.Pool) *PingCheck {
+	return &PingCheck{pool: pool}
+}
+
+func (c *PingCheck) Name() string {
+	return "database"
+}
+
+func (c *PingCheck) Check(ctx context.Context) (map[string]any, error) {
+	return nil, c.pool.Ping(ctx)
+}
+
+type MigrationCheck struct {
+	pool     *pgxpool.Pool
+	expected int64
+}
+
+func NewMigrationCheck(pool *pgxpool.Pool, expected int64) *MigrationCheck {
+	return &MigrationCheck{pool: pool, expected: expected}
+}
+
+func (c *MigrationCheck) Name() string {
+	return "migrations"
+}
+
+func (c *MigrationCheck) Check(ctx context.Context) (map[string]any, error) {
+	const query = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+	var (
+		version int64
+		dirty   bool
+	)
+
+	details := map[string]any{"expected_version": c.expected}
+
+	if err := c.pool.QueryRow(ctx, query).Scan(&version, &dirty); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return details, fmt.Errorf("no migrations applied")
+		}
+		return details, err
+	}
+
+	details["version"] = version
+	details["dirty"] = dirty
+
+	if dirty {
+		return details, fmt.Errorf("migration %d is dirty", version)
+	}
+
+	// A newer schema is fine: during a rolling deploy the new pods migrate
+	// while the old ones keep serving.
+	if version < c.expected {
+		return details, fmt.Errorf("schema version %d, expected at least %d", version, c.expected)
+	}
+
+	return details, nil
+}
+
+type PoolSaturationCheck struct {
+	pool      *pgxpool.Pool
+	threshold float64
+}
+
+// NewPoolSaturationCheck reports acquired/max connections; a threshold of zero
+// only reports and never fails.
+func NewPoolSaturationCheck(pool *pgxpool.Pool, threshold float64) *PoolSaturationCheck {
+	return &PoolSaturationCheck{pool: pool, threshold: threshold}
+}
+
+func (c *PoolSaturationCheck) Name() string {
+	return "pool"
+}
+
+func (c *PoolSaturationCheck) Check(context.Context) (map[string]any, error) {
+	stat := c.pool.Stat()
+
+	var saturation float64
+	if stat.MaxConns() > 0 {
+		saturation = float64(stat.AcquiredConns()) / float64(stat.MaxConns())
+	}
+
+	details := map[string]any{
+		"acquired_conns": stat.AcquiredConns(),
+		"idle_conns":     stat.IdleConns(),
+		"total_conns":    stat.TotalConns(),
+		"max_conns":      stat.MaxConns(),
+		"saturation":     saturation,
+	}
+
+	if c.threshold > 0 && saturation >= c.threshold {
+		return details, fmt.Errorf("pool saturation %.2f exceeds %.2f", saturation, c.threshold)
+	}
+
+	return details, nil
+}
+
+var (
+	_ health.Check = (*PingCheck)(nil)
+	_ health.Check = (*MigrationCheck)(nil)
+	_ health.Check = (*PoolSaturationCheck)(nil)
+)
@@ -2,54 +2,189 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
 
 	"pr-reviewer-assignment/internal/adapters/output/database"
+	metricsports "pr-reviewer-assignment/internal/core/ports/metrics"
 	"pr-reviewer-assignment/internal/core/ports/transactions"
+	"pr-reviewer-assignment/internal/logger"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.uber.org/zap"
 )
 
+const (
+	pgCodeSerializationFailure = "40001"
+	pgCodeDeadlockDetected     = "40P01"
+)
+
+// RetryPolicy controls how transactions aborted by serialization failures or
+// deadlocks are replayed. Delays grow exponentially from BaseDelay up to
+// MaxDelay with full jitter; MaxRetries of 0 disables retrying.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
 type TransactionManager struct {
-	pool   *pgxpool.Pool
-	logger *zap.Logger
+	pool     *pgxpool.Pool
+	logger   *zap.Logger
+	recorder metricsports.Recorder
+	retry    RetryPolicy
 }
 
-func NewTransactionManager(pool *pgxpool.Pool, logger *zap.Logger) *TransactionManager {
+func NewTransactionManager(pool *pgxpool.Pool, logger *zap.Logger, recorder metricsports.Recorder, retry RetryPolicy) *TransactionManager {
+	if recorder == nil {
+		recorder = metricsports.NoopRecorder{}
+	}
 
 	return &TransactionManager{
-		pool:   pool,
-		logger: logger,
+		pool:     pool,
+		logger:   logger,
+		recorder: recorder,
+		retry:    retry,
 	}
 }
 
-func (m *TransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+func (m *TransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...transactions.Option) error {
 	if fn == nil {
 		return nil
 	}
 
-	tx, err := m.pool.BeginTx(ctx, pgx.TxOptions{})
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "TransactionManager.WithinTransaction")
+	defer span.End()
+
+	if outer, ok := database.DBFromContext(ctx).(pgx.Tx); ok {
+		return m.runNested(ctx, outer, fn)
+	}
+
+	options := transactions.ApplyOptions(opts...)
+	txOptions := pgx.TxOptions{IsoLevel: pgx.TxIsoLevel(options.Isolation)}
+	if options.ReadOnly {
+		txOptions.AccessMode = pgx.ReadOnly
+	}
+
+	if options.Isolation != transactions.IsolationDefault {
+		span.SetAttributes(attribute.String("db.transaction.isolation", string(options.Isolation)))
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := m.run(ctx, txOptions, fn)
+		if err == nil {
+			m.recorder.TransactionCommitted()
+			return nil
+		}
+
+		if !isRetryable(err) || attempt >= m.retry.MaxRetries {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "rolled back")
+			m.recorder.TransactionRolledBack()
+			return err
+		}
+
+		m.recorder.TransactionRetried()
+		delay := m.backoff(attempt)
+		m.log(ctx).Info("retrying transaction",
+			zap.Int("attempt", attempt+1),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			span.RecordError(ctx.Err())
+			span.SetStatus(codes.Error, "cancelled while retrying")
+			m.recorder.TransactionRolledBack()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (m *TransactionManager) run(ctx context.Context, txOptions pgx.TxOptions, fn func(ctx context.Context) error) error {
+	tx, err := m.pool.BeginTx(ctx, txOptions)
 	if err != nil {
-		m.logger.Error("failed to begin transaction", zap.Error(err))
+		m.log(ctx).Error("failed to begin transaction", zap.Error(err))
 		return err
 	}
 
-	txCtx := database.ContextWithDB(ctx, tx)
+	txCtx, committed := transactions.WithCommitHooks(database.ContextWithDB(ctx, tx))
 
 	if err := fn(txCtx); err != nil {
 		if rbErr := tx.Rollback(ctx); rbErr != nil {
-			m.logger.Error("transaction rollback failed", zap.Error(rbErr))
+			m.log(ctx).Error("transaction rollback failed", zap.Error(rbErr))
 		}
 		return err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		m.logger.Error("failed to commit transaction", zap.Error(err))
+		m.log(ctx).Error("failed to commit transaction", zap.Error(err))
+		return err
+	}
+
+	committed()
+	return nil
+}
+
+// runNested runs fn in a savepoint of the caller's transaction, so a failed
+// fn undoes only its own writes. Retries are left to the outermost call, since
+// a serialization failure aborts the whole transaction.
+func (m *TransactionManager) runNested(ctx context.Context, outer pgx.Tx, fn func(ctx context.Context) error) error {
+	savepoint, err := outer.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	savepointCtx, committed := transactions.WithCommitHooks(database.ContextWithDB(ctx, savepoint))
+
+	if err := fn(savepointCtx); err != nil {
+		if rbErr := savepoint.Rollback(ctx); rbErr != nil {
+			m.log(ctx).Error("savepoint rollback failed", zap.Error(rbErr))
+		}
+		return err
+	}
+
+	if err := savepoint.Commit(ctx); err != nil {
 		return err
 	}
 
+	committed()
 	return nil
 }
 
+func (m *TransactionManager) backoff(attempt int) time.Duration {
+	if m.retry.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := m.retry.BaseDelay << min(attempt, 16)
+	if m.retry.MaxDelay > 0 && delay > m.retry.MaxDelay {
+		delay = m.retry.MaxDelay
+	}
+
+	return rand.N(delay) + 1
+}
+
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == pgCodeSerializationFailure || pgErr.Code == pgCodeDeadlockDetected
+}
+
+func (m *TransactionManager) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, m.logger)
+}
+
 var _ transactions.Manager = (*TransactionManager)(nil)
@@ -1,68 +1,234 @@
 package infrastructure
 
 import (
+	adaptergraphql "pr-reviewer-assignment/internal/adapters/input/graphql"
 	adapterhttp "pr-reviewer-assignment/internal/adapters/input/http"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/metrics"
 	"pr-reviewer-assignment/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
-func NewRouter(logger *zap.Logger, health *adapterhttp.HealthHandler, team *adapterhttp.TeamHandler, user *adapterhttp.UserHandler, pr *adapterhttp.PullRequestHandler, stats *adapterhttp.StatsHandler) *gin.Engine {
+type RouterDeps struct {
+	Logger  *zap.Logger
+	Metrics *metrics.Metrics
+	Auth    *adapterhttp.Authenticator
+	Limiter *middleware.RateLimiter
+
+	Idempotency *middleware.Idempotency
+
+	Health      *adapterhttp.HealthHandler
+	Team        *adapterhttp.TeamHandler
+	User        *adapterhttp.UserHandler
+	PullRequest *adapterhttp.PullRequestHandler
+	Stats       *adapterhttp.StatsHandler
+	APIKeys     *adapterhttp.APIKeyHandler
+	Webhooks    *adapterhttp.WebhookHandler
+	Integration *adapterhttp.IntegrationHandler
+	GitHub      *adapterhttp.GitHubWebhookHandler
+	GitLab      *adapterhttp.GitLabWebhookHandler
+
+	Notifications *adapterhttp.NotificationHandler
+	Escalations   *adapterhttp.EscalationHandler
+	Events        *adapterhttp.EventStreamHandler
+	GraphQL       *adaptergraphql.Handler
+}
+
+func NewRouter(deps RouterDeps) *gin.Engine {
 	r := gin.New()
 	r.Use(gin.Recovery())
-	if logger != nil {
-		r.Use(middleware.RequestLogger(logger))
+	r.Use(middleware.RequestTracing())
+	r.Use(middleware.RequestID(deps.Logger))
+	if deps.Logger != nil {
+		r.Use(middleware.RequestLogger(deps.Logger))
+	}
+	if deps.Metrics != nil {
+		r.Use(middleware.RequestMetrics(deps.Metrics))
 	}
 
-	if health != nil {
-		health.Register(r)
+	if deps.Health != nil {
+		deps.Health.Register(r)
 	}
 
-	registerTeamRoutes(r, team)
-	registerUserRoutes(r, user)
-	registerPullRequestRoutes(r, pr)
-	registerStatsRoutes(r, stats)
+	g := guard{auth: deps.Auth, limiter: deps.Limiter, idempotency: deps.Idempotency}
+
+	if deps.Metrics != nil {
+		r.GET("/metrics", g.limiter.PerIP(), g.auth.Require(types.ScopeRead), gin.WrapH(deps.Metrics.Handler()))
+	}
+	registerTeamRoutes(r, g, deps.Team)
+	registerUserRoutes(r, g, deps.User)
+	registerPullRequestRoutes(r, g, deps.PullRequest)
+	registerStatsRoutes(r, g, deps.Stats)
+	registerAPIKeyRoutes(r, g, deps.APIKeys)
+	registerWebhookRoutes(r, g, deps.Webhooks)
+	registerIntegrationRoutes(r, g, deps)
+	registerNotificationRoutes(r, g, deps.Notifications)
+	registerEscalationRoutes(r, g, deps.Escalations)
+	registerEventRoutes(r, g, deps.Events)
+	registerGraphQLRoutes(r, g, deps.GraphQL)
 
 	return r
 }
 
-func registerTeamRoutes(r *gin.Engine, handler *adapterhttp.TeamHandler) {
+// guard puts authentication, rate limiting and idempotency in front of
+// handlers, so limits and stored responses are keyed by the authenticated
+// caller. The per-IP limit comes first, before any credential is checked.
+type guard struct {
+	auth        *adapterhttp.Authenticator
+	limiter     *middleware.RateLimiter
+	idempotency *middleware.Idempotency
+}
+
+func (g guard) require(scope types.Scope, handlers ...gin.HandlerFunc) []gin.HandlerFunc {
+	return g.query(scope, append([]gin.HandlerFunc{g.idempotency.Handler()}, handlers...)...)
+}
+
+// query leaves out idempotency, for read-only endpoints that take a POST body
+// and must always answer with fresh data.
+func (g guard) query(scope types.Scope, handlers ...gin.HandlerFunc) []gin.HandlerFunc {
+	return append([]gin.HandlerFunc{
+		g.limiter.PerIP(),
+		g.auth.Require(scope),
+		g.limiter.Handler(),
+	}, handlers...)
+}
+
+func registerTeamRoutes(r *gin.Engine, g guard, handler *adapterhttp.TeamHandler) {
 	if handler == nil {
 		return
 	}
 
 	group := r.Group("/team")
 
-	group.POST("/add", handler.CreateTeam)
-	group.GET("/get", handler.GetTeam)
+	group.POST("/add", g.require(types.ScopeAdminTeams, handler.CreateTeam)...)
+	group.GET("/get", g.require(types.ScopeRead, handler.GetTeam)...)
+	group.POST("/setRole", g.require(types.ScopeAdminTeams, handler.SetRole)...)
+	group.POST("/removeRole", g.require(types.ScopeAdminTeams, handler.RemoveRole)...)
+	group.GET("/roles", g.require(types.ScopeRead, handler.GetRoles)...)
 }
 
-func registerUserRoutes(r *gin.Engine, handler *adapterhttp.UserHandler) {
+func registerUserRoutes(r *gin.Engine, g guard, handler *adapterhttp.UserHandler) {
 	if handler == nil {
 		return
 	}
 
 	group := r.Group("/users")
-	group.POST("/setIsActive", handler.SetActivity)
-	group.GET("/getReview", handler.GetReviewerAssignments)
+	group.POST("/setIsActive", g.require(types.ScopeAdminTeams, handler.SetActivity)...)
+	group.GET("/getReview", g.require(types.ScopeRead, handler.GetReviewerAssignments)...)
+	group.POST("/setPreferences", g.require(types.ScopeWritePR, handler.SetPreferences)...)
 }
 
-func registerPullRequestRoutes(r *gin.Engine, handler *adapterhttp.PullRequestHandler) {
+func registerPullRequestRoutes(r *gin.Engine, g guard, handler *adapterhttp.PullRequestHandler) {
 	if handler == nil {
 		return
 	}
 
-	group := r.Group("/pullRequest")
+	group := r.Group("/pullRequest", g.require(types.ScopeWritePR)...)
 
 	group.POST("/create", handler.Create)
 	group.POST("/merge", handler.Merge)
 	group.POST("/reassign", handler.Reassign)
 }
 
-func registerStatsRoutes(r *gin.Engine, handler *adapterhttp.StatsHandler) {
+func registerStatsRoutes(r *gin.Engine, g guard, handler *adapterhttp.StatsHandler) {
+	if handler == nil {
+		return
+	}
+
+	group := r.Group("/stats", g.require(types.ScopeRead)...)
+
+	group.GET("", handler.GetStats)
+	group.GET("/history", handler.GetHistory)
+	group.GET("/fairness", handler.GetFairness)
+}
+
+func registerAPIKeyRoutes(r *gin.Engine, g guard, handler *adapterhttp.APIKeyHandler) {
+	if handler == nil {
+		return
+	}
+
+	group := r.Group("/admin/apiKeys", g.require(types.ScopeAdminKeys)...)
+
+	group.POST("/create", handler.Create)
+	group.GET("/list", handler.List)
+	group.POST("/revoke", handler.Revoke)
+}
+
+func registerWebhookRoutes(r *gin.Engine, g guard, handler *adapterhttp.WebhookHandler) {
+	if handler == nil {
+		return
+	}
+
+	group := r.Group("/webhooks", g.require(types.ScopeAdminWebhooks)...)
+
+	group.POST("/create", handler.Create)
+	group.GET("/list", handler.List)
+	group.POST("/delete", handler.Delete)
+	group.GET("/deliveries", handler.Deliveries)
+	group.POST("/redeliver", handler.Redeliver)
+}
+
+// registerIntegrationRoutes: code host webhooks authenticate by signature or
+// shared token, so they bypass the API key guard.
+func registerIntegrationRoutes(r *gin.Engine, g guard, deps RouterDeps) {
+	group := r.Group("/integrations")
+
+	if deps.Integration != nil {
+		accounts := group.Group("/accounts", g.require(types.ScopeAdminIntegrations)...)
+		accounts.POST("/link", deps.Integration.LinkAccount)
+		accounts.POST("/unlink", deps.Integration.UnlinkAccount)
+		accounts.GET("/list", deps.Integration.ListAccounts)
+	}
+
+	if deps.GitHub != nil {
+		group.POST("/github/webhook", deps.GitHub.Handle)
+	}
+
+	if deps.GitLab != nil {
+		group.POST("/gitlab/webhook", deps.GitLab.Handle)
+	}
+}
+
+func registerNotificationRoutes(r *gin.Engine, g guard, handler *adapterhttp.NotificationHandler) {
 	if handler == nil {
 		return
 	}
-	handler.RegisterRoutes(r)
+
+	group := r.Group("/notifications/channels", g.require(types.ScopeAdminTeams)...)
+
+	group.POST("/set", handler.SetChannel)
+	group.GET("/list", handler.ListChannels)
+	group.POST("/remove", handler.RemoveChannel)
+}
+
+func registerEscalationRoutes(r *gin.Engine, g guard, handler *adapterhttp.EscalationHandler) {
+	if handler == nil {
+		return
+	}
+
+	group := r.Group("/escalations")
+
+	group.POST("/sla/set", g.require(types.ScopeAdminTeams, handler.SetSLA)...)
+	group.GET("/sla/list", g.require(types.ScopeRead, handler.ListSLAs)...)
+	group.POST("/sla/remove", g.require(types.ScopeAdminTeams, handler.RemoveSLA)...)
+	group.GET("/list", g.require(types.ScopeRead, handler.List)...)
+}
+
+func registerEventRoutes(r *gin.Engine, g guard, handler *adapterhttp.EventStreamHandler) {
+	if handler == nil {
+		return
+	}
+
+	r.GET("/events/stream", g.require(types.ScopeRead, handler.Stream)...)
+}
+
+func registerGraphQLRoutes(r *gin.Engine, g guard, handler *adaptergraphql.Handler) {
+	if handler == nil {
+		return
+	}
+
+	r.GET("/graphql", g.query(types.ScopeRead, handler.Serve)...)
+	r.POST("/graphql", g.query(types.ScopeRead, handler.Serve)...)
 }
@@ -0,0 +1,198 @@
+package jwtauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"pr-reviewer-assignment/internal/config"
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	domainErrors "pr-reviewer-assignment/internal/core/domain/errors"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/logger"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// Verifier validates bearer JWTs against a JWKS and turns their claims into a
+// Principal. A role claim value may carry a team after its last colon, e.g.
+// "team-lead:backend", which makes the subject a lead of that team; a bare
+// "team-lead" relies on the team_roles table instead. Values that map to a
+// role as a whole, such as "urn:org:role:admin", are never split.
+type Verifier struct {
+	keys        *keySet
+	parser      *jwt.Parser
+	rolesClaim  []string
+	roleMapping map[string]string
+	logger      *zap.Logger
+}
+
+func NewVerifier(ctx context.Context, cfg config.JWTConfig, client *http.Client, logger *zap.Logger) (*Verifier, error) {
+	if cfg.Issuer == "" || cfg.Audience == "" {
+		return nil, errors.New("jwt issuer and audience are required")
+	}
+
+	mapping, err := parseRoleMapping(cfg.RoleMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys *keySet
+	switch {
+	case cfg.JWKSFile != "":
+		keys = newFileKeySet(cfg.JWKSFile)
+	case cfg.JWKSURL != "":
+		keys = newURLKeySet(cfg.JWKSURL, client, cfg.JWKSRefreshInterval)
+	default:
+		return nil, errors.New("jwks file or url is required")
+	}
+
+	if err := keys.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return &Verifier{
+		keys: keys,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods(signingMethods),
+			jwt.WithIssuer(cfg.Issuer),
+			jwt.WithAudience(cfg.Audience),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(cfg.ClockSkew),
+		),
+		rolesClaim:  strings.Split(cfg.RolesClaim, "."),
+		roleMapping: mapping,
+		logger:      logger,
+	}, nil
+}
+
+func (v *Verifier) Verify(ctx context.Context, token string) (*entities.Principal, error) {
+	claims := jwt.MapClaims{}
+
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.key(ctx, kid)
+	})
+	if err != nil {
+		logger.FromContext(ctx, v.logger).Info("Rejected bearer token", zap.Error(err))
+		return nil, domainErrors.Unauthorized("invalid bearer token")
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, domainErrors.Unauthorized("token has no subject")
+	}
+
+	principal := &entities.Principal{
+		Subject: "jwt:" + subject,
+		UserID:  subject,
+		Kind:    entities.PrincipalJWT,
+	}
+
+	for _, value := range claimStrings(lookupClaim(claims, v.rolesClaim)) {
+		role, team, ok := v.parseRoleValue(value)
+		if !ok {
+			continue
+		}
+
+		if role == types.RoleTeamLead && team != "" && !slices.Contains(principal.LedTeams, team) {
+			principal.LedTeams = append(principal.LedTeams, team)
+		}
+
+		if !slices.Contains(principal.Roles, role) {
+			principal.Roles = append(principal.Roles, role)
+		}
+
+		for _, scope := range role.Scopes() {
+			if !slices.Contains(principal.Scopes, scope) {
+				principal.Scopes = append(principal.Scopes, scope)
+			}
+		}
+	}
+
+	return principal, nil
+}
+
+func (v *Verifier) parseRoleValue(value string) (types.Role, string, bool) {
+	if role, ok := v.mapRole(value); ok {
+		return role, "", true
+	}
+
+	i := strings.LastIndex(value, ":")
+	if i < 0 {
+		return "", "", false
+	}
+
+	role, ok := v.mapRole(value[:i])
+	return role, value[i+1:], ok
+}
+
+func (v *Verifier) mapRole(name string) (types.Role, bool) {
+	if mapped, ok := v.roleMapping[name]; ok {
+		name = mapped
+	}
+
+	return types.ParseRole(name)
+}
+
+func lookupClaim(claims jwt.MapClaims, path []string) any {
+	var current any = map[string]any(claims)
+	for _, part := range path {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = object[part]
+	}
+
+	return current
+}
+
+// claimStrings accepts both JSON arrays and space separated strings, the two
+// shapes IdPs commonly use for role and scope claims.
+func claimStrings(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+func parseRoleMapping(raw string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		from, to, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid role mapping %q", pair)
+		}
+
+		role, ok := types.ParseRole(to)
+		if !ok {
+			return nil, fmt.Errorf("role mapping %q targets unknown role", pair)
+		}
+
+		mapping[strings.TrimSpace(from)] = role.String()
+	}
+
+	return mapping, nil
+}
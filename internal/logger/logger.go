@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"context"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -35,3 +37,29 @@ func NewProduction() *zap.Logger {
 	logger, _ := zap.NewProduction()
 	return logger
 }
+
+type loggerKey struct{}
+
+func ContextWithLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	if ctx == nil || logger == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the request-scoped logger stored in ctx, or fallback
+// when the context carries none.
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+			return logger
+		}
+	}
+
+	if fallback == nil {
+		return zap.NewNop()
+	}
+
+	return fallback
+}
@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	metricsports "pr-reviewer-assignment/internal/core/ports/metrics"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_reviewer"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	transactions *prometheus.CounterVec
+
+	reviewersAssigned prometheus.Counter
+	noCandidate       *prometheus.CounterVec
+	reassignments     prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests processed, by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency, by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "db_transactions_total",
+			Help:      "Database transactions finished, by outcome.",
+		}, []string{"outcome"}),
+		reviewersAssigned: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewers_assigned_total",
+			Help:      "Reviewers assigned to newly created pull requests.",
+		}),
+		noCandidate: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "no_candidate_total",
+			Help:      "Reviewer selections that found no active candidate, by operation.",
+		}, []string{"operation"}),
+		reassignments: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewer_reassignments_total",
+			Help:      "Successful reviewer reassignments.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.transactions,
+		m.reviewersAssigned,
+		m.noCandidate,
+		m.reassignments,
+	)
+
+	return m
+}
+
+func (m *Metrics) RegisterPool(pool *pgxpool.Pool) {
+	if pool == nil {
+		return
+	}
+
+	m.registry.MustRegister(newPoolCollector(pool))
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, statusLabel).Inc()
+	m.httpDuration.WithLabelValues(method, route, statusLabel).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ReviewersAssigned(count int) {
+	if count > 0 {
+		m.reviewersAssigned.Add(float64(count))
+	}
+}
+
+func (m *Metrics) NoCandidate(operation string) {
+	m.noCandidate.WithLabelValues(operation).Inc()
+}
+
+func (m *Metrics) ReviewerReassigned() {
+	m.reassignments.Inc()
+}
+
+func (m *Metrics) TransactionCommitted() {
+	m.transactions.WithLabelValues("commit").Inc()
+}
+
+func (m *Metrics) TransactionRolledBack() {
+	m.transactions.WithLabelValues("rollback").Inc()
+}
+
+func (m *Metrics) TransactionRetried() {
+	m.transactions.WithLabelValues("retry").Inc()
+}
+
+var _ metricsports.Recorder = (*Metrics)(nil)
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"pr-reviewer-assignment/internal/dto"
+	"pr-reviewer-assignment/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+func abortWithError(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, dto.ErrorResponse{
+		Error: dto.ErrorBody{
+			Code:      code,
+			Message:   message,
+			RequestID: requestid.FromContext(c.Request.Context()),
+		},
+	})
+}
@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/identity"
+	repo "pr-reviewer-assignment/internal/core/ports/repositories"
+	"pr-reviewer-assignment/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	idempotencyKeyMaxLength   = 255
+	idempotencyAnonymousOwner = "anonymous"
+
+	errorCodeBadRequest            = "BAD_REQUEST"
+	errorCodeInternal              = "INTERNAL_ERROR"
+	errorCodeIdempotencyKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+	errorCodeIdempotencyInProgress = "IDEMPOTENCY_KEY_IN_PROGRESS"
+)
+
+// Idempotency makes POST requests carrying an Idempotency-Key safe to retry.
+// The first response (anything below 500) is stored per caller and key and
+// replayed for repeats with the same body until the TTL runs out.
+type Idempotency struct {
+	repo        repo.IdempotencyRepository
+	ttl         time.Duration
+	lockTimeout time.Duration
+	logger      *zap.Logger
+}
+
+func NewIdempotency(repo repo.IdempotencyRepository, ttl, lockTimeout time.Duration, logger *zap.Logger) *Idempotency {
+	return &Idempotency{
+		repo:        repo,
+		ttl:         ttl,
+		lockTimeout: lockTimeout,
+		logger:      logger,
+	}
+}
+
+// Handler returns a pass-through middleware for a nil Idempotency.
+func (m *Idempotency) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if m == nil || c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > idempotencyKeyMaxLength {
+			abortWithError(c, http.StatusBadRequest, errorCodeBadRequest, "Idempotency-Key is too long")
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abortWithError(c, http.StatusBadRequest, errorCodeBadRequest, "failed to read request body")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		now := time.Now().UTC()
+		record := &entities.IdempotencyRecord{
+			Owner:       idempotencyOwner(ctx),
+			Key:         key,
+			RequestHash: requestHash(c.Request, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(m.ttl),
+		}
+
+		reserved, err := m.repo.Reserve(ctx, record, now.Add(-m.lockTimeout))
+		if err != nil {
+			m.log(ctx).Error("Failed to reserve idempotency key", zap.Error(err))
+			abortWithError(c, http.StatusInternalServerError, errorCodeInternal, "internal server error")
+			return
+		}
+
+		if !reserved {
+			m.replay(c, record)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// Detached so a client disconnect does not leave the key pending.
+		storeCtx := context.WithoutCancel(ctx)
+		completed := false
+		defer func() {
+			if !completed {
+				if err := m.repo.Release(storeCtx, record.Owner, record.Key); err != nil {
+					m.log(ctx).Warn("Failed to release idempotency key", zap.Error(err))
+				}
+			}
+		}()
+
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+
+		record.StatusCode = status
+		record.ContentType = recorder.Header().Get("Content-Type")
+		record.ETag = recorder.Header().Get("ETag")
+		record.ResponseBody = recorder.body.Bytes()
+
+		if err := m.repo.Complete(storeCtx, record); err != nil {
+			m.log(ctx).Warn("Failed to store idempotent response", zap.Error(err))
+			return
+		}
+		completed = true
+	}
+}
+
+func (m *Idempotency) replay(c *gin.Context, attempt *entities.IdempotencyRecord) {
+	ctx := c.Request.Context()
+
+	stored, err := m.repo.Get(ctx, attempt.Owner, attempt.Key)
+	if err != nil {
+		m.log(ctx).Error("Failed to load idempotency key", zap.Error(err))
+		abortWithError(c, http.StatusInternalServerError, errorCodeInternal, "internal server error")
+		return
+	}
+
+	if stored.RequestHash != attempt.RequestHash {
+		abortWithError(c, http.StatusUnprocessableEntity, errorCodeIdempotencyKeyReused,
+			"Idempotency-Key was already used with a different request")
+		return
+	}
+
+	if !stored.IsCompleted() {
+		c.Header("Retry-After", "1")
+		abortWithError(c, http.StatusConflict, errorCodeIdempotencyInProgress,
+			"a request with this Idempotency-Key is still being processed")
+		return
+	}
+
+	c.Header(IdempotentReplayedHeader, "true")
+	if stored.ETag != "" {
+		c.Header("ETag", stored.ETag)
+	}
+	c.Data(stored.StatusCode, stored.ContentType, stored.ResponseBody)
+	c.Abort()
+}
+
+// PurgeExpired is meant to run as a periodic worker.
+func (m *Idempotency) PurgeExpired(ctx context.Context) error {
+	deleted, err := m.repo.DeleteExpired(ctx, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		m.log(ctx).Debug("Expired idempotency keys purged", zap.Int64("count", deleted))
+	}
+
+	return nil
+}
+
+func (m *Idempotency) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, m.logger)
+}
+
+func idempotencyOwner(ctx context.Context) string {
+	if principal := identity.PrincipalFromContext(ctx); principal != nil {
+		return principal.Subject
+	}
+
+	return idempotencyAnonymousOwner
+}
+
+func requestHash(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -3,17 +3,19 @@ package middleware
 import (
 	"time"
 
+	"pr-reviewer-assignment/internal/logger"
+
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
-func RequestLogger(logger *zap.Logger) gin.HandlerFunc {
+func RequestLogger(base *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		c.Next()
 
 		duration := time.Since(start)
-		logger.Info("http request",
+		logger.FromContext(c.Request.Context(), base).Info("http request",
 			zap.String("method", c.Request.Method),
 			zap.String("path", c.Request.URL.Path),
 			zap.Int("status", c.Writer.Status()),
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"pr-reviewer-assignment/internal/core/identity"
+	"pr-reviewer-assignment/internal/logger"
+	"pr-reviewer-assignment/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const errorCodeRateLimited = "RATE_LIMITED"
+
+// RateLimiter throttles clients per route. Handler runs after authentication
+// so authenticated callers are keyed by principal and anonymous ones by IP.
+// PerIP runs before authentication and keeps floods of bad credentials from
+// reaching the key and token checks.
+type RateLimiter struct {
+	store  ratelimit.Store
+	rules  *ratelimit.Rules
+	logger *zap.Logger
+}
+
+func NewRateLimiter(store ratelimit.Store, rules *ratelimit.Rules, logger *zap.Logger) *RateLimiter {
+	return &RateLimiter{
+		store:  store,
+		rules:  rules,
+		logger: logger,
+	}
+}
+
+// Handler returns a pass-through middleware for a nil RateLimiter.
+func (l *RateLimiter) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if l == nil {
+			c.Next()
+			return
+		}
+
+		limit, scope, ok := l.rules.For(c.Request.Method + " " + c.FullPath())
+		if !ok {
+			c.Next()
+			return
+		}
+
+		client := "ip:" + c.ClientIP()
+		if principal := identity.PrincipalFromContext(c.Request.Context()); principal != nil {
+			client = principal.Subject
+		}
+
+		if l.allow(c, scope+"|"+client, limit) {
+			c.Next()
+		}
+	}
+}
+
+// PerIP returns a pass-through middleware for a nil RateLimiter or when no
+// per-IP limit is configured.
+func (l *RateLimiter) PerIP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if l == nil || l.rules.PerIP == nil {
+			c.Next()
+			return
+		}
+
+		if l.allow(c, "ip|"+c.ClientIP(), *l.rules.PerIP) {
+			c.Next()
+		}
+	}
+}
+
+func (l *RateLimiter) allow(c *gin.Context, key string, limit ratelimit.Limit) bool {
+	decision, err := l.store.Allow(c.Request.Context(), key, limit)
+	if err != nil {
+		// Failing open keeps the API up when a shared store is unavailable.
+		logger.FromContext(c.Request.Context(), l.logger).Warn("Rate limit store failed", zap.Error(err))
+		return true
+	}
+
+	if !decision.Allowed {
+		seconds := int(math.Ceil(decision.RetryAfter.Seconds()))
+		c.Header("Retry-After", strconv.Itoa(max(seconds, 1)))
+		abortWithError(c, http.StatusTooManyRequests, errorCodeRateLimited, "rate limit exceeded")
+		return false
+	}
+
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	return true
+}
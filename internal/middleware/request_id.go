@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"pr-reviewer-assignment/internal/logger"
+	"pr-reviewer-assignment/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// RequestID accepts a well-formed X-Request-ID or generates one, echoes it in
+// the response and stores it, together with a logger tagged with it, in the
+// request context.
+func RequestID(base *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !requestid.IsValid(id) {
+			id = requestid.New()
+		}
+
+		c.Header(requestid.Header, id)
+
+		ctx := requestid.ContextWithRequestID(c.Request.Context(), id)
+
+		fields := []zap.Field{zap.String("request_id", id)}
+		if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+			fields = append(fields, zap.String("trace_id", spanCtx.TraceID().String()))
+		}
+		ctx = logger.ContextWithLogger(ctx, logger.FromContext(ctx, base).With(fields...))
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
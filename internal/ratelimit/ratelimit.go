@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit describes a token bucket: Rate tokens are added per second up to
+// Burst.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+type Decision struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Store keeps bucket state. The in-memory store suits a single instance;
+// multi-instance deployments plug in a shared implementation.
+type Store interface {
+	Allow(ctx context.Context, key string, limit Limit) (Decision, error)
+}
+
+// Rules maps routes ("METHOD /path" as registered in the router) to limits.
+// Routes without an explicit rule share the Default bucket per client. PerIP
+// caps each address across all routes, authenticated or not.
+type Rules struct {
+	Default *Limit
+	Routes  map[string]Limit
+	PerIP   *Limit
+}
+
+func (r *Rules) For(route string) (Limit, string, bool) {
+	if limit, ok := r.Routes[route]; ok {
+		return limit, route, true
+	}
+
+	if r.Default != nil {
+		return *r.Default, "*", true
+	}
+
+	return Limit{}, "", false
+}
+
+// ParseRules reads the default limit and a comma separated list of
+// "METHOD /path=spec" overrides. A spec is "<count>/<s|m|h>[:burst]", e.g.
+// "30/m:5"; the burst defaults to the count.
+func ParseRules(defaultSpec, routeSpecs string) (*Rules, error) {
+	rules := &Rules{Routes: make(map[string]Limit)}
+
+	if strings.TrimSpace(defaultSpec) != "" {
+		limit, err := ParseLimit(defaultSpec)
+		if err != nil {
+			return nil, fmt.Errorf("default rate limit: %w", err)
+		}
+		rules.Default = &limit
+	}
+
+	for _, entry := range strings.Split(routeSpecs, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("rate limit rule %q: expected METHOD /path=spec", entry)
+		}
+
+		fields := strings.Fields(route)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("rate limit rule %q: expected METHOD /path", entry)
+		}
+
+		limit, err := ParseLimit(spec)
+		if err != nil {
+			return nil, fmt.Errorf("rate limit rule %q: %w", entry, err)
+		}
+
+		rules.Routes[strings.ToUpper(fields[0])+" "+fields[1]] = limit
+	}
+
+	return rules, nil
+}
+
+func ParseLimit(spec string) (Limit, error) {
+	spec = strings.TrimSpace(spec)
+
+	rate, burstPart, hasBurst := strings.Cut(spec, ":")
+	countPart, unit, ok := strings.Cut(rate, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid limit %q", spec)
+	}
+
+	count, err := strconv.Atoi(countPart)
+	if err != nil || count <= 0 {
+		return Limit{}, fmt.Errorf("invalid request count in %q", spec)
+	}
+
+	var period time.Duration
+	switch unit {
+	case "s":
+		period = time.Second
+	case "m":
+		period = time.Minute
+	case "h":
+		period = time.Hour
+	default:
+		return Limit{}, fmt.Errorf("invalid period in %q", spec)
+	}
+
+	burst := count
+	if hasBurst {
+		burst, err = strconv.Atoi(burstPart)
+		if err != nil || burst <= 0 {
+			return Limit{}, fmt.Errorf("invalid burst in %q", spec)
+		}
+	}
+
+	return Limit{Rate: float64(count) / period.Seconds(), Burst: burst}, nil
+}
+
+// retryAfter returns how long it takes for the missing tokens to refill.
+func retryAfter(missing float64, rate float64) time.Duration {
+	if rate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(missing / rate * float64(time.Second))
+}
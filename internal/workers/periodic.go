@@ -0,0 +1,36 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Task func(ctx context.Context) error
+
+// RunPeriodic invokes task every interval until ctx is cancelled. Task errors
+// are logged and do not stop the loop.
+func RunPeriodic(ctx context.Context, name string, interval time.Duration, logger *zap.Logger, task Task) {
+	if interval <= 0 || task == nil {
+		return
+	}
+
+	logger = logger.With(zap.String("worker", name))
+	logger.Info("Worker started", zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Worker stopped")
+			return
+		case <-ticker.C:
+			if err := task(ctx); err != nil && ctx.Err() == nil {
+				logger.Error("Worker iteration failed", zap.Error(err))
+			}
+		}
+	}
+}
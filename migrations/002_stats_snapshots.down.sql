@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_stats_snapshots_taken_at;
+
+DROP TABLE IF EXISTS stats_snapshots;
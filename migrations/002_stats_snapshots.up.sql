@@ -0,0 +1,10 @@
+CREATE TABLE stats_snapshots (
+    taken_at TIMESTAMP NOT NULL,
+    metric VARCHAR NOT NULL,
+    team_name VARCHAR NOT NULL DEFAULT '',
+    value INTEGER NOT NULL,
+
+    PRIMARY KEY (metric, team_name, taken_at)
+);
+
+CREATE INDEX idx_stats_snapshots_taken_at ON stats_snapshots(taken_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_team_roles_user;
+
+DROP TABLE IF EXISTS team_roles;
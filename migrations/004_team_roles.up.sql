@@ -0,0 +1,10 @@
+CREATE TABLE team_roles (
+    team_name VARCHAR NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    user_id VARCHAR NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    role VARCHAR NOT NULL CHECK (role IN ('viewer', 'team-lead')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (team_name, user_id)
+);
+
+CREATE INDEX idx_team_roles_user ON team_roles(user_id);
@@ -0,0 +1,14 @@
+CREATE TABLE idempotency_keys (
+    owner VARCHAR NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR NOT NULL,
+    status_code INTEGER NULL,
+    content_type VARCHAR NULL,
+    response_body BYTEA NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (owner, idempotency_key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires ON idempotency_keys(expires_at);
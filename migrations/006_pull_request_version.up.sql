@@ -0,0 +1 @@
+ALTER TABLE pull_requests ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
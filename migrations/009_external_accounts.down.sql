@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_external_accounts_user;
+
+DROP TABLE IF EXISTS external_accounts;
+
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'CLOSED';
+
+ALTER TYPE pr_status_enum RENAME TO pr_status_enum_old;
+CREATE TYPE pr_status_enum AS ENUM ('OPEN', 'MERGED');
+ALTER TABLE pull_requests ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE pull_requests ALTER COLUMN status TYPE pr_status_enum USING status::text::pr_status_enum;
+ALTER TABLE pull_requests ALTER COLUMN status SET DEFAULT 'OPEN';
+DROP TYPE pr_status_enum_old;
@@ -0,0 +1,12 @@
+ALTER TYPE pr_status_enum ADD VALUE IF NOT EXISTS 'CLOSED';
+
+CREATE TABLE external_accounts (
+    provider VARCHAR NOT NULL CHECK (provider IN ('github', 'gitlab')),
+    login VARCHAR NOT NULL,
+    user_id VARCHAR NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (provider, login)
+);
+
+CREATE INDEX idx_external_accounts_user ON external_accounts(user_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_code_host_sync_jobs_due;
+
+DROP TABLE IF EXISTS code_host_sync_jobs;
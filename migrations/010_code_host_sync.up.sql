@@ -0,0 +1,12 @@
+CREATE TABLE code_host_sync_jobs (
+    pull_request_id VARCHAR PRIMARY KEY REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    removed_reviewers TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error TEXT NULL,
+    revision BIGINT NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_code_host_sync_jobs_due ON code_host_sync_jobs(next_attempt_at) WHERE status = 'pending';
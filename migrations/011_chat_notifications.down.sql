@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_chat_notifications_team;
+DROP INDEX IF EXISTS idx_chat_notifications_due;
+
+DROP TABLE IF EXISTS chat_notifications;
+DROP TABLE IF EXISTS chat_channels;
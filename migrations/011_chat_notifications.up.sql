@@ -0,0 +1,27 @@
+CREATE TABLE chat_channels (
+    team_name VARCHAR PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+    webhook_url VARCHAR NOT NULL,
+    channel VARCHAR NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE chat_notifications (
+    notification_id BIGSERIAL PRIMARY KEY,
+    team_name VARCHAR NOT NULL REFERENCES chat_channels(team_name) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL UNIQUE,
+    kind VARCHAR NOT NULL CHECK (kind IN ('assigned', 'replaced')),
+    pull_request_id VARCHAR NOT NULL,
+    pull_request_name VARCHAR NOT NULL,
+    author_id VARCHAR NOT NULL,
+    reviewer_id VARCHAR NULL,
+    old_reviewer_id VARCHAR NULL,
+    status VARCHAR NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error TEXT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP NULL
+);
+
+CREATE INDEX idx_chat_notifications_due ON chat_notifications(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_chat_notifications_team ON chat_notifications(team_name, next_attempt_at) WHERE status = 'pending';
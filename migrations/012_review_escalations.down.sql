@@ -0,0 +1,13 @@
+DELETE FROM chat_notifications WHERE kind = 'reminder' OR event_id IS NULL;
+
+ALTER TABLE chat_notifications DROP CONSTRAINT chat_notifications_kind_check;
+ALTER TABLE chat_notifications ADD CONSTRAINT chat_notifications_kind_check
+    CHECK (kind IN ('assigned', 'replaced'));
+ALTER TABLE chat_notifications DROP COLUMN IF EXISTS assigned_at;
+ALTER TABLE chat_notifications ALTER COLUMN event_id SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_pr_reviewers_assigned;
+DROP INDEX IF EXISTS idx_review_escalations_team;
+
+DROP TABLE IF EXISTS review_escalations;
+DROP TABLE IF EXISTS review_slas;
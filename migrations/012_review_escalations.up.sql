@@ -0,0 +1,31 @@
+CREATE TABLE review_slas (
+    team_name VARCHAR PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+    remind_after_seconds BIGINT NOT NULL CHECK (remind_after_seconds > 0),
+    escalate_after_seconds BIGINT NULL CHECK (escalate_after_seconds > remind_after_seconds),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- One row per action taken on an assignment; an assignment is identified by
+-- its pull request, reviewer and assigned_at, so a reviewer assigned again
+-- later starts with a clean slate.
+CREATE TABLE review_escalations (
+    escalation_id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    team_name VARCHAR NOT NULL,
+    reviewer_id VARCHAR NOT NULL,
+    assigned_at TIMESTAMP NOT NULL,
+    action VARCHAR NOT NULL CHECK (action IN ('reminded', 'reassigned', 'no_candidate')),
+    replaced_by VARCHAR NULL,
+    created_at TIMESTAMP NOT NULL,
+
+    UNIQUE (pull_request_id, reviewer_id, assigned_at, action)
+);
+
+CREATE INDEX idx_review_escalations_team ON review_escalations(team_name, escalation_id);
+CREATE INDEX idx_pr_reviewers_assigned ON pr_reviewers(assigned_at);
+
+ALTER TABLE chat_notifications ALTER COLUMN event_id DROP NOT NULL;
+ALTER TABLE chat_notifications ADD COLUMN assigned_at TIMESTAMP NULL;
+ALTER TABLE chat_notifications DROP CONSTRAINT chat_notifications_kind_check;
+ALTER TABLE chat_notifications ADD CONSTRAINT chat_notifications_kind_check
+    CHECK (kind IN ('assigned', 'replaced', 'reminder'));
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS review_digests;
+
+ALTER TABLE users DROP COLUMN IF EXISTS digest_enabled;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+ALTER TABLE users DROP COLUMN IF EXISTS email;
@@ -0,0 +1,13 @@
+ALTER TABLE users ADD COLUMN email VARCHAR NULL;
+ALTER TABLE users ADD COLUMN timezone VARCHAR NOT NULL DEFAULT 'UTC';
+ALTER TABLE users ADD COLUMN digest_enabled BOOLEAN NOT NULL DEFAULT true;
+
+-- One row per user and local calendar day the digest went out on.
+CREATE TABLE review_digests (
+    user_id VARCHAR NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    digest_date DATE NOT NULL,
+    review_count INT NOT NULL,
+    sent_at TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (user_id, digest_date)
+);
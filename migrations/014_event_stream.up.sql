@@ -0,0 +1,21 @@
+-- Positions are handed out by the relay, which publishes one batch at a time
+-- under an advisory lock, so a reader never sees a position appear below one
+-- it has already read. IDs do not have that property: they are taken when
+-- the writing transaction inserts, not when it commits.
+CREATE SEQUENCE outbox_stream_position;
+
+ALTER TABLE outbox_events ADD COLUMN stream_position BIGINT NULL;
+ALTER SEQUENCE outbox_stream_position OWNED BY outbox_events.stream_position;
+
+UPDATE outbox_events e
+SET stream_position = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (ORDER BY id) AS position
+    FROM outbox_events
+    WHERE published_at IS NOT NULL
+) ordered
+WHERE e.id = ordered.id;
+
+SELECT setval('outbox_stream_position', COALESCE((SELECT MAX(stream_position) FROM outbox_events), 0) + 1, false);
+
+CREATE UNIQUE INDEX idx_outbox_events_stream_position ON outbox_events(stream_position) WHERE stream_position IS NOT NULL;
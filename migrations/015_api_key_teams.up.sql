@@ -0,0 +1 @@
+ALTER TABLE api_keys ADD COLUMN team_names TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,6 @@
+DELETE FROM chat_notifications WHERE kind = 'digest';
+
+ALTER TABLE chat_notifications DROP CONSTRAINT chat_notifications_kind_check;
+ALTER TABLE chat_notifications ADD CONSTRAINT chat_notifications_kind_check
+    CHECK (kind IN ('assigned', 'replaced', 'reminder'));
+ALTER TABLE chat_notifications DROP COLUMN IF EXISTS review_count;
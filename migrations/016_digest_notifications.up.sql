@@ -0,0 +1,4 @@
+ALTER TABLE chat_notifications ADD COLUMN review_count INT NULL;
+ALTER TABLE chat_notifications DROP CONSTRAINT chat_notifications_kind_check;
+ALTER TABLE chat_notifications ADD CONSTRAINT chat_notifications_kind_check
+    CHECK (kind IN ('assigned', 'replaced', 'reminder', 'digest'));
@@ -3,6 +3,11 @@ import http from 'k6/http';
 
 const baseURL = __ENV.BASE_URL || 'http://localhost:8080';
 
+const headers = { 'Content-Type': 'application/json' };
+if (__ENV.API_KEY) {
+  headers.Authorization = `Bearer ${__ENV.API_KEY}`;
+}
+
 export const options = {
   stages: [
     { duration: '10s', target: 5 },
@@ -28,9 +33,7 @@ export function setup() {
     ],
   });
 
-  const teamRes = http.post(`${baseURL}/team/add`, teamPayload, {
-    headers: { 'Content-Type': 'application/json' },
-  });
+  const teamRes = http.post(`${baseURL}/team/add`, teamPayload, { headers });
   
   console.log(`Team creation result: ${teamRes.status}`);
   
@@ -58,11 +61,11 @@ export default function (data) {
     pull_request_id: prId,
     pull_request_name: `Load Test PR ${vuId}-${iter}`,
     author_id: "test-author",
-  }), { headers: { 'Content-Type': 'application/json' } });
+  }), { headers });
   
   check(prRes, { 'PR created': (r) => r.status === 201 });
 
-  const reviewRes = http.get(`${baseURL}/users/getReview?user_id=test-rev-1`);
+  const reviewRes = http.get(`${baseURL}/users/getReview?user_id=test-rev-1`, { headers });
   check(reviewRes, { 'assignments retrieved': (r) => r.status === 200 });
 
   sleep(0.1);
@@ -11,8 +11,10 @@ import (
 	adapterhttp "pr-reviewer-assignment/internal/adapters/input/http"
 	adapterdb "pr-reviewer-assignment/internal/adapters/output/database"
 	"pr-reviewer-assignment/internal/core/services"
+	"pr-reviewer-assignment/internal/health"
 	"pr-reviewer-assignment/internal/infrastructure"
 	"pr-reviewer-assignment/internal/infrastructure/database/postgres"
+	"pr-reviewer-assignment/internal/metrics"
 	helpers "pr-reviewer-assignment/tests/shared"
 
 	"github.com/gin-gonic/gin"
@@ -21,10 +23,11 @@ import (
 )
 
 var (
-	testSuite  *helpers.E2ESuite
-	testPool   *pgxpool.Pool
-	testServer *httptest.Server
-	testLogger = zap.NewNop()
+	testSuite   *helpers.E2ESuite
+	testPool    *pgxpool.Pool
+	testServer  *httptest.Server
+	testLogger  = zap.NewNop()
+	testMetrics *metrics.Metrics
 )
 
 func TestMain(m *testing.M) {
@@ -66,27 +69,53 @@ func TestMain(m *testing.M) {
 }
 
 func buildRouter(pool *pgxpool.Pool) *gin.Engine {
-	txManager := postgres.NewTransactionManager(pool, testLogger)
+	testMetrics = metrics.New()
+	testMetrics.RegisterPool(pool)
+
+	txManager := postgres.NewTransactionManager(pool, testLogger, testMetrics, testRetryPolicy)
 
 	teamRepo := adapterdb.NewTeamRepository(pool, testLogger)
 	userRepo := adapterdb.NewUserRepository(pool, testLogger)
 	prRepo := adapterdb.NewPullRequestRepository(pool, testLogger)
-
-	teamService := services.NewTeamService(teamRepo, userRepo, testLogger, txManager)
-	userService := services.NewUserService(userRepo, prRepo, testLogger)
-	prService := services.NewPullRequestService(prRepo, userRepo, teamRepo, testLogger, txManager)
-	statsService := services.NewStatsService(teamRepo, userRepo, prRepo)
-
-	healthHandler := adapterhttp.NewHealthHandler()
+	statsRepo := adapterdb.NewStatsRepository(pool, testLogger)
+	teamRoleRepo := adapterdb.NewTeamRoleRepository(pool, testLogger)
+	outboxRepo := adapterdb.NewOutboxRepository(pool, testLogger)
+
+	authz := services.NewAuthorizer(teamRoleRepo)
+
+	teamService := services.NewTeamService(teamRepo, userRepo, teamRoleRepo, testLogger, txManager, authz)
+	userService := services.NewUserService(userRepo, prRepo, outboxRepo, testLogger, txManager, authz)
+	prService := services.NewPullRequestService(prRepo, userRepo, teamRepo, outboxRepo, testLogger, txManager, nil, testMetrics, authz)
+	statsService := services.NewStatsService(teamRepo, userRepo, prRepo, statsRepo, txManager)
+
+	healthHandler := adapterhttp.NewHealthHandler(health.NewChecker(time.Second,
+		postgres.NewPingCheck(pool),
+		postgres.NewMigrationCheck(pool, postgres.SchemaVersion),
+		postgres.NewPoolSaturationCheck(pool, 0),
+	))
 	teamHandler := adapterhttp.NewTeamHandler(teamService, testLogger)
 	userHandler := adapterhttp.NewUserHandler(userService, testLogger)
 	prHandler := adapterhttp.NewPullRequestHandler(prService, testLogger)
 	statsHandler := adapterhttp.NewStatsHandler(statsService, testLogger)
 
-	return infrastructure.NewRouter(testLogger, healthHandler, teamHandler, userHandler, prHandler, statsHandler)
+	return infrastructure.NewRouter(infrastructure.RouterDeps{
+		Logger:      testLogger,
+		Metrics:     testMetrics,
+		Health:      healthHandler,
+		Team:        teamHandler,
+		User:        userHandler,
+		PullRequest: prHandler,
+		Stats:       statsHandler,
+	})
 }
 
 func resetTables(t testing.TB) {
 	t.Helper()
 	testSuite.ResetTables(t)
 }
+
+var testRetryPolicy = postgres.RetryPolicy{
+	MaxRetries: 10,
+	BaseDelay:  time.Millisecond,
+	MaxDelay:   50 * time.Millisecond,
+}
@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"pr-reviewer-assignment/internal/config"
+	"pr-reviewer-assignment/internal/dto"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testBootstrapKey = "bootstrap-secret"
+
+func bearer(token string) map[string]string {
+	return map[string]string{"Authorization": "Bearer " + token}
+}
+
+func TestAuth_APIKeyLifecycle(t *testing.T) {
+	resetTables(t)
+
+	suite := helpers.NewIntegrationSuite(buildRouter(testPool, routerOptions{
+		auth: config.AuthConfig{Enabled: true, BootstrapKey: testBootstrapKey},
+	}), testPool)
+
+	rec := suite.PerformRequest(t, http.MethodGet, "/team/get?team_name="+testTeamCore, nil)
+	suite.ExpectError(t, rec, http.StatusUnauthorized, "UNAUTHORIZED")
+	require.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+
+	rec = suite.PerformRequestWithHeaders(t, http.MethodGet, "/team/get?team_name="+testTeamCore, nil, bearer("prk_missing_secret"))
+	suite.ExpectError(t, rec, http.StatusUnauthorized, "UNAUTHORIZED")
+
+	rec = suite.PerformRequest(t, http.MethodGet, "/health/live", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = suite.PerformRequest(t, http.MethodGet, "/metrics", nil)
+	suite.ExpectError(t, rec, http.StatusUnauthorized, "UNAUTHORIZED")
+
+	rec = suite.PerformRequestWithHeaders(t, http.MethodPost, "/admin/apiKeys/create", map[string]any{
+		"name":   "ci-reader",
+		"scopes": []string{"read"},
+	}, bearer(testBootstrapKey))
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var created dto.CreateAPIKeyResponse
+	suite.DecodeBody(t, rec, &created)
+	require.NotEmpty(t, created.Token)
+	require.Equal(t, []string{"read"}, created.APIKey.Scopes)
+
+	rec = suite.PerformRequestWithHeaders(t, http.MethodGet, "/team/get?team_name="+testTeamCore, nil, bearer(created.Token))
+	suite.ExpectError(t, rec, http.StatusNotFound, "NOT_FOUND")
+
+	rec = suite.PerformRequestWithHeaders(t, http.MethodGet, "/metrics", nil, bearer(created.Token))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = suite.PerformRequestWithHeaders(t, http.MethodPost, "/team/add", map[string]any{
+		"team_name": testTeamCore,
+		"members":   helpers.NewTeamMembersBuilder().With(testAuthorID, "Author", true).Build(),
+	}, bearer(created.Token))
+	suite.ExpectError(t, rec, http.StatusForbidden, "FORBIDDEN")
+
+	rec = suite.PerformRequestWithHeaders(t, http.MethodGet, "/admin/apiKeys/list", nil, bearer(testBootstrapKey))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var listed dto.ListAPIKeysResponse
+	suite.DecodeBody(t, rec, &listed)
+	require.Len(t, listed.APIKeys, 1)
+	require.Equal(t, created.APIKey.KeyID, listed.APIKeys[0].KeyID)
+	require.NotNil(t, listed.APIKeys[0].LastUsedAt)
+
+	rec = suite.PerformRequestWithHeaders(t, http.MethodPost, "/admin/apiKeys/revoke", map[string]any{
+		"key_id": created.APIKey.KeyID,
+	}, bearer(testBootstrapKey))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = suite.PerformRequestWithHeaders(t, http.MethodGet, "/team/get?team_name="+testTeamCore, nil, bearer(created.Token))
+	suite.ExpectError(t, rec, http.StatusUnauthorized, "UNAUTHORIZED")
+}
+
+func TestAuth_CreateKeyValidation(t *testing.T) {
+	resetTables(t)
+
+	suite := helpers.NewIntegrationSuite(buildRouter(testPool, routerOptions{
+		auth: config.AuthConfig{Enabled: true, BootstrapKey: testBootstrapKey},
+	}), testPool)
+
+	cases := []struct {
+		name    string
+		payload map[string]any
+	}{
+		{name: "missing name", payload: map[string]any{"scopes": []string{"read"}}},
+		{name: "missing scopes", payload: map[string]any{"name": "empty"}},
+		{name: "unknown scope", payload: map[string]any{"name": "bad", "scopes": []string{"root"}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := suite.PerformRequestWithHeaders(t, http.MethodPost, "/admin/apiKeys/create", tc.payload, bearer(testBootstrapKey))
+			suite.ExpectError(t, rec, http.StatusBadRequest, "BAD_REQUEST")
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"pr-reviewer-assignment/internal/adapters/output/codehost"
+	adapterdb "pr-reviewer-assignment/internal/adapters/output/database"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	eventports "pr-reviewer-assignment/internal/core/ports/events"
+	"pr-reviewer-assignment/internal/core/services"
+	"pr-reviewer-assignment/internal/dto"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCodeHostSync(client eventports.CodeHostClient) *services.CodeHostSyncService {
+	return services.NewCodeHostSyncService(
+		adapterdb.NewCodeHostSyncRepository(testPool, testLogger),
+		adapterdb.NewPullRequestRepository(testPool, testLogger),
+		adapterdb.NewExternalAccountRepository(testPool, testLogger),
+		map[types.Provider]eventports.CodeHostClient{types.ProviderGitHub: client},
+		testLogger,
+		testWebhookPolicy,
+	)
+}
+
+func setupGitHubPullRequest(t *testing.T) (dto.PullRequestDTO, map[string]string) {
+	t.Helper()
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", true).
+		With("reviewer-3", "Dana", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+
+	logins := map[string]string{
+		"reviewer-1": "bob-gh",
+		"reviewer-2": "charlie-gh",
+		"reviewer-3": "dana-gh",
+	}
+	linkAccount(t, "github", "octo-author", testAuthorID)
+	for userID, login := range logins {
+		linkAccount(t, "github", login, userID)
+	}
+
+	result := deliverGitHubPullRequest(t, "pull_request_opened")
+	require.Equal(t, "created", result.Result)
+	require.Len(t, result.PR.AssignedReviewers, 2)
+
+	return *result.PR, logins
+}
+
+func loginsOf(logins map[string]string, userIDs []string) []string {
+	out := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		out = append(out, logins[userID])
+	}
+	return out
+}
+
+func TestCodeHostSync_RequestsAndWithdrawsReviewers(t *testing.T) {
+	resetTables(t)
+	ctx := context.Background()
+
+	client := codehost.NewFakeClient()
+	sync := newTestCodeHostSync(client)
+
+	pr, logins := setupGitHubPullRequest(t)
+
+	// Pull requests created through the API have no code host to sync with.
+	testSuite.CreatePullRequest(t, "PR-950", "Local only", testAuthorID)
+
+	_, err := newTestRelay(sync, 100).RelayPending(ctx)
+	require.NoError(t, err)
+
+	synced, err := sync.SyncDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, synced)
+
+	calls := client.Calls()
+	require.Len(t, calls, 1)
+	require.Equal(t, "request", calls[0].Method)
+	require.Equal(t, githubFixturePR, calls[0].Ref.ID())
+	require.ElementsMatch(t, loginsOf(logins, pr.AssignedReviewers), calls[0].Logins)
+
+	synced, err = sync.SyncDue(ctx)
+	require.NoError(t, err)
+	require.Zero(t, synced)
+
+	oldReviewer := pr.AssignedReviewers[0]
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/pullRequest/reassign", map[string]any{
+		"pull_request_id": githubFixturePR,
+		"old_user_id":     oldReviewer,
+	})
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var reassigned helpers.ReassignResponse
+	testSuite.DecodeBody(t, resp, &reassigned)
+
+	client.Reset()
+	_, err = newTestRelay(sync, 100).RelayPending(ctx)
+	require.NoError(t, err)
+
+	synced, err = sync.SyncDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, synced)
+
+	calls = client.Calls()
+	require.Len(t, calls, 2)
+	require.Equal(t, "request", calls[0].Method)
+	require.ElementsMatch(t, loginsOf(logins, reassigned.PR.AssignedReviewers), calls[0].Logins)
+	require.Contains(t, calls[0].Logins, logins[reassigned.ReplacedBy])
+	require.Equal(t, "remove", calls[1].Method)
+	require.Equal(t, []string{logins[oldReviewer]}, calls[1].Logins)
+
+	job, err := adapterdb.NewCodeHostSyncRepository(testPool, testLogger).Get(ctx, githubFixturePR)
+	require.NoError(t, err)
+	require.Equal(t, types.DeliveryDelivered, job.Status)
+	require.Empty(t, job.RemovedReviewers)
+}
+
+func TestCodeHostSync_RetriesAndGivesUp(t *testing.T) {
+	resetTables(t)
+	ctx := context.Background()
+
+	client := codehost.NewFakeClient()
+	sync := newTestCodeHostSync(client)
+	jobs := adapterdb.NewCodeHostSyncRepository(testPool, testLogger)
+
+	pr, _ := setupGitHubPullRequest(t)
+
+	_, err := newTestRelay(sync, 100).RelayPending(ctx)
+	require.NoError(t, err)
+
+	client.FailNext(1)
+	synced, err := sync.SyncDue(ctx)
+	require.NoError(t, err)
+	require.Zero(t, synced)
+
+	job, err := jobs.Get(ctx, githubFixturePR)
+	require.NoError(t, err)
+	require.Equal(t, types.DeliveryPending, job.Status)
+	require.Equal(t, 1, job.Attempts)
+	require.NotEmpty(t, job.LastError)
+
+	synced, err = sync.SyncDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, synced)
+	require.Len(t, client.Calls(), 2)
+
+	// A reassignment re-arms the delivered job; a rejection is final.
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/pullRequest/reassign", map[string]any{
+		"pull_request_id": githubFixturePR,
+		"old_user_id":     pr.AssignedReviewers[0],
+	})
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	_, err = newTestRelay(sync, 100).RelayPending(ctx)
+	require.NoError(t, err)
+
+	client.Reject(true)
+	synced, err = sync.SyncDue(ctx)
+	require.NoError(t, err)
+	require.Zero(t, synced)
+
+	job, err = jobs.Get(ctx, githubFixturePR)
+	require.NoError(t, err)
+	require.Equal(t, types.DeliveryDead, job.Status)
+	require.Equal(t, 1, job.Attempts)
+	require.Contains(t, job.LastError, "rejected")
+
+	synced, err = sync.SyncDue(ctx)
+	require.NoError(t, err)
+	require.Zero(t, synced)
+}
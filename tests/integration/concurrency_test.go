@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestEndpoints_ParallelCreatesStayBalanced(t *testing.T) {
+	resetTables(t)
+
+	const (
+		reviewers = 10
+		creates   = 200
+	)
+
+	builder := helpers.NewTeamMembersBuilder().With(testAuthorID, "Author", true)
+	for i := 1; i <= reviewers; i++ {
+		builder.With(fmt.Sprintf("reviewer-%02d", i), fmt.Sprintf("Reviewer %d", i), true)
+	}
+	testSuite.CreateTeam(t, testTeamCore, builder.Build())
+
+	var wg sync.WaitGroup
+	codes := make([]int, creates)
+	for i := 0; i < creates; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp := testSuite.PerformRequest(t, http.MethodPost, "/pullRequest/create", map[string]any{
+				"pull_request_id":   fmt.Sprintf("PR-C%03d", i),
+				"pull_request_name": "Parallel",
+				"author_id":         testAuthorID,
+			})
+			codes[i] = resp.Code
+		}(i)
+	}
+	wg.Wait()
+
+	for i, code := range codes {
+		require.Equal(t, http.StatusCreated, code, "create %d", i)
+	}
+
+	ctx := context.Background()
+
+	var malformed int
+	require.NoError(t, testPool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM (
+			SELECT pull_request_id
+			FROM pr_reviewers
+			GROUP BY pull_request_id
+			HAVING COUNT(DISTINCT user_id) <> 2 OR bool_or(user_id = $1)
+		) bad
+	`, testAuthorID).Scan(&malformed))
+	require.Zero(t, malformed)
+
+	rows, err := testPool.Query(ctx, `SELECT user_id, COUNT(*) FROM pr_reviewers GROUP BY user_id`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	total := 0
+	for rows.Next() {
+		var (
+			userID string
+			count  int
+		)
+		require.NoError(t, rows.Scan(&userID, &count))
+		counts[userID] = count
+		total += count
+	}
+	require.NoError(t, rows.Err())
+
+	require.Len(t, counts, reviewers)
+	require.Equal(t, creates*2, total)
+
+	// Every create locks the team and picks the least loaded reviewers, so
+	// the load never drifts apart by more than a single assignment.
+	lowest, highest := total, 0
+	for _, count := range counts {
+		lowest = min(lowest, count)
+		highest = max(highest, count)
+	}
+	require.LessOrEqual(t, highest-lowest, 1, "assignments per reviewer: %v", counts)
+}
@@ -0,0 +1,317 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	adapterdb "pr-reviewer-assignment/internal/adapters/output/database"
+	"pr-reviewer-assignment/internal/adapters/output/email"
+	"pr-reviewer-assignment/internal/clock"
+	"pr-reviewer-assignment/internal/core/ports/events"
+	"pr-reviewer-assignment/internal/core/services"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+type receivedMail struct {
+	from    string
+	to      []string
+	subject string
+	body    string
+}
+
+// fakeSMTPServer speaks just enough SMTP for net/smtp to deliver a message.
+type fakeSMTPServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	received []receivedMail
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &fakeSMTPServer{listener: listener}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+
+	return server
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	reply := func(line string) { _ = text.PrintfLine("%s", line) }
+
+	var current receivedMail
+	reply("220 fake ESMTP")
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "EHLO", "HELO":
+			reply("250 fake")
+		case "MAIL":
+			current = receivedMail{from: addressArg(line)}
+			reply("250 OK")
+		case "RCPT":
+			current.to = append(current.to, addressArg(line))
+			reply("250 OK")
+		case "DATA":
+			reply("354 go ahead")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.store(current, data)
+			reply("250 OK")
+		case "RSET", "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) store(envelope receivedMail, data []byte) {
+	if msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(string(data)))); err == nil {
+		envelope.subject = msg.Header.Get("Subject")
+		body, _ := io.ReadAll(quotedprintable.NewReader(msg.Body))
+		envelope.body = strings.ReplaceAll(string(body), "\r\n", "\n")
+	}
+
+	s.mu.Lock()
+	s.received = append(s.received, envelope)
+	s.mu.Unlock()
+}
+
+func (s *fakeSMTPServer) snapshot() []receivedMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]receivedMail(nil), s.received...)
+}
+
+func addressArg(line string) string {
+	start, end := strings.Index(line, "<"), strings.LastIndex(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+func setPreferences(t *testing.T, payload map[string]any) {
+	t.Helper()
+
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/users/setPreferences", payload)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+}
+
+func TestDigest_DailyPerUserTimezone(t *testing.T) {
+	resetTables(t)
+	ctx := context.Background()
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", true).
+		Build())
+
+	receiver, server := newWebhookReceiver(t)
+	setChatChannel(t, testTeamCore, server.URL, "")
+	smtpServer := newFakeSMTPServer(t)
+
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/users/setPreferences", map[string]any{
+		"user_id":  "reviewer-1",
+		"email":    "bob@example.com",
+		"timezone": "Asia/Tokyo",
+	})
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var updated helpers.UserResponse
+	testSuite.DecodeBody(t, resp, &updated)
+	require.Equal(t, "bob@example.com", updated.User.Email)
+	require.Equal(t, "Asia/Tokyo", updated.User.Timezone)
+	require.True(t, updated.User.DigestEnabled)
+
+	setPreferences(t, map[string]any{"user_id": "reviewer-2", "digest_enabled": false})
+
+	// 01:00 UTC is 10:00 in Tokyo.
+	fake := clock.NewFake(time.Date(2030, time.January, 15, 1, 0, 0, 0, time.UTC))
+	notifier := newTestNotifier(t, services.NotificationSettings{PullRequestURL: "https://reviews.example.com/pr/{id}", Clock: fake})
+	digests := services.NewDigestService(
+		adapterdb.NewDigestRepository(testPool, testLogger),
+		[]events.DigestSender{
+			notifier,
+			email.NewSMTPSender(email.SMTPSettings{Host: "127.0.0.1", Port: smtpServer.port(), From: "reviews@example.com"}),
+		},
+		testLogger,
+		services.DigestSettings{Hour: 9, PullRequestURL: "https://reviews.example.com/pr/{id}", Clock: fake},
+	)
+
+	pr := testSuite.CreatePullRequest(t, "PR-990", "Slow review", testAuthorID)
+	merged := testSuite.CreatePullRequest(t, "PR-991", "Done already", testAuthorID)
+	testSuite.MergePullRequest(t, merged.PullRequestID)
+
+	_, err := testPool.Exec(ctx, `UPDATE pull_requests SET created_at = $2 WHERE pull_request_id = $1`,
+		pr.PullRequestID, fake.Now().Add(-50*time.Hour))
+	require.NoError(t, err)
+	_, err = testPool.Exec(ctx, `UPDATE pr_reviewers SET assigned_at = $2 WHERE pull_request_id = $1`,
+		pr.PullRequestID, fake.Now().Add(-26*time.Hour))
+	require.NoError(t, err)
+
+	sent, err := digests.Run(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent, "only Bob has reached the digest hour and not opted out")
+
+	mails := smtpServer.snapshot()
+	require.Len(t, mails, 1)
+	require.Equal(t, "reviews@example.com", mails[0].from)
+	require.Equal(t, []string{"bob@example.com"}, mails[0].to)
+	require.Equal(t, "1 pull request awaits your review", mails[0].subject)
+	require.Equal(t, "Hi Bob,\n\n1 pull request awaits your review:\n\n"+
+		"- Slow review (PR-990) by Author, open for 2d 2h\n"+
+		"  https://reviews.example.com/pr/PR-990\n", mails[0].body)
+
+	require.Empty(t, receiver.snapshot(), "chat digests go through the notification queue")
+	delivered, err := notifier.SendDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, delivered)
+
+	messages := chatMessages(t, receiver)
+	require.Len(t, messages, 1)
+	require.Equal(t, "Bob, 1 pull request awaits your review, the oldest is "+
+		"<https://reviews.example.com/pr/PR-990|Slow review> by Author, waiting for 1d 2h", messages[0].Text)
+
+	sent, err = digests.Run(ctx)
+	require.NoError(t, err)
+	require.Zero(t, sent, "one digest per local day")
+
+	fake.Advance(9 * time.Hour)
+	sent, err = digests.Run(ctx)
+	require.NoError(t, err)
+	require.Zero(t, sent, "Charlie opted out")
+
+	setPreferences(t, map[string]any{"user_id": "reviewer-2", "digest_enabled": true})
+	receiver.status.Store(http.StatusServiceUnavailable)
+
+	sent, err = digests.Run(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent, "Charlie has no email, the chat digest is queued")
+	require.Len(t, smtpServer.snapshot(), 1)
+
+	delivered, err = notifier.SendDue(ctx)
+	require.NoError(t, err)
+	require.Zero(t, delivered, "the chat is down")
+
+	receiver.status.Store(http.StatusOK)
+	delivered, err = notifier.SendDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, delivered, "the queue retries the chat digest")
+
+	messages = chatMessages(t, receiver)
+	require.Contains(t, messages[len(messages)-1].Text, "Charlie, 1 pull request awaits your review")
+
+	sent, err = digests.Run(ctx)
+	require.NoError(t, err)
+	require.Zero(t, sent, "a queued digest is not sent again")
+
+	fake.Advance(15 * time.Hour)
+	sent, err = digests.Run(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent, "the next day starts in Tokyo first")
+	require.Len(t, smtpServer.snapshot(), 2)
+	require.Contains(t, smtpServer.snapshot()[1].body, "open for 3d 2h")
+}
+
+func TestDigest_PreferencesValidation(t *testing.T) {
+	resetTables(t)
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		Build())
+
+	setPreferences(t, map[string]any{"user_id": testAuthorID, "email": "author@example.com"})
+	setPreferences(t, map[string]any{"user_id": testAuthorID, "email": ""})
+
+	cases := []struct {
+		name       string
+		payload    map[string]any
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "missing user",
+			payload:    map[string]any{"timezone": "UTC"},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "nothing to change",
+			payload:    map[string]any{"user_id": testAuthorID},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "unknown timezone",
+			payload:    map[string]any{"user_id": testAuthorID, "timezone": "Mars/Olympus"},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "invalid email",
+			payload:    map[string]any{"user_id": testAuthorID, "email": "Author <author@example.com>"},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "unknown user",
+			payload:    map[string]any{"user_id": "ghost", "digest_enabled": false},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := testSuite.PerformRequest(t, http.MethodPost, "/users/setPreferences", tc.payload)
+			testSuite.ExpectError(t, resp, tc.wantStatus, tc.wantCode)
+		})
+	}
+
+}
@@ -0,0 +1,295 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	adapterdb "pr-reviewer-assignment/internal/adapters/output/database"
+	"pr-reviewer-assignment/internal/clock"
+	"pr-reviewer-assignment/internal/core/services"
+	"pr-reviewer-assignment/internal/dto"
+	"pr-reviewer-assignment/internal/infrastructure/database/postgres"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEscalations(t *testing.T, notifier *services.NotificationService, clk clock.Clock) *services.EscalationService {
+	t.Helper()
+
+	txManager := postgres.NewTransactionManager(testPool, testLogger, nil, testRetryPolicy)
+	pullRequests := services.NewPullRequestService(
+		adapterdb.NewPullRequestRepository(testPool, testLogger),
+		adapterdb.NewUserRepository(testPool, testLogger),
+		adapterdb.NewTeamRepository(testPool, testLogger),
+		adapterdb.NewOutboxRepository(testPool, testLogger),
+		testLogger,
+		txManager,
+		clk,
+		nil,
+		nil,
+	)
+
+	return services.NewEscalationService(
+		adapterdb.NewEscalationRepository(testPool, testLogger),
+		pullRequests,
+		notifier,
+		txManager,
+		clk,
+		testLogger,
+		nil,
+		0,
+	)
+}
+
+func setReviewSLA(t *testing.T, teamName, remindAfter, escalateAfter string) dto.ReviewSLADTO {
+	t.Helper()
+
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/escalations/sla/set", map[string]any{
+		"team_name":      teamName,
+		"remind_after":   remindAfter,
+		"escalate_after": escalateAfter,
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var result dto.ReviewSLAResponse
+	testSuite.DecodeBody(t, resp, &result)
+	return result.SLA
+}
+
+func assignedHoursAgo(t *testing.T, prID string, now time.Time, hours int) {
+	t.Helper()
+
+	_, err := testPool.Exec(context.Background(),
+		`UPDATE pr_reviewers SET assigned_at = $2 WHERE pull_request_id = $1`,
+		prID, now.Add(-time.Duration(hours)*time.Hour))
+	require.NoError(t, err)
+}
+
+func listEscalations(t *testing.T, query string) []dto.ReviewEscalationDTO {
+	t.Helper()
+
+	resp := testSuite.PerformRequest(t, http.MethodGet, "/escalations/list"+query, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var result dto.ListReviewEscalationsResponse
+	testSuite.DecodeBody(t, resp, &result)
+	return result.Escalations
+}
+
+func TestEscalations_RemindThenReassign(t *testing.T) {
+	resetTables(t)
+	ctx := context.Background()
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", false).
+		Build())
+
+	receiver, server := newWebhookReceiver(t)
+	setChatChannel(t, testTeamCore, server.URL, "")
+
+	sla := setReviewSLA(t, testTeamCore, "24h", "48h")
+	require.Equal(t, "24h0m0s", sla.RemindAfter)
+	require.Equal(t, "48h0m0s", sla.EscalateAfter)
+
+	fake := clock.NewFake(time.Now().UTC())
+	notifier := newTestNotifier(t, services.NotificationSettings{Clock: fake})
+	escalations := newTestEscalations(t, notifier, fake)
+
+	pr := testSuite.CreatePullRequest(t, "PR-980", "Slow review", testAuthorID)
+	require.Equal(t, []string{"reviewer-1"}, pr.AssignedReviewers)
+
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/users/setIsActive", map[string]any{
+		"user_id":   "reviewer-2",
+		"is_active": true,
+	})
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	taken, err := escalations.Run(ctx)
+	require.NoError(t, err)
+	require.Zero(t, taken, "a fresh review is within the SLA")
+
+	assignedHoursAgo(t, pr.PullRequestID, fake.Now(), 25)
+
+	taken, err = escalations.Run(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, taken)
+
+	taken, err = escalations.Run(ctx)
+	require.NoError(t, err)
+	require.Zero(t, taken, "a reminder is sent once per assignment")
+
+	sent, err := notifier.SendDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
+	messages := chatMessages(t, receiver)
+	require.Len(t, messages, 1)
+	require.Equal(t, "Bob, Slow review by Author has been waiting for your review for 1d 1h", messages[0].Text)
+
+	fake.Advance(24 * time.Hour)
+
+	taken, err = escalations.Run(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, taken)
+
+	var reviewers []string
+	err = testPool.QueryRow(ctx,
+		`SELECT array_agg(user_id) FROM pr_reviewers WHERE pull_request_id = $1`, pr.PullRequestID).Scan(&reviewers)
+	require.NoError(t, err)
+	require.Equal(t, []string{"reviewer-2"}, reviewers)
+
+	recorded := listEscalations(t, "?pull_request_id="+pr.PullRequestID)
+	require.Len(t, recorded, 2)
+	require.Equal(t, "reassigned", recorded[0].Action)
+	require.Equal(t, "reviewer-1", recorded[0].ReviewerID)
+	require.Equal(t, "reviewer-2", recorded[0].ReplacedBy)
+	require.Equal(t, "reminded", recorded[1].Action)
+	require.Empty(t, recorded[1].ReplacedBy)
+	require.Equal(t, recorded[0].AssignedAt, recorded[1].AssignedAt)
+
+	var assignedAt time.Time
+	err = testPool.QueryRow(ctx,
+		`SELECT assigned_at FROM pr_reviewers WHERE pull_request_id = $1`, pr.PullRequestID).Scan(&assignedAt)
+	require.NoError(t, err)
+	require.WithinDuration(t, fake.Now(), assignedAt, time.Millisecond, "the replacement is assigned on the service clock")
+
+	fake.Advance(25 * time.Hour)
+
+	taken, err = escalations.Run(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, taken, "the replacement's SLA runs on the same clock")
+
+	recorded = listEscalations(t, "?pull_request_id="+pr.PullRequestID)
+	require.Len(t, recorded, 3)
+	require.Equal(t, "reminded", recorded[0].Action)
+	require.Equal(t, "reviewer-2", recorded[0].ReviewerID)
+}
+
+func TestEscalations_NoCandidateAndMergedPullRequests(t *testing.T) {
+	resetTables(t)
+	ctx := context.Background()
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build())
+	setReviewSLA(t, testTeamCore, "1h", "2h")
+
+	fake := clock.NewFake(time.Now().UTC())
+	notifier := newTestNotifier(t, services.NotificationSettings{Clock: fake})
+	escalations := newTestEscalations(t, notifier, fake)
+
+	stuck := testSuite.CreatePullRequest(t, "PR-981", "Nobody else", testAuthorID)
+	merged := testSuite.CreatePullRequest(t, "PR-982", "Already merged", testAuthorID)
+	testSuite.MergePullRequest(t, merged.PullRequestID)
+	assignedHoursAgo(t, stuck.PullRequestID, fake.Now(), 3)
+	assignedHoursAgo(t, merged.PullRequestID, fake.Now(), 3)
+
+	taken, err := escalations.Run(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, taken, "the reviewer is reminded before being replaced")
+
+	taken, err = escalations.Run(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, taken)
+
+	taken, err = escalations.Run(ctx)
+	require.NoError(t, err)
+	require.Zero(t, taken)
+
+	recorded := listEscalations(t, "?team_name="+testTeamCore)
+	require.Len(t, recorded, 2)
+	require.Equal(t, stuck.PullRequestID, recorded[0].PullRequestID)
+	require.Equal(t, "no_candidate", recorded[0].Action)
+	require.Empty(t, recorded[0].ReplacedBy)
+	require.Equal(t, "reminded", recorded[1].Action)
+
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/escalations/sla/remove", map[string]any{
+		"team_name": testTeamCore,
+	})
+	require.Equal(t, http.StatusNoContent, resp.Code)
+
+	resp = testSuite.PerformRequest(t, http.MethodGet, "/escalations/sla/list", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var list dto.ListReviewSLAsResponse
+	testSuite.DecodeBody(t, resp, &list)
+	require.Empty(t, list.SLAs)
+}
+
+func TestEscalations_ValidationErrors(t *testing.T) {
+	resetTables(t)
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		Build())
+
+	cases := []struct {
+		name       string
+		method     string
+		path       string
+		payload    any
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "unparsable remind_after",
+			method:     http.MethodPost,
+			path:       "/escalations/sla/set",
+			payload:    map[string]any{"team_name": testTeamCore, "remind_after": "a day"},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "remind_after below a minute",
+			method:     http.MethodPost,
+			path:       "/escalations/sla/set",
+			payload:    map[string]any{"team_name": testTeamCore, "remind_after": "30s"},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "escalation before reminder",
+			method:     http.MethodPost,
+			path:       "/escalations/sla/set",
+			payload:    map[string]any{"team_name": testTeamCore, "remind_after": "24h", "escalate_after": "12h"},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "unknown team",
+			method:     http.MethodPost,
+			path:       "/escalations/sla/set",
+			payload:    map[string]any{"team_name": "ghost-team", "remind_after": "24h"},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+		{
+			name:       "remove missing sla",
+			method:     http.MethodPost,
+			path:       "/escalations/sla/remove",
+			payload:    map[string]any{"team_name": testTeamCore},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+		{
+			name:       "invalid limit",
+			method:     http.MethodGet,
+			path:       "/escalations/list?limit=-1",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := testSuite.PerformRequest(t, tc.method, tc.path, tc.payload)
+			testSuite.ExpectError(t, resp, tc.wantStatus, tc.wantCode)
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	adapterhttp "pr-reviewer-assignment/internal/adapters/input/http"
+	adapterdb "pr-reviewer-assignment/internal/adapters/output/database"
+	"pr-reviewer-assignment/internal/core/services"
+	"pr-reviewer-assignment/internal/dto"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type streamedEvent struct {
+	id    int64
+	event string
+	data  dto.EventDTO
+}
+
+// streamInstance is one service instance with its own stream service, as if
+// it ran in a separate process next to the one behind testRouter.
+type streamInstance struct {
+	service *services.EventStreamService
+	server  *httptest.Server
+}
+
+func newStreamInstance(t *testing.T) *streamInstance {
+	t.Helper()
+
+	service := services.NewEventStreamService(
+		adapterdb.NewOutboxRepository(testPool, testLogger),
+		adapterdb.NewTeamRepository(testPool, testLogger),
+		adapterdb.NewUserRepository(testPool, testLogger),
+		testLogger,
+		0,
+	)
+	require.NoError(t, service.Poll(context.Background()))
+
+	router := gin.New()
+	router.GET("/events/stream", adapterhttp.NewEventStreamHandler(service, time.Hour, testLogger).Stream)
+	server := httptest.NewServer(router)
+
+	t.Cleanup(func() {
+		service.Close()
+		server.Close()
+	})
+
+	return &streamInstance{service: service, server: server}
+}
+
+func (i *streamInstance) poll(t *testing.T) {
+	t.Helper()
+	require.NoError(t, i.service.Poll(context.Background()))
+}
+
+// openStream connects and returns once the response headers arrive, by
+// which time the subscription is registered.
+func (i *streamInstance) openStream(t *testing.T, query string, headers map[string]string) <-chan streamedEvent {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, i.server.URL+"/events/stream?"+query, nil)
+	require.NoError(t, err)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan streamedEvent, 64)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		var current streamedEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" {
+				if current.event != "" {
+					events <- current
+				}
+				current = streamedEvent{}
+				continue
+			}
+
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "id":
+				current.id, _ = strconv.ParseInt(value, 10, 64)
+			case "event":
+				current.event = value
+			case "data":
+				_ = json.Unmarshal([]byte(value), &current.data)
+			}
+		}
+	}()
+
+	return events
+}
+
+func nextStreamed(t *testing.T, events <-chan streamedEvent) streamedEvent {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "stream ended")
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+		return streamedEvent{}
+	}
+}
+
+func relayAll(t *testing.T) {
+	t.Helper()
+
+	_, err := newTestRelay(&recordingPublisher{}, 100).RelayPending(context.Background())
+	require.NoError(t, err)
+}
+
+func setActive(t *testing.T, userID string, active bool) {
+	t.Helper()
+
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/users/setIsActive", map[string]any{
+		"user_id":   userID,
+		"is_active": active,
+	})
+	require.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestEventStream_FiltersAndReachesEveryInstance(t *testing.T) {
+	resetTables(t)
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", true).
+		Build())
+	testSuite.CreateTeam(t, testTeamPlatform, helpers.NewTeamMembersBuilder().
+		With("platform-author", "Eve", true).
+		With("platform-reviewer", "Frank", true).
+		Build())
+
+	first, second := newStreamInstance(t), newStreamInstance(t)
+	coreStream := first.openStream(t, "team_name="+testTeamCore, nil)
+	bobStream := second.openStream(t, "user_id=reviewer-1", nil)
+	platformStream := second.openStream(t, "team_name="+testTeamPlatform, nil)
+
+	corePR := testSuite.CreatePullRequest(t, "PR-1000", "Core change", testAuthorID)
+	platformPR := testSuite.CreatePullRequest(t, "PR-1001", "Platform change", "platform-author")
+	relayAll(t)
+	first.poll(t)
+	second.poll(t)
+
+	var lastID int64
+	for range 2 {
+		event := nextStreamed(t, coreStream)
+		require.Equal(t, "reviewer.assigned", event.event)
+		require.Equal(t, corePR.PullRequestID, event.data.PullRequest.PullRequestID)
+		require.Greater(t, event.id, lastID)
+		lastID = event.id
+	}
+
+	event := nextStreamed(t, bobStream)
+	require.Equal(t, "reviewer.assigned", event.event)
+	require.Equal(t, corePR.PullRequestID, event.data.PullRequest.PullRequestID)
+
+	event = nextStreamed(t, platformStream)
+	require.Equal(t, "reviewer.assigned", event.event)
+	require.Equal(t, platformPR.PullRequestID, event.data.PullRequest.PullRequestID)
+	require.Equal(t, "platform-reviewer", event.data.ReviewerID)
+
+	testSuite.MergePullRequest(t, corePR.PullRequestID)
+	setActive(t, "reviewer-2", false)
+	setActive(t, "reviewer-2", true)
+	relayAll(t)
+	first.poll(t)
+	second.poll(t)
+
+	var streamed []string
+	for range 3 {
+		event := nextStreamed(t, coreStream)
+		require.Greater(t, event.id, lastID)
+		lastID = event.id
+		streamed = append(streamed, event.event)
+	}
+	require.Equal(t, []string{"pull_request.merged", "user.deactivated", "user.activated"}, streamed)
+
+	// Charlie's activity changes do not involve Bob; after his assignments he
+	// only sees the merge of the pull request he reviews.
+	for {
+		event = nextStreamed(t, bobStream)
+		if event.event != "reviewer.assigned" {
+			break
+		}
+	}
+	require.Equal(t, "pull_request.merged", event.event)
+
+	testSuite.MergePullRequest(t, platformPR.PullRequestID)
+	relayAll(t)
+	second.poll(t)
+
+	event = nextStreamed(t, platformStream)
+	require.Equal(t, "pull_request.merged", event.event, "core activity changes stay out of the platform stream")
+}
+
+func TestEventStream_ResumesFromLastEventID(t *testing.T) {
+	resetTables(t)
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", true).
+		Build())
+
+	instance := newStreamInstance(t)
+	stream := instance.openStream(t, "team_name="+testTeamCore, nil)
+
+	pr := testSuite.CreatePullRequest(t, "PR-1010", "Resume", testAuthorID)
+	relayAll(t)
+	instance.poll(t)
+
+	firstAssigned := nextStreamed(t, stream)
+	secondAssigned := nextStreamed(t, stream)
+
+	// The client drops here and misses the merge and a deactivation. It
+	// reconnects before the instance polls them, so they arrive both from the
+	// replay and live.
+	testSuite.MergePullRequest(t, pr.PullRequestID)
+	setActive(t, "reviewer-1", false)
+	relayAll(t)
+
+	resumed := instance.openStream(t, "team_name="+testTeamCore,
+		map[string]string{"Last-Event-ID": strconv.FormatInt(firstAssigned.id, 10)})
+	instance.poll(t)
+
+	replayed := []streamedEvent{nextStreamed(t, resumed), nextStreamed(t, resumed), nextStreamed(t, resumed)}
+	require.Equal(t, secondAssigned.id, replayed[0].id)
+	require.Equal(t, "pull_request.merged", replayed[1].event)
+	require.Equal(t, "user.deactivated", replayed[2].event)
+	require.Equal(t, "reviewer-1", replayed[2].data.UserID)
+
+	setActive(t, "reviewer-1", true)
+	relayAll(t)
+	instance.poll(t)
+
+	live := nextStreamed(t, resumed)
+	require.Equal(t, "user.activated", live.event, "replayed events are not repeated live")
+	require.Greater(t, live.id, replayed[2].id)
+
+	fromQuery := instance.openStream(t, "last_event_id="+strconv.FormatInt(replayed[2].id, 10)+"&user_id=reviewer-1", nil)
+	event := nextStreamed(t, fromQuery)
+	require.Equal(t, live.id, event.id)
+}
+
+func TestEventStream_ValidationErrors(t *testing.T) {
+	resetTables(t)
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		Build())
+
+	cases := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "unknown team",
+			query:      "team_name=ghost-team",
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+		{
+			name:       "unknown user",
+			query:      "user_id=ghost",
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+		{
+			name:       "malformed last event id",
+			query:      "last_event_id=latest",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "negative last event id",
+			query:      "last_event_id=-1",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := testSuite.PerformRequest(t, http.MethodGet, "/events/stream?"+tc.query, nil)
+			testSuite.ExpectError(t, resp, tc.wantStatus, tc.wantCode)
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"pr-reviewer-assignment/internal/dto"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+const githubFixturePR = "github:acme/widgets#42"
+
+// deliverGitHub replays a recorded GitHub delivery from testdata/github,
+// signed the way GitHub signs it.
+func deliverGitHub(t *testing.T, event, fixture, secret string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", "github", fixture+".json"))
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	req := httptest.NewRequest(http.MethodPost, "/integrations/github/webhook", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	rec := httptest.NewRecorder()
+	testRouter.ServeHTTP(rec, req)
+	return rec
+}
+
+func deliverGitHubPullRequest(t *testing.T, fixture string) dto.SyncResponse {
+	t.Helper()
+
+	resp := deliverGitHub(t, "pull_request", fixture, testGitHubSecret)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var result dto.SyncResponse
+	testSuite.DecodeBody(t, resp, &result)
+	return result
+}
+
+func linkAccount(t *testing.T, provider, login, userID string) {
+	t.Helper()
+
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/integrations/accounts/link", map[string]any{
+		"provider": provider,
+		"login":    login,
+		"user_id":  userID,
+	})
+	require.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestGitHubWebhook_PullRequestLifecycle(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", true).
+		With("reviewer-3", "Dana", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+	linkAccount(t, "github", "octo-author", testAuthorID)
+
+	resp := deliverGitHub(t, "ping", "ping", testGitHubSecret)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	result := deliverGitHubPullRequest(t, "pull_request_opened_draft")
+	require.Equal(t, "ignored", result.Result)
+	require.Nil(t, result.PR)
+
+	result = deliverGitHubPullRequest(t, "pull_request_ready_for_review")
+	require.Equal(t, "created", result.Result)
+	require.NotNil(t, result.PR)
+	require.Equal(t, githubFixturePR, result.PR.PullRequestID)
+	require.Equal(t, "Add retry budget to the payment client", result.PR.PullRequestName)
+	require.Equal(t, testAuthorID, result.PR.AuthorID)
+	require.Equal(t, "OPEN", result.PR.Status)
+	require.Len(t, result.PR.AssignedReviewers, 2)
+	reviewers := result.PR.AssignedReviewers
+
+	// GitHub redelivers events; a repeated open must not create a second PR.
+	result = deliverGitHubPullRequest(t, "pull_request_opened")
+	require.Equal(t, "ignored", result.Result)
+	require.Equal(t, reviewers, result.PR.AssignedReviewers)
+
+	result = deliverGitHubPullRequest(t, "pull_request_labeled")
+	require.Equal(t, "ignored", result.Result)
+
+	result = deliverGitHubPullRequest(t, "pull_request_closed")
+	require.Equal(t, "closed", result.Result)
+	require.Equal(t, "CLOSED", result.PR.Status)
+
+	resp = testSuite.PerformRequest(t, http.MethodPost, "/pullRequest/reassign", map[string]any{
+		"pull_request_id": githubFixturePR,
+		"old_user_id":     reviewers[0],
+	})
+	testSuite.ExpectError(t, resp, http.StatusConflict, "PR_CLOSED")
+
+	result = deliverGitHubPullRequest(t, "pull_request_reopened")
+	require.Equal(t, "reopened", result.Result)
+	require.Equal(t, "OPEN", result.PR.Status)
+	require.Equal(t, reviewers, result.PR.AssignedReviewers)
+
+	result = deliverGitHubPullRequest(t, "pull_request_merged")
+	require.Equal(t, "merged", result.Result)
+	require.Equal(t, "MERGED", result.PR.Status)
+	require.NotNil(t, result.PR.MergedAt)
+
+	result = deliverGitHubPullRequest(t, "pull_request_merged")
+	require.Equal(t, "merged", result.Result)
+
+	var recorded []string
+	rows, err := testPool.Query(context.Background(), `SELECT event_type FROM outbox_events WHERE event_type LIKE 'pull_request.%' ORDER BY id`)
+	require.NoError(t, err)
+	for rows.Next() {
+		var eventType string
+		require.NoError(t, rows.Scan(&eventType))
+		recorded = append(recorded, eventType)
+	}
+	rows.Close()
+	require.Equal(t, []string{
+		"pull_request.created",
+		"pull_request.closed",
+		"pull_request.reopened",
+		"pull_request.merged",
+	}, recorded)
+}
+
+func TestGitHubWebhook_Rejections(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+
+	resp := deliverGitHub(t, "pull_request", "pull_request_opened", "wrong-secret")
+	testSuite.ExpectError(t, resp, http.StatusUnauthorized, "UNAUTHORIZED")
+
+	resp = deliverGitHub(t, "pull_request", "pull_request_opened", testGitHubSecret)
+	testSuite.ExpectError(t, resp, http.StatusNotFound, "NOT_FOUND")
+
+	result := deliverGitHubPullRequest(t, "pull_request_merged")
+	require.Equal(t, "ignored", result.Result)
+	require.Nil(t, result.PR)
+
+	resp = deliverGitHub(t, "issues", "pull_request_opened", testGitHubSecret)
+	require.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestIntegrationAccounts(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+
+	linkAccount(t, "GitHub", " Octo-Author ", testAuthorID)
+	linkAccount(t, "gitlab", "author.one", testAuthorID)
+
+	resp := testSuite.PerformRequest(t, http.MethodGet, "/integrations/accounts/list?provider=github", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var list dto.ListExternalAccountsResponse
+	testSuite.DecodeBody(t, resp, &list)
+	require.Len(t, list.Accounts, 1)
+	require.Equal(t, "github", list.Accounts[0].Provider)
+	require.Equal(t, "octo-author", list.Accounts[0].Login)
+	require.Equal(t, testAuthorID, list.Accounts[0].UserID)
+
+	resp = testSuite.PerformRequest(t, http.MethodPost, "/integrations/accounts/unlink", map[string]any{
+		"provider": "github",
+		"login":    "OCTO-AUTHOR",
+	})
+	require.Equal(t, http.StatusNoContent, resp.Code)
+
+	resp = testSuite.PerformRequest(t, http.MethodGet, "/integrations/accounts/list", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	testSuite.DecodeBody(t, resp, &list)
+	require.Len(t, list.Accounts, 1)
+	require.Equal(t, "gitlab", list.Accounts[0].Provider)
+
+	cases := []struct {
+		name       string
+		path       string
+		payload    map[string]any
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "unknown provider",
+			path:       "/integrations/accounts/link",
+			payload:    map[string]any{"provider": "bitbucket", "login": "x", "user_id": testAuthorID},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "missing login",
+			path:       "/integrations/accounts/link",
+			payload:    map[string]any{"provider": "github", "user_id": testAuthorID},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "unknown user",
+			path:       "/integrations/accounts/link",
+			payload:    map[string]any{"provider": "github", "login": "ghost", "user_id": "ghost"},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+		{
+			name:       "unlink unknown login",
+			path:       "/integrations/accounts/unlink",
+			payload:    map[string]any{"provider": "github", "login": "ghost"},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := testSuite.PerformRequest(t, http.MethodPost, tc.path, tc.payload)
+			testSuite.ExpectError(t, resp, tc.wantStatus, tc.wantCode)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"pr-reviewer-assignment/internal/dto"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+const gitlabFixturePR = "gitlab:platform/billing!7"
+
+// deliverGitLab replays a recorded GitLab delivery from testdata/gitlab.
+func deliverGitLab(t *testing.T, event, fixture, token string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", "gitlab", fixture+".json"))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/integrations/gitlab/webhook", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", event)
+	req.Header.Set("X-Gitlab-Token", token)
+
+	rec := httptest.NewRecorder()
+	testRouter.ServeHTTP(rec, req)
+	return rec
+}
+
+func deliverGitLabMergeRequest(t *testing.T, fixture string) dto.SyncResponse {
+	t.Helper()
+
+	resp := deliverGitLab(t, "Merge Request Hook", fixture, testGitLabToken)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var result dto.SyncResponse
+	testSuite.DecodeBody(t, resp, &result)
+	return result
+}
+
+func TestGitLabWebhook_MergeRequestLifecycle(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+	linkAccount(t, "gitlab", "author.one", testAuthorID)
+	linkAccount(t, "gitlab", "bob", "reviewer-1")
+	linkAccount(t, "github", "charlie-gh", "reviewer-2")
+
+	result := deliverGitLabMergeRequest(t, "merge_request_open_draft")
+	require.Equal(t, "ignored", result.Result)
+	require.Nil(t, result.PR)
+
+	result = deliverGitLabMergeRequest(t, "merge_request_update_ready")
+	require.Equal(t, "created", result.Result)
+	require.NotNil(t, result.PR)
+	require.Equal(t, gitlabFixturePR, result.PR.PullRequestID)
+	require.Equal(t, "Split invoice renderer", result.PR.PullRequestName)
+	require.Equal(t, testAuthorID, result.PR.AuthorID)
+	require.ElementsMatch(t, []string{"reviewer-1", "reviewer-2"}, result.PR.AssignedReviewers)
+	require.ElementsMatch(t, []dto.ReviewerAccountDTO{
+		{UserID: "reviewer-1", Login: "bob"},
+		{UserID: "reviewer-2"},
+	}, result.Reviewers)
+
+	result = deliverGitLabMergeRequest(t, "merge_request_update_title")
+	require.Equal(t, "ignored", result.Result)
+
+	result = deliverGitLabMergeRequest(t, "merge_request_update_draft")
+	require.Equal(t, "ignored", result.Result)
+	require.Equal(t, "OPEN", result.PR.Status)
+
+	result = deliverGitLabMergeRequest(t, "merge_request_close")
+	require.Equal(t, "closed", result.Result)
+	require.Equal(t, "CLOSED", result.PR.Status)
+
+	result = deliverGitLabMergeRequest(t, "merge_request_reopen")
+	require.Equal(t, "reopened", result.Result)
+	require.Equal(t, "OPEN", result.PR.Status)
+	require.Len(t, result.Reviewers, 2)
+
+	result = deliverGitLabMergeRequest(t, "merge_request_merge")
+	require.Equal(t, "merged", result.Result)
+	require.Equal(t, "MERGED", result.PR.Status)
+}
+
+func TestGitLabWebhook_ReadyByAnotherUserIsIgnored(t *testing.T) {
+	resetTables(t)
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build())
+	linkAccount(t, "gitlab", "author.one", testAuthorID)
+	linkAccount(t, "gitlab", "release.maintainer", "reviewer-1")
+
+	result := deliverGitLabMergeRequest(t, "merge_request_update_ready_by_maintainer")
+	require.Equal(t, "ignored", result.Result)
+	require.Equal(t, "event does not identify the pull request author", result.Reason)
+	require.Nil(t, result.PR)
+
+	result = deliverGitLabMergeRequest(t, "merge_request_update_ready")
+	require.Equal(t, "created", result.Result)
+	require.Equal(t, testAuthorID, result.PR.AuthorID)
+}
+
+func TestGitLabWebhook_Rejections(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+
+	resp := deliverGitLab(t, "Merge Request Hook", "merge_request_open", "wrong-token")
+	testSuite.ExpectError(t, resp, http.StatusUnauthorized, "UNAUTHORIZED")
+
+	resp = deliverGitLab(t, "Merge Request Hook", "merge_request_open", "")
+	testSuite.ExpectError(t, resp, http.StatusUnauthorized, "UNAUTHORIZED")
+
+	resp = deliverGitLab(t, "Merge Request Hook", "merge_request_open", testGitLabToken)
+	testSuite.ExpectError(t, resp, http.StatusNotFound, "NOT_FOUND")
+
+	resp = deliverGitLab(t, "Push Hook", "merge_request_open", testGitLabToken)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	result := deliverGitLabMergeRequest(t, "merge_request_close")
+	require.Equal(t, "ignored", result.Result)
+	require.Nil(t, result.PR)
+}
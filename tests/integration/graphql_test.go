@@ -0,0 +1,439 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+
+	adaptergraphql "pr-reviewer-assignment/internal/adapters/input/graphql"
+	adapterdb "pr-reviewer-assignment/internal/adapters/output/database"
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	serviceports "pr-reviewer-assignment/internal/core/ports/services"
+	"pr-reviewer-assignment/internal/core/services"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type graphqlError struct {
+	Message    string `json:"message"`
+	Extensions struct {
+		Code string `json:"code"`
+	} `json:"extensions"`
+}
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphqlError  `json:"errors"`
+}
+
+type graphqlUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	TeamName string `json:"teamName"`
+	IsActive bool   `json:"isActive"`
+	Team     *struct {
+		Name    string        `json:"name"`
+		Members []graphqlUser `json:"members"`
+	} `json:"team"`
+	Reviews []graphqlPullRequest `json:"reviews"`
+}
+
+type graphqlPullRequest struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Status    string        `json:"status"`
+	MergedAt  *string       `json:"mergedAt"`
+	Author    *graphqlUser  `json:"author"`
+	Reviewers []graphqlUser `json:"reviewers"`
+}
+
+type graphqlTeam struct {
+	Name    string        `json:"name"`
+	Members []graphqlUser `json:"members"`
+}
+
+func performGraphQL(t *testing.T, suite *helpers.IntegrationSuite, query string, variables map[string]any) (int, graphqlResponse, []byte) {
+	t.Helper()
+
+	rec := suite.PerformRequest(t, http.MethodPost, "/graphql", map[string]any{
+		"query":     query,
+		"variables": variables,
+	})
+
+	var resp graphqlResponse
+	suite.DecodeBody(t, rec, &resp)
+	return rec.Code, resp, rec.Body.Bytes()
+}
+
+func requireGraphQLError(t *testing.T, suite *helpers.IntegrationSuite, query string, wantStatus int, wantCode string) {
+	t.Helper()
+
+	status, resp, body := performGraphQL(t, suite, query, nil)
+	require.Equal(t, wantStatus, status, string(body))
+	require.NotEmpty(t, resp.Errors, string(body))
+	require.Equal(t, wantCode, resp.Errors[0].Extensions.Code, string(body))
+}
+
+func userIDs(users []graphqlUser) []string {
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
+
+func usernames(users []graphqlUser) []string {
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		names = append(names, user.Username)
+	}
+	return names
+}
+
+func reviewIDs(prs []graphqlPullRequest) []string {
+	ids := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		ids = append(ids, pr.ID)
+	}
+	return ids
+}
+
+// countingDashboard records how often each batch lookup reaches the service.
+type countingDashboard struct {
+	serviceports.DashboardService
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (d *countingDashboard) count(method string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.calls[method]++
+}
+
+func (d *countingDashboard) snapshot() map[string]int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	calls := make(map[string]int, len(d.calls))
+	for method, n := range d.calls {
+		calls[method] = n
+	}
+	return calls
+}
+
+func (d *countingDashboard) TeamsByName(ctx context.Context, names []string) (map[string]*entities.Team, error) {
+	d.count("TeamsByName")
+	return d.DashboardService.TeamsByName(ctx, names)
+}
+
+func (d *countingDashboard) UsersByID(ctx context.Context, ids []string) (map[string]*entities.User, error) {
+	d.count("UsersByID")
+	return d.DashboardService.UsersByID(ctx, ids)
+}
+
+func (d *countingDashboard) PullRequestsByID(ctx context.Context, ids []string) (map[string]*entities.PullRequest, error) {
+	d.count("PullRequestsByID")
+	return d.DashboardService.PullRequestsByID(ctx, ids)
+}
+
+func (d *countingDashboard) ReviewsByUser(ctx context.Context, ids []string) (map[string][]*entities.PullRequest, error) {
+	d.count("ReviewsByUser")
+	return d.DashboardService.ReviewsByUser(ctx, ids)
+}
+
+func TestGraphQL_TeamsUsersPullRequestsAndStats(t *testing.T) {
+	resetTables(t)
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", true).
+		Build())
+	testSuite.CreateTeam(t, testTeamPlatform, helpers.NewTeamMembersBuilder().
+		With("platform-1", "Dana", true).
+		Build())
+
+	testSuite.CreatePullRequest(t, "PR-1", "Add search", testAuthorID)
+	testSuite.CreatePullRequest(t, "PR-2", "Fix login", testAuthorID)
+	testSuite.MergePullRequest(t, "PR-2")
+
+	status, resp, body := performGraphQL(t, testSuite, `
+		query Dashboard($team: String!) {
+			team(name: $team) {
+				name
+				members {
+					id
+					username
+					isActive
+					reviews { id status author { username } reviewers { id } }
+					open: reviews(status: OPEN) { id }
+				}
+			}
+			ghost: team(name: "ghost") { name }
+			stats { teams users pullRequests assignments }
+		}`, map[string]any{"team": testTeamCore})
+	require.Equal(t, http.StatusOK, status, string(body))
+	require.Empty(t, resp.Errors, string(body))
+
+	var dashboard struct {
+		Team *struct {
+			Name    string `json:"name"`
+			Members []struct {
+				graphqlUser
+				Open []graphqlPullRequest `json:"open"`
+			} `json:"members"`
+		} `json:"team"`
+		Ghost *graphqlTeam `json:"ghost"`
+		Stats struct {
+			Teams        int `json:"teams"`
+			Users        int `json:"users"`
+			PullRequests int `json:"pullRequests"`
+			Assignments  int `json:"assignments"`
+		} `json:"stats"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &dashboard))
+
+	require.Nil(t, dashboard.Ghost)
+	require.NotNil(t, dashboard.Team)
+	require.Equal(t, testTeamCore, dashboard.Team.Name)
+	require.Len(t, dashboard.Team.Members, 3)
+	require.Equal(t, "Author", dashboard.Team.Members[0].Username)
+	require.Empty(t, dashboard.Team.Members[0].Reviews)
+
+	for _, member := range dashboard.Team.Members[1:] {
+		require.True(t, member.IsActive)
+		require.ElementsMatch(t, []string{"PR-1", "PR-2"}, reviewIDs(member.Reviews))
+		require.Equal(t, []string{"PR-1"}, reviewIDs(member.Open))
+
+		for _, review := range member.Reviews {
+			require.Equal(t, "Author", review.Author.Username)
+			require.ElementsMatch(t, []string{"reviewer-1", "reviewer-2"}, userIDs(review.Reviewers))
+		}
+	}
+
+	require.Equal(t, 2, dashboard.Stats.Teams)
+	require.Equal(t, 4, dashboard.Stats.Users)
+	require.Equal(t, 2, dashboard.Stats.PullRequests)
+	require.Equal(t, 4, dashboard.Stats.Assignments)
+
+	status, resp, body = performGraphQL(t, testSuite, `
+		query Lookup($pr: ID!, $user: ID!) {
+			pullRequest(id: $pr) { id name status mergedAt author { team { name } } reviewers { username } }
+			missing: pullRequest(id: "PR-404") { id }
+			user(id: $user) { teamName team { members { username } } }
+		}`, map[string]any{"pr": "PR-2", "user": "platform-1"})
+	require.Equal(t, http.StatusOK, status, string(body))
+	require.Empty(t, resp.Errors, string(body))
+
+	var lookup struct {
+		PullRequest *graphqlPullRequest `json:"pullRequest"`
+		Missing     *graphqlPullRequest `json:"missing"`
+		User        *graphqlUser        `json:"user"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &lookup))
+
+	require.Nil(t, lookup.Missing)
+	require.NotNil(t, lookup.PullRequest)
+	require.Equal(t, "Fix login", lookup.PullRequest.Name)
+	require.Equal(t, "MERGED", lookup.PullRequest.Status)
+	require.NotNil(t, lookup.PullRequest.MergedAt)
+	require.Equal(t, testTeamCore, lookup.PullRequest.Author.Team.Name)
+	require.ElementsMatch(t, []string{"Bob", "Charlie"}, usernames(lookup.PullRequest.Reviewers))
+
+	require.NotNil(t, lookup.User)
+	require.Equal(t, testTeamPlatform, lookup.User.TeamName)
+	require.Equal(t, []string{"Dana"}, usernames(lookup.User.Team.Members))
+
+	params := url.Values{"query": {`{ stats { teams } }`}}
+	rec := testSuite.PerformRequest(t, http.MethodGet, "/graphql?"+params.Encode(), nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.JSONEq(t, `{"data":{"stats":{"teams":2}}}`, rec.Body.String())
+}
+
+func TestGraphQL_BatchesLookupsPerRequest(t *testing.T) {
+	resetTables(t)
+
+	for _, team := range []struct{ name, prefix string }{{testTeamCore, "core"}, {testTeamPlatform, "platform"}} {
+		testSuite.CreateTeam(t, team.name, helpers.NewTeamMembersBuilder().
+			With(team.prefix+"-1", team.prefix+" one", true).
+			With(team.prefix+"-2", team.prefix+" two", true).
+			With(team.prefix+"-3", team.prefix+" three", true).
+			Build())
+
+		for i := 1; i <= 3; i++ {
+			testSuite.CreatePullRequest(t, fmt.Sprintf("%s-PR-%d", team.prefix, i), "Change", fmt.Sprintf("%s-%d", team.prefix, i))
+		}
+	}
+
+	dashboard := &countingDashboard{
+		DashboardService: services.NewDashboardService(
+			adapterdb.NewTeamRepository(testPool, testLogger),
+			adapterdb.NewUserRepository(testPool, testLogger),
+			adapterdb.NewPullRequestRepository(testPool, testLogger),
+		),
+		calls: make(map[string]int),
+	}
+	handler, err := adaptergraphql.NewHandler(dashboard, testStatsService, testGraphQLLimits, testLogger)
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/graphql", handler.Serve)
+	suite := helpers.NewIntegrationSuite(router, testPool)
+
+	query := `{
+		teams {
+			name
+			members {
+				username
+				reviews { id author { username team { name } } reviewers { username } }
+			}
+		}
+	}`
+
+	status, resp, body := performGraphQL(t, suite, query, nil)
+	require.Equal(t, http.StatusOK, status, string(body))
+	require.Empty(t, resp.Errors, string(body))
+
+	var result struct {
+		Teams []struct {
+			Name    string        `json:"name"`
+			Members []graphqlUser `json:"members"`
+		} `json:"teams"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &result))
+	require.Len(t, result.Teams, 2)
+
+	assignments := 0
+	for _, team := range result.Teams {
+		require.Len(t, team.Members, 3)
+		for _, member := range team.Members {
+			for _, review := range member.Reviews {
+				require.NotNil(t, review.Author)
+				require.Equal(t, team.Name, review.Author.Team.Name)
+				require.Contains(t, usernames(review.Reviewers), member.Username)
+			}
+			assignments += len(member.Reviews)
+		}
+	}
+	require.Equal(t, 12, assignments, "every pull request has two reviewers")
+
+	// One lookup per level: members come with their teams, every member's
+	// reviews in one call, and the authors and reviewers of all of them in
+	// another; the authors' teams are already loaded.
+	require.Equal(t, map[string]int{
+		"TeamsByName":   1,
+		"ReviewsByUser": 1,
+		"UsersByID":     1,
+	}, dashboard.snapshot())
+
+	status, _, body = performGraphQL(t, suite, query, nil)
+	require.Equal(t, http.StatusOK, status, string(body))
+	require.Equal(t, map[string]int{
+		"TeamsByName":   2,
+		"ReviewsByUser": 2,
+		"UsersByID":     2,
+	}, dashboard.snapshot(), "loaders do not outlive the request")
+}
+
+func TestGraphQL_RejectsInvalidAndExpensiveQueries(t *testing.T) {
+	resetTables(t)
+
+	cases := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "empty query",
+			query:      "",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "syntax error",
+			query:      `{ team(name: `,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "unknown field",
+			query:      `{ stats { velocity } }`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "too deep",
+			query:      `{ user(id: "u") { team { members { reviews { author { team { name } } } } } } }`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "QUERY_TOO_DEEP",
+		},
+		{
+			name:       "too costly",
+			query:      `{ teams { members { reviews { reviewers { reviews { id } } } } } }`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "QUERY_TOO_COSTLY",
+		},
+		{
+			name: "too costly through fragments",
+			query: `
+				fragment Reviews on User { reviews { reviewers { reviews { id } } } }
+				{ teams { members { ...Reviews } } }`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "QUERY_TOO_COSTLY",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			requireGraphQLError(t, testSuite, tc.query, tc.wantStatus, tc.wantCode)
+		})
+	}
+
+	status, resp, body := performGraphQL(t, testSuite, `{ __schema { queryType { name } types { name fields { name } } } }`, nil)
+	require.Equal(t, http.StatusOK, status, string(body))
+	require.Empty(t, resp.Errors, "introspection does not count against the limits")
+}
+
+func TestGraphQL_IgnoresIdempotencyKey(t *testing.T) {
+	resetTables(t)
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With("reviewer-1", "Bob", true).
+		Build())
+
+	query := map[string]any{"query": `{ user(id: "reviewer-1") { isActive } }`}
+	withKey := map[string]string{"Idempotency-Key": "dashboard-poll"}
+
+	isActive := func() bool {
+		rec := testSuite.PerformRequestWithHeaders(t, http.MethodPost, "/graphql", query, withKey)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.Empty(t, rec.Header().Get("Idempotent-Replayed"))
+
+		var resp struct {
+			Data struct {
+				User graphqlUser `json:"user"`
+			} `json:"data"`
+		}
+		testSuite.DecodeBody(t, rec, &resp)
+		return resp.Data.User.IsActive
+	}
+
+	require.True(t, isActive())
+
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/users/setIsActive", map[string]any{
+		"user_id":   "reviewer-1",
+		"is_active": false,
+	})
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	require.False(t, isActive(), "a query is never answered from the idempotency store")
+}
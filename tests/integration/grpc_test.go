@@ -0,0 +1,354 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	reviewerv1 "pr-reviewer-assignment/api/proto/reviewer/v1"
+	adaptergrpc "pr-reviewer-assignment/internal/adapters/input/grpc"
+	adapterdb "pr-reviewer-assignment/internal/adapters/output/database"
+	"pr-reviewer-assignment/internal/config"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/core/services"
+	"pr-reviewer-assignment/internal/infrastructure/database/postgres"
+	"pr-reviewer-assignment/internal/ratelimit"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type grpcClients struct {
+	team  reviewerv1.TeamServiceClient
+	user  reviewerv1.UserServiceClient
+	pr    reviewerv1.PullRequestServiceClient
+	stats reviewerv1.StatsServiceClient
+
+	health grpc_health_v1.HealthClient
+
+	apiKeys *services.APIKeyService
+}
+
+// newGRPCClients serves the gRPC API over an in-memory listener, backed by
+// the same services the REST router uses.
+func newGRPCClients(t *testing.T, auth config.AuthConfig, limiter *adaptergrpc.RateLimiter) *grpcClients {
+	t.Helper()
+
+	txManager := postgres.NewTransactionManager(testPool, testLogger, nil, testRetryPolicy)
+
+	teamRepo := adapterdb.NewTeamRepository(testPool, testLogger)
+	userRepo := adapterdb.NewUserRepository(testPool, testLogger)
+	prRepo := adapterdb.NewPullRequestRepository(testPool, testLogger)
+	outboxRepo := adapterdb.NewOutboxRepository(testPool, testLogger)
+	authz := services.NewAuthorizer(adapterdb.NewTeamRoleRepository(testPool, testLogger))
+	apiKeys := services.NewAPIKeyService(adapterdb.NewAPIKeyRepository(testPool, testLogger), testLogger, auth.BootstrapKey)
+
+	server := adaptergrpc.NewServer(adaptergrpc.ServerDeps{
+		Logger:      testLogger,
+		Auth:        adaptergrpc.NewAuthenticator(apiKeys, nil, auth.Enabled, testLogger),
+		Limiter:     limiter,
+		Team:        services.NewTeamService(teamRepo, userRepo, adapterdb.NewTeamRoleRepository(testPool, testLogger), testLogger, txManager, authz),
+		User:        services.NewUserService(userRepo, prRepo, outboxRepo, testLogger, txManager, authz),
+		PullRequest: services.NewPullRequestService(prRepo, userRepo, teamRepo, outboxRepo, testLogger, txManager, nil, nil, authz),
+		Stats:       services.NewStatsService(teamRepo, userRepo, prRepo, adapterdb.NewStatsRepository(testPool, testLogger), txManager),
+	})
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	return &grpcClients{
+		team:    reviewerv1.NewTeamServiceClient(conn),
+		user:    reviewerv1.NewUserServiceClient(conn),
+		pr:      reviewerv1.NewPullRequestServiceClient(conn),
+		stats:   reviewerv1.NewStatsServiceClient(conn),
+		health:  grpc_health_v1.NewHealthClient(conn),
+		apiKeys: apiKeys,
+	}
+}
+
+// requireGRPCError checks the status code and the REST error code carried
+// in the ErrorInfo detail.
+func requireGRPCError(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	require.True(t, ok, "not a gRPC status: %v", err)
+	require.Equal(t, code, st.Code(), st.Message())
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			require.Equal(t, reason, info.GetReason())
+			return
+		}
+	}
+	t.Fatalf("status %v has no ErrorInfo detail", st)
+}
+
+func withBearer(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func TestGRPC_TeamsUsersAndPullRequests(t *testing.T) {
+	resetTables(t)
+	ctx := context.Background()
+	clients := newGRPCClients(t, config.AuthConfig{}, nil)
+
+	created, err := clients.team.CreateTeam(ctx, &reviewerv1.CreateTeamRequest{
+		TeamName: testTeamCore,
+		Members: []*reviewerv1.TeamMember{
+			{UserId: testAuthorID, Username: "Author", IsActive: true},
+			{UserId: "reviewer-1", Username: "Bob", IsActive: true},
+			{UserId: "reviewer-2", Username: "Charlie", IsActive: true},
+			{UserId: "reviewer-3", Username: "Dana", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, created.GetTeam().GetMembers(), 4)
+
+	_, err = clients.team.CreateTeam(ctx, &reviewerv1.CreateTeamRequest{TeamName: testTeamCore})
+	requireGRPCError(t, err, codes.AlreadyExists, "TEAM_EXISTS")
+
+	team, err := clients.team.GetTeam(ctx, &reviewerv1.GetTeamRequest{TeamName: testTeamCore})
+	require.NoError(t, err)
+	require.Equal(t, testTeamCore, team.GetTeam().GetTeamName())
+
+	_, err = clients.team.GetTeam(ctx, &reviewerv1.GetTeamRequest{TeamName: "ghost-team"})
+	requireGRPCError(t, err, codes.NotFound, "NOT_FOUND")
+
+	role, err := clients.team.SetMemberRole(ctx, &reviewerv1.SetMemberRoleRequest{TeamName: testTeamCore, UserId: "reviewer-1", Role: "team-lead"})
+	require.NoError(t, err)
+	require.Equal(t, "team-lead", role.GetRole().GetRole())
+
+	_, err = clients.team.SetMemberRole(ctx, &reviewerv1.SetMemberRoleRequest{TeamName: testTeamCore, UserId: "reviewer-1", Role: "owner"})
+	requireGRPCError(t, err, codes.InvalidArgument, "BAD_REQUEST")
+
+	roles, err := clients.team.ListMemberRoles(ctx, &reviewerv1.ListMemberRolesRequest{TeamName: testTeamCore})
+	require.NoError(t, err)
+	require.Len(t, roles.GetRoles(), 1)
+
+	_, err = clients.team.RemoveMemberRole(ctx, &reviewerv1.RemoveMemberRoleRequest{TeamName: testTeamCore, UserId: "reviewer-1"})
+	require.NoError(t, err)
+
+	pr, err := clients.pr.CreatePullRequest(ctx, &reviewerv1.CreatePullRequestRequest{
+		PullRequestId:   "PR-1100",
+		PullRequestName: "Over gRPC",
+		AuthorId:        testAuthorID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "OPEN", pr.GetPr().GetStatus())
+	require.Len(t, pr.GetPr().GetAssignedReviewers(), 2)
+	require.NotNil(t, pr.GetPr().GetCreatedAt())
+	require.Nil(t, pr.GetPr().GetMergedAt())
+
+	_, err = clients.pr.CreatePullRequest(ctx, &reviewerv1.CreatePullRequestRequest{
+		PullRequestId:   "PR-1100",
+		PullRequestName: "Again",
+		AuthorId:        testAuthorID,
+	})
+	requireGRPCError(t, err, codes.AlreadyExists, "PR_EXISTS")
+
+	reviewer := pr.GetPr().GetAssignedReviewers()[0]
+	review, err := clients.user.GetReview(ctx, &reviewerv1.GetReviewRequest{UserId: reviewer})
+	require.NoError(t, err)
+	require.Len(t, review.GetPullRequests(), 1)
+	require.Equal(t, "PR-1100", review.GetPullRequests()[0].GetPullRequestId())
+
+	_, err = clients.pr.ReassignReviewer(ctx, &reviewerv1.ReassignReviewerRequest{
+		PullRequestId:   "PR-1100",
+		OldUserId:       reviewer,
+		ExpectedVersion: pr.GetPr().GetVersion() + 1,
+	})
+	requireGRPCError(t, err, codes.Aborted, "CONFLICT")
+
+	reassigned, err := clients.pr.ReassignReviewer(ctx, &reviewerv1.ReassignReviewerRequest{
+		PullRequestId:   "PR-1100",
+		OldUserId:       reviewer,
+		ExpectedVersion: pr.GetPr().GetVersion(),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, reassigned.GetReplacedBy())
+	require.NotContains(t, reassigned.GetPr().GetAssignedReviewers(), reviewer)
+
+	_, err = clients.pr.ReassignReviewer(ctx, &reviewerv1.ReassignReviewerRequest{PullRequestId: "PR-1100", OldUserId: testAuthorID})
+	requireGRPCError(t, err, codes.FailedPrecondition, "NOT_ASSIGNED")
+
+	merged, err := clients.pr.MergePullRequest(ctx, &reviewerv1.MergePullRequestRequest{PullRequestId: "PR-1100"})
+	require.NoError(t, err)
+	require.Equal(t, "MERGED", merged.GetPr().GetStatus())
+	require.NotNil(t, merged.GetPr().GetMergedAt())
+
+	_, err = clients.pr.ReassignReviewer(ctx, &reviewerv1.ReassignReviewerRequest{
+		PullRequestId: "PR-1100",
+		OldUserId:     reassigned.GetReplacedBy(),
+	})
+	requireGRPCError(t, err, codes.FailedPrecondition, "PR_MERGED")
+
+	user, err := clients.user.SetIsActive(ctx, &reviewerv1.SetIsActiveRequest{UserId: "reviewer-3", IsActive: false})
+	require.NoError(t, err)
+	require.False(t, user.GetUser().GetIsActive())
+
+	timezone := "Europe/Berlin"
+	prefs, err := clients.user.SetPreferences(ctx, &reviewerv1.SetPreferencesRequest{UserId: "reviewer-3", Timezone: &timezone})
+	require.NoError(t, err)
+	require.Equal(t, timezone, prefs.GetUser().GetTimezone())
+	require.True(t, prefs.GetUser().GetDigestEnabled(), "fields that are not set stay as they were")
+
+	_, err = clients.user.SetPreferences(ctx, &reviewerv1.SetPreferencesRequest{UserId: "reviewer-3"})
+	requireGRPCError(t, err, codes.InvalidArgument, "BAD_REQUEST")
+
+	_, err = clients.user.SetIsActive(ctx, &reviewerv1.SetIsActiveRequest{UserId: "ghost", IsActive: true})
+	requireGRPCError(t, err, codes.NotFound, "NOT_FOUND")
+}
+
+func TestGRPC_Stats(t *testing.T) {
+	resetTables(t)
+	ctx := context.Background()
+	clients := newGRPCClients(t, config.AuthConfig{}, nil)
+
+	_, err := clients.team.CreateTeam(ctx, &reviewerv1.CreateTeamRequest{
+		TeamName: testTeamCore,
+		Members: []*reviewerv1.TeamMember{
+			{UserId: testAuthorID, Username: "Author", IsActive: true},
+			{UserId: "reviewer-1", Username: "Bob", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = clients.pr.CreatePullRequest(ctx, &reviewerv1.CreatePullRequestRequest{
+		PullRequestId:   "PR-1110",
+		PullRequestName: "Stats",
+		AuthorId:        testAuthorID,
+	})
+	require.NoError(t, err)
+
+	stats, err := clients.stats.GetStats(ctx, &reviewerv1.GetStatsRequest{})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, stats.GetStats().GetTeams())
+	require.EqualValues(t, 2, stats.GetStats().GetUsers())
+	require.EqualValues(t, 1, stats.GetStats().GetPullRequests())
+	require.EqualValues(t, 1, stats.GetStats().GetAssignments())
+
+	fairness, err := clients.stats.GetFairness(ctx, &reviewerv1.GetFairnessRequest{TeamName: testTeamCore})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, fairness.GetFairness().GetTotalAssignments())
+	require.Nil(t, fairness.GetFairness().GetFrom())
+
+	now := time.Now()
+	_, err = clients.stats.GetFairness(ctx, &reviewerv1.GetFairnessRequest{
+		TeamName: testTeamCore,
+		From:     timestamppb.New(now),
+		To:       timestamppb.New(now.Add(-time.Hour)),
+	})
+	requireGRPCError(t, err, codes.InvalidArgument, "BAD_REQUEST")
+
+	require.NoError(t, testStatsService.TakeSnapshot(ctx))
+	history, err := clients.stats.GetStatsHistory(ctx, &reviewerv1.GetStatsHistoryRequest{
+		Metric: "pull_requests",
+		From:   timestamppb.New(now.Add(-time.Hour)),
+		To:     timestamppb.New(now.Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "hour", history.GetBucket())
+	require.NotEmpty(t, history.GetPoints())
+	require.EqualValues(t, 1, history.GetPoints()[len(history.GetPoints())-1].GetLast())
+
+	_, err = clients.stats.GetStatsHistory(ctx, &reviewerv1.GetStatsHistoryRequest{Metric: "reviews"})
+	requireGRPCError(t, err, codes.InvalidArgument, "BAD_REQUEST")
+}
+
+func TestGRPC_Authentication(t *testing.T) {
+	resetTables(t)
+	ctx := context.Background()
+	clients := newGRPCClients(t, config.AuthConfig{Enabled: true, BootstrapKey: testBootstrapKey}, nil)
+
+	_, err := clients.team.GetTeam(ctx, &reviewerv1.GetTeamRequest{TeamName: testTeamCore})
+	requireGRPCError(t, err, codes.Unauthenticated, "UNAUTHORIZED")
+
+	_, err = clients.team.GetTeam(withBearer(ctx, "prk_missing_secret"), &reviewerv1.GetTeamRequest{TeamName: testTeamCore})
+	requireGRPCError(t, err, codes.Unauthenticated, "UNAUTHORIZED")
+
+	_, err = clients.team.CreateTeam(withBearer(ctx, testBootstrapKey), &reviewerv1.CreateTeamRequest{
+		TeamName: testTeamCore,
+		Members:  []*reviewerv1.TeamMember{{UserId: testAuthorID, Username: "Author", IsActive: true}},
+	})
+	require.NoError(t, err)
+
+	_, token, err := clients.apiKeys.CreateKey(withBearer(ctx, testBootstrapKey), "grpc-reader", []types.Scope{types.ScopeRead}, nil)
+	require.NoError(t, err)
+
+	team, err := clients.team.GetTeam(withBearer(ctx, token), &reviewerv1.GetTeamRequest{TeamName: testTeamCore})
+	require.NoError(t, err)
+	require.Equal(t, testTeamCore, team.GetTeam().GetTeamName())
+
+	_, err = clients.pr.CreatePullRequest(withBearer(ctx, token), &reviewerv1.CreatePullRequestRequest{
+		PullRequestId:   "PR-1120",
+		PullRequestName: "Read-only caller",
+		AuthorId:        testAuthorID,
+	})
+	requireGRPCError(t, err, codes.PermissionDenied, "FORBIDDEN")
+}
+
+func TestGRPC_HealthIsPublicAndCallsAreRateLimited(t *testing.T) {
+	resetTables(t)
+	ctx := context.Background()
+
+	rules, err := ratelimit.ParseRules("100/s", "POST /pullRequest/create=1/h")
+	require.NoError(t, err)
+	limiter := adaptergrpc.NewRateLimiter(ratelimit.NewMemoryStore(nil), rules, testLogger)
+	clients := newGRPCClients(t, config.AuthConfig{Enabled: true, BootstrapKey: testBootstrapKey}, limiter)
+
+	health, err := clients.health.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, health.GetStatus())
+
+	admin := withBearer(ctx, testBootstrapKey)
+	_, err = clients.team.CreateTeam(admin, &reviewerv1.CreateTeamRequest{
+		TeamName: testTeamCore,
+		Members: []*reviewerv1.TeamMember{
+			{UserId: testAuthorID, Username: "Author", IsActive: true},
+			{UserId: "reviewer-1", Username: "Bob", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = clients.pr.CreatePullRequest(admin, &reviewerv1.CreatePullRequestRequest{
+		PullRequestId:   "PR-1130",
+		PullRequestName: "First",
+		AuthorId:        testAuthorID,
+	})
+	require.NoError(t, err)
+
+	var trailer metadata.MD
+	_, err = clients.pr.CreatePullRequest(admin, &reviewerv1.CreatePullRequestRequest{
+		PullRequestId:   "PR-1131",
+		PullRequestName: "Second",
+		AuthorId:        testAuthorID,
+	}, grpc.Trailer(&trailer))
+	requireGRPCError(t, err, codes.ResourceExhausted, "RATE_LIMITED")
+	require.NotEmpty(t, trailer.Get("retry-after"))
+
+	_, err = clients.team.GetTeam(admin, &reviewerv1.GetTeamRequest{TeamName: testTeamCore})
+	require.NoError(t, err, "other methods use the default limit")
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	adapterhttp "pr-reviewer-assignment/internal/adapters/input/http"
+	"pr-reviewer-assignment/internal/health"
+	"pr-reviewer-assignment/internal/infrastructure"
+	"pr-reviewer-assignment/internal/infrastructure/database/postgres"
+
+	"github.com/stretchr/testify/require"
+)
+
+type readinessResponse struct {
+	Status string                   `json:"status"`
+	Checks map[string]health.Result `json:"checks"`
+}
+
+func TestHealthEndpoints_LiveAndReady(t *testing.T) {
+	resp := testSuite.PerformRequest(t, http.MethodGet, "/health/live", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	resp = testSuite.PerformRequest(t, http.MethodGet, "/health/ready", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var parsed readinessResponse
+	testSuite.DecodeBody(t, resp, &parsed)
+	require.Equal(t, "ready", parsed.Status)
+
+	for _, name := range []string{"database", "migrations", "pool"} {
+		require.Contains(t, parsed.Checks, name)
+		require.Equal(t, health.StatusOK, parsed.Checks[name].Status, parsed.Checks[name].Error)
+	}
+	require.Contains(t, parsed.Checks["pool"].Details, "saturation")
+}
+
+func TestHealthEndpoints_ReadyOnNewerSchema(t *testing.T) {
+	router := infrastructure.NewRouter(infrastructure.RouterDeps{
+		Health: adapterhttp.NewHealthHandler(health.NewChecker(time.Second, postgres.NewMigrationCheck(testPool, postgres.SchemaVersion-1))),
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+func TestHealthEndpoints_NotReady(t *testing.T) {
+	cases := []struct {
+		name        string
+		checker     func() *health.Checker
+		failedCheck string
+	}{
+		{
+			name: "schema behind the code",
+			checker: func() *health.Checker {
+				return health.NewChecker(time.Second, postgres.NewMigrationCheck(testPool, postgres.SchemaVersion+1))
+			},
+			failedCheck: "migrations",
+		},
+		{
+			name: "shutting down",
+			checker: func() *health.Checker {
+				checker := health.NewChecker(time.Second, postgres.NewPingCheck(testPool))
+				checker.BeginShutdown()
+				return checker
+			},
+			failedCheck: "shutdown",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := infrastructure.NewRouter(infrastructure.RouterDeps{
+				Health: adapterhttp.NewHealthHandler(tc.checker()),
+			})
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+			require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+			var parsed readinessResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &parsed))
+			require.Equal(t, "not_ready", parsed.Status)
+			require.Equal(t, health.StatusFail, parsed.Checks[tc.failedCheck].Status)
+			require.NotEmpty(t, parsed.Checks[tc.failedCheck].Error)
+
+			rec = httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+			require.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
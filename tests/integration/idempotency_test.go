@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"pr-reviewer-assignment/internal/adapters/output/database"
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	resetTables(t)
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build())
+
+	payload := map[string]any{
+		"pull_request_id":   "PR-6001",
+		"pull_request_name": "Retry me",
+		"author_id":         testAuthorID,
+	}
+	withKey := map[string]string{"Idempotency-Key": "ci-run-42"}
+
+	first := testSuite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", payload, withKey)
+	require.Equal(t, http.StatusCreated, first.Code)
+	require.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+	replay := testSuite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", payload, withKey)
+	require.Equal(t, http.StatusCreated, replay.Code)
+	require.Equal(t, "true", replay.Header().Get("Idempotent-Replayed"))
+	require.JSONEq(t, first.Body.String(), replay.Body.String())
+	require.NotEmpty(t, first.Header().Get("ETag"))
+	require.Equal(t, first.Header().Get("ETag"), replay.Header().Get("ETag"), "a retried create can still send If-Match")
+
+	withoutKey := testSuite.PerformRequest(t, http.MethodPost, "/pullRequest/create", payload)
+	testSuite.ExpectError(t, withoutKey, http.StatusConflict, "PR_EXISTS")
+
+	payload["pull_request_name"] = "Something else"
+	reused := testSuite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", payload, withKey)
+	testSuite.ExpectError(t, reused, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED")
+}
+
+func TestIdempotency_StoresClientErrors(t *testing.T) {
+	resetTables(t)
+
+	payload := map[string]any{
+		"pull_request_id":   "PR-6101",
+		"pull_request_name": "Ghost",
+		"author_id":         "ghost",
+	}
+	withKey := map[string]string{"Idempotency-Key": "ghost-run"}
+
+	first := testSuite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", payload, withKey)
+	testSuite.ExpectError(t, first, http.StatusNotFound, "NOT_FOUND")
+
+	// The author appears afterwards, but the stored outcome is still replayed.
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().With("ghost", "Casper", true).Build())
+
+	replay := testSuite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", payload, withKey)
+	testSuite.ExpectError(t, replay, http.StatusNotFound, "NOT_FOUND")
+	require.Equal(t, "true", replay.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotency_ExpiryAndInFlightRequests(t *testing.T) {
+	resetTables(t)
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		Build())
+
+	ctx := context.Background()
+	repo := database.NewIdempotencyRepository(testPool, testLogger)
+	now := time.Now().UTC()
+
+	teamPayload := map[string]any{
+		"team_name": "other",
+		"members":   []map[string]any{},
+	}
+	body, err := json.Marshal(teamPayload)
+	require.NoError(t, err)
+	hash := sha256.Sum256(append([]byte("POST /team/add\n"), body...))
+
+	// Simulates another instance that is still handling the same request.
+	reserved, err := repo.Reserve(ctx, &entities.IdempotencyRecord{
+		Owner:       "anonymous",
+		Key:         "in-flight",
+		RequestHash: hex.EncodeToString(hash[:]),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}, now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	rec := testSuite.PerformRequestWithHeaders(t, http.MethodPost, "/team/add", teamPayload, map[string]string{"Idempotency-Key": "in-flight"})
+	testSuite.ExpectError(t, rec, http.StatusConflict, "IDEMPOTENCY_KEY_IN_PROGRESS")
+	require.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	payload := map[string]any{
+		"pull_request_id":   "PR-6201",
+		"pull_request_name": "Expiring",
+		"author_id":         testAuthorID,
+	}
+	withKey := map[string]string{"Idempotency-Key": "short-lived"}
+
+	rec = testSuite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", payload, withKey)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	_, err = testPool.Exec(ctx, `UPDATE idempotency_keys SET expires_at = $1 WHERE idempotency_key = 'short-lived'`, now.Add(-time.Second))
+	require.NoError(t, err)
+
+	rec = testSuite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", payload, withKey)
+	testSuite.ExpectError(t, rec, http.StatusConflict, "PR_EXISTS")
+	require.Empty(t, rec.Header().Get("Idempotent-Replayed"))
+
+	deleted, err := repo.DeleteExpired(ctx, now.Add(48*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), deleted)
+}
@@ -0,0 +1,221 @@
+package tests
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"pr-reviewer-assignment/internal/config"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/jwtauth"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testJWTIssuer   = "https://idp.example.test"
+	testJWTAudience = "pr-reviewer"
+	testJWTKeyID    = "test-key"
+)
+
+type jwtFixture struct {
+	key   *rsa.PrivateKey
+	jwks  []byte
+	suite *helpers.IntegrationSuite
+}
+
+func newJWTFixture(t *testing.T, jwtCfg config.JWTConfig) *jwtFixture {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": testJWTKeyID,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	require.NoError(t, err)
+
+	jwtCfg.JWKSFile = filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwtCfg.JWKSFile, jwks, 0o600))
+
+	jwtCfg.Issuer = testJWTIssuer
+	jwtCfg.Audience = testJWTAudience
+	if jwtCfg.RolesClaim == "" {
+		jwtCfg.RolesClaim = "roles"
+	}
+
+	verifier, err := jwtauth.NewVerifier(context.Background(), jwtCfg, nil, testLogger)
+	require.NoError(t, err)
+
+	router := buildRouter(testPool, routerOptions{
+		auth:     config.AuthConfig{Enabled: true, JWT: jwtCfg},
+		verifier: verifier,
+	})
+
+	return &jwtFixture{key: key, jwks: jwks, suite: helpers.NewIntegrationSuite(router, testPool)}
+}
+
+func (f *jwtFixture) token(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	base := jwt.MapClaims{
+		"iss": testJWTIssuer,
+		"aud": testJWTAudience,
+		"sub": "someone",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for name, value := range claims {
+		base[name] = value
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, base)
+	token.Header["kid"] = testJWTKeyID
+
+	signed, err := token.SignedString(f.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestJWT_RoleEnforcement(t *testing.T) {
+	resetTables(t)
+
+	fixture := newJWTFixture(t, config.JWTConfig{RoleMapping: "idp-admins=admin"})
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build())
+	testSuite.CreateTeam(t, testTeamPlatform, helpers.NewTeamMembersBuilder().
+		With("platform-1", "Pat", true).
+		Build())
+
+	lead := fixture.token(t, jwt.MapClaims{"sub": "lead", "roles": []string{"team-lead:" + testTeamCore}})
+	viewer := fixture.token(t, jwt.MapClaims{"sub": "viewer", "roles": "viewer"})
+	admin := fixture.token(t, jwt.MapClaims{"sub": "admin", "roles": []string{"idp-admins"}})
+
+	setActive := func(token, userID string) *httptest.ResponseRecorder {
+		return fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/users/setIsActive", map[string]any{
+			"user_id":   userID,
+			"is_active": false,
+		}, bearer(token))
+	}
+
+	rec := fixture.suite.PerformRequestWithHeaders(t, http.MethodGet, "/team/get?team_name="+testTeamCore, nil, bearer(viewer))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	fixture.suite.ExpectError(t, setActive(viewer, "reviewer-1"), http.StatusForbidden, "FORBIDDEN")
+	require.Equal(t, http.StatusOK, setActive(lead, "reviewer-1").Code)
+	fixture.suite.ExpectError(t, setActive(lead, "platform-1"), http.StatusForbidden, "FORBIDDEN")
+	require.Equal(t, http.StatusOK, setActive(admin, "platform-1").Code)
+
+	rec = fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/team/add", map[string]any{
+		"team_name": testTeamPlatform,
+		"members":   helpers.NewTeamMembersBuilder().With("platform-2", "Quinn", true).Build(),
+	}, bearer(lead))
+	fixture.suite.ExpectError(t, rec, http.StatusForbidden, "FORBIDDEN")
+}
+
+func TestJWT_RejectsInvalidTokens(t *testing.T) {
+	resetTables(t)
+
+	fixture := newJWTFixture(t, config.JWTConfig{})
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	forged := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss": testJWTIssuer,
+		"aud": testJWTAudience,
+		"sub": "mallory",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	forged.Header["kid"] = testJWTKeyID
+	forgedToken, err := forged.SignedString(otherKey)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name  string
+		token string
+	}{
+		{name: "expired", token: fixture.token(t, jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()})},
+		{name: "wrong issuer", token: fixture.token(t, jwt.MapClaims{"iss": "https://evil.example.test"})},
+		{name: "wrong audience", token: fixture.token(t, jwt.MapClaims{"aud": "someone-else"})},
+		{name: "bad signature", token: forgedToken},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := fixture.suite.PerformRequestWithHeaders(t, http.MethodGet, "/team/get?team_name="+testTeamCore, nil, bearer(tc.token))
+			fixture.suite.ExpectError(t, rec, http.StatusUnauthorized, "UNAUTHORIZED")
+		})
+	}
+}
+
+func TestJWT_LoadsJWKSFromURL(t *testing.T) {
+	resetTables(t)
+
+	var jwks []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(jwks)
+	}))
+	defer server.Close()
+
+	// The fixture generates the key set, so serve it from a file first and
+	// then point a second verifier at the URL.
+	fileFixture := newJWTFixture(t, config.JWTConfig{})
+	jwks = fileFixture.jwks
+
+	verifier, err := jwtauth.NewVerifier(context.Background(), config.JWTConfig{
+		JWKSURL:             server.URL,
+		JWKSRefreshInterval: time.Minute,
+		Issuer:              testJWTIssuer,
+		Audience:            testJWTAudience,
+		RolesClaim:          "roles",
+	}, server.Client(), testLogger)
+	require.NoError(t, err)
+
+	principal, err := verifier.Verify(context.Background(), fileFixture.token(t, jwt.MapClaims{"roles": "viewer"}))
+	require.NoError(t, err)
+	require.Equal(t, "jwt:someone", principal.Subject)
+	require.True(t, principal.HasScope("read"))
+}
+
+func TestJWT_MapsURNRoleClaims(t *testing.T) {
+	fixture := newJWTFixture(t, config.JWTConfig{})
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, fixture.jwks, 0o600))
+
+	verifier, err := jwtauth.NewVerifier(context.Background(), config.JWTConfig{
+		JWKSFile:    jwksFile,
+		Issuer:      testJWTIssuer,
+		Audience:    testJWTAudience,
+		RolesClaim:  "roles",
+		RoleMapping: "urn:org:role:admin=admin,urn:org:role:lead=team-lead",
+	}, nil, testLogger)
+	require.NoError(t, err)
+
+	principal, err := verifier.Verify(context.Background(), fixture.token(t, jwt.MapClaims{
+		"roles": []string{"urn:org:role:admin", "urn:org:role:lead:" + testTeamCore, "urn:org:role:unknown"},
+	}))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []types.Role{types.RoleAdmin, types.RoleTeamLead}, principal.Roles)
+	require.Equal(t, []string{testTeamCore}, principal.LedTeams)
+}
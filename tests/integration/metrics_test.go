@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsEndpoint_ExposesHTTPDatabaseAndDomainMetrics(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+	testSuite.CreatePullRequest(t, "PR-6001", "Observe me", testAuthorID)
+
+	resp := testSuite.PerformRequest(t, http.MethodGet, "/metrics", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Contains(t, resp.Header().Get("Content-Type"), "text/plain")
+
+	body := resp.Body.String()
+	require.Contains(t, body, `pr_reviewer_http_requests_total{method="POST",route="/pullRequest/create",status="201"}`)
+	require.Contains(t, body, `pr_reviewer_http_request_duration_seconds_bucket{method="POST",route="/pullRequest/create",status="201"`)
+	require.Contains(t, body, `pr_reviewer_db_transactions_total{outcome="commit"}`)
+	require.Contains(t, body, "pr_reviewer_db_pool_max_conns")
+	require.Contains(t, body, "pr_reviewer_db_pool_acquired_conns")
+	require.Contains(t, body, "pr_reviewer_reviewers_assigned_total")
+	require.Contains(t, body, "pr_reviewer_reviewer_reassignments_total")
+}
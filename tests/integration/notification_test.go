@@ -0,0 +1,257 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	adapterdb "pr-reviewer-assignment/internal/adapters/output/database"
+	"pr-reviewer-assignment/internal/adapters/output/webhook"
+	"pr-reviewer-assignment/internal/core/services"
+	"pr-reviewer-assignment/internal/dto"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+type chatPayload struct {
+	Text    string `json:"text"`
+	Channel string `json:"channel"`
+}
+
+func newTestNotifier(t *testing.T, settings services.NotificationSettings) *services.NotificationService {
+	t.Helper()
+
+	notifier, err := services.NewNotificationService(
+		adapterdb.NewNotificationRepository(testPool, testLogger),
+		adapterdb.NewUserRepository(testPool, testLogger),
+		webhook.NewHTTPClient(time.Second),
+		testLogger,
+		nil,
+		testWebhookPolicy,
+		settings,
+	)
+	require.NoError(t, err)
+	return notifier
+}
+
+func setChatChannel(t *testing.T, teamName, url, channel string) {
+	t.Helper()
+
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/notifications/channels/set", map[string]any{
+		"team_name":   teamName,
+		"webhook_url": url,
+		"channel":     channel,
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+}
+
+func chatMessages(t *testing.T, receiver *webhookReceiver) []chatPayload {
+	t.Helper()
+
+	received := receiver.snapshot()
+	messages := make([]chatPayload, 0, len(received))
+	for _, hook := range received {
+		var message chatPayload
+		require.NoError(t, json.Unmarshal(hook.body, &message))
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+func TestNotifications_AssignAndReplace(t *testing.T) {
+	resetTables(t)
+	ctx := context.Background()
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", true).
+		With("reviewer-3", "Dana", true).
+		Build())
+	testSuite.CreateTeam(t, testTeamPlatform, helpers.NewTeamMembersBuilder().
+		With("platform-author", "Paul", true).
+		With("platform-reviewer", "Rita", true).
+		Build())
+
+	receiver, server := newWebhookReceiver(t)
+	setChatChannel(t, testTeamCore, server.URL, "#core-reviews")
+
+	notifier := newTestNotifier(t, services.NotificationSettings{
+		PullRequestURL: "https://reviews.example.com/pr/{id}",
+	})
+
+	pr := testSuite.CreatePullRequest(t, "PR-960", "Cache <warm-up>", testAuthorID)
+	testSuite.CreatePullRequest(t, "PR-961", "No channel", "platform-author")
+
+	_, err := newTestRelay(notifier, 100).RelayPending(ctx)
+	require.NoError(t, err)
+
+	sent, err := notifier.SendDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, sent)
+
+	messages := chatMessages(t, receiver)
+	require.Len(t, messages, 1, "assignments of one pull request are batched")
+	require.Equal(t, "#core-reviews", messages[0].Channel)
+
+	lines := strings.Split(messages[0].Text, "\n")
+	require.Equal(t, "2 review updates for core-team:", lines[0])
+	require.Len(t, lines, 3)
+	for _, line := range lines[1:] {
+		require.Contains(t, line, "was asked to review <https://reviews.example.com/pr/PR-960|Cache &lt;warm-up&gt;> by Author")
+	}
+
+	names := map[string]string{"reviewer-1": "Bob", "reviewer-2": "Charlie", "reviewer-3": "Dana"}
+	oldReviewer := pr.AssignedReviewers[0]
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/pullRequest/reassign", map[string]any{
+		"pull_request_id": pr.PullRequestID,
+		"old_user_id":     oldReviewer,
+	})
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var reassigned helpers.ReassignResponse
+	testSuite.DecodeBody(t, resp, &reassigned)
+
+	_, err = newTestRelay(notifier, 100).RelayPending(ctx)
+	require.NoError(t, err)
+
+	sent, err = notifier.SendDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
+	messages = chatMessages(t, receiver)
+	require.Len(t, messages, 2)
+	require.Equal(t,
+		names[reassigned.ReplacedBy]+" replaces "+names[oldReviewer]+" as reviewer of <https://reviews.example.com/pr/PR-960|Cache &lt;warm-up&gt;> by Author",
+		messages[1].Text)
+}
+
+func TestNotifications_BatchWindowTemplatesAndRetries(t *testing.T) {
+	resetTables(t)
+	ctx := context.Background()
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build())
+
+	receiver, server := newWebhookReceiver(t)
+	setChatChannel(t, testTeamCore, server.URL, "")
+
+	_, err := services.NewNotificationService(nil, nil, nil, testLogger, nil, testWebhookPolicy, services.NotificationSettings{
+		AssignedTemplate: "{{.Reviewer",
+	})
+	require.Error(t, err)
+
+	waiting := newTestNotifier(t, services.NotificationSettings{BatchWindow: time.Hour})
+	testSuite.CreatePullRequest(t, "PR-970", "Waits for the batch", testAuthorID)
+
+	_, err = newTestRelay(waiting, 100).RelayPending(ctx)
+	require.NoError(t, err)
+
+	sent, err := waiting.SendDue(ctx)
+	require.NoError(t, err)
+	require.Zero(t, sent)
+	require.Empty(t, receiver.snapshot())
+
+	resetTables(t)
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build())
+	setChatChannel(t, testTeamCore, server.URL, "")
+	receiver.status.Store(http.StatusServiceUnavailable)
+
+	notifier := newTestNotifier(t, services.NotificationSettings{
+		AssignedTemplate: "{{.Reviewer}}: {{.PullRequestID}} {{.Link}}",
+	})
+	testSuite.CreatePullRequest(t, "github:acme/widgets#7", "From GitHub", testAuthorID)
+
+	_, err = newTestRelay(notifier, 100).RelayPending(ctx)
+	require.NoError(t, err)
+
+	for range testWebhookPolicy.MaxAttempts + 1 {
+		sent, err := notifier.SendDue(ctx)
+		require.NoError(t, err)
+		require.Zero(t, sent)
+	}
+
+	messages := chatMessages(t, receiver)
+	require.Len(t, messages, testWebhookPolicy.MaxAttempts)
+	require.Equal(t, "Bob: github:acme/widgets#7 ", messages[0].Text, "links need a configured code host URL")
+	require.Empty(t, messages[0].Channel)
+}
+
+func TestNotifications_Channels(t *testing.T) {
+	resetTables(t)
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		Build())
+
+	setChatChannel(t, testTeamCore, "https://hooks.example.com/services/T0/B0/old", "")
+	setChatChannel(t, testTeamCore, "https://hooks.example.com/services/T0/B0/new", "#core")
+
+	resp := testSuite.PerformRequest(t, http.MethodGet, "/notifications/channels/list", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var list dto.ListChatChannelsResponse
+	testSuite.DecodeBody(t, resp, &list)
+	require.Len(t, list.Channels, 1)
+	require.Equal(t, testTeamCore, list.Channels[0].TeamName)
+	require.Equal(t, "https://hooks.example.com/***", list.Channels[0].WebhookURL)
+	require.Equal(t, "#core", list.Channels[0].Channel)
+
+	resp = testSuite.PerformRequest(t, http.MethodPost, "/notifications/channels/remove", map[string]any{
+		"team_name": testTeamCore,
+	})
+	require.Equal(t, http.StatusNoContent, resp.Code)
+
+	cases := []struct {
+		name       string
+		path       string
+		payload    map[string]any
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "missing url",
+			path:       "/notifications/channels/set",
+			payload:    map[string]any{"team_name": testTeamCore},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "relative url",
+			path:       "/notifications/channels/set",
+			payload:    map[string]any{"team_name": testTeamCore, "webhook_url": "/hooks"},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "unknown team",
+			path:       "/notifications/channels/set",
+			payload:    map[string]any{"team_name": "ghost-team", "webhook_url": "https://hooks.example.com/x"},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+		{
+			name:       "remove missing channel",
+			path:       "/notifications/channels/remove",
+			payload:    map[string]any{"team_name": testTeamCore},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := testSuite.PerformRequest(t, http.MethodPost, tc.path, tc.payload)
+			testSuite.ExpectError(t, resp, tc.wantStatus, tc.wantCode)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	adapterdb "pr-reviewer-assignment/internal/adapters/output/database"
+	"pr-reviewer-assignment/internal/core/domain/entities"
+	"pr-reviewer-assignment/internal/core/domain/types"
+	"pr-reviewer-assignment/internal/core/ports/events"
+	"pr-reviewer-assignment/internal/core/services"
+	"pr-reviewer-assignment/internal/infrastructure/database/postgres"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []*entities.Event
+	failOn map[int64]int
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event *entities.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failOn[event.ID] > 0 {
+		p.failOn[event.ID]--
+		return errors.New("subscriber unavailable")
+	}
+
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *recordingPublisher) types() []types.EventType {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := make([]types.EventType, 0, len(p.events))
+	for _, event := range p.events {
+		result = append(result, event.Type)
+	}
+	return result
+}
+
+func newTestRelay(publisher events.Publisher, batchSize int) *services.OutboxRelay {
+	txManager := postgres.NewTransactionManager(testPool, testLogger, nil, testRetryPolicy)
+	return services.NewOutboxRelay(adapterdb.NewOutboxRepository(testPool, testLogger), publisher, txManager, testLogger, batchSize)
+}
+
+func TestOutbox_RecordsAndRelaysDomainEvents(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", true).
+		With("reviewer-3", "Dana", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+
+	pr := testSuite.CreatePullRequest(t, "PR-800", "Outbox", testAuthorID)
+	oldReviewer := pr.AssignedReviewers[0]
+
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/pullRequest/reassign", map[string]any{
+		"pull_request_id": pr.PullRequestID,
+		"old_user_id":     oldReviewer,
+	})
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	testSuite.MergePullRequest(t, pr.PullRequestID)
+	testSuite.MergePullRequest(t, pr.PullRequestID)
+
+	for range 2 {
+		resp = testSuite.PerformRequest(t, http.MethodPost, "/users/setIsActive", map[string]any{
+			"user_id":   "reviewer-3",
+			"is_active": false,
+		})
+		require.Equal(t, http.StatusOK, resp.Code)
+	}
+
+	publisher := &recordingPublisher{}
+	relay := newTestRelay(publisher, 2)
+
+	published, err := relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 6, published)
+
+	require.Equal(t, []types.EventType{
+		types.EventPullRequestCreated,
+		types.EventReviewerAssigned,
+		types.EventReviewerAssigned,
+		types.EventReviewerReplaced,
+		types.EventPullRequestMerged,
+		types.EventUserDeactivated,
+	}, publisher.types())
+
+	replaced := publisher.events[3]
+	require.Equal(t, testTeamCore, replaced.TeamName)
+	require.Equal(t, pr.PullRequestID, replaced.Data.PullRequestID)
+	require.Equal(t, oldReviewer, replaced.Data.OldReviewerID)
+	require.NotEmpty(t, replaced.Data.ReviewerID)
+	require.Contains(t, replaced.Data.Reviewers, replaced.Data.ReviewerID)
+
+	for i := 1; i < len(publisher.events); i++ {
+		require.Greater(t, publisher.events[i].ID, publisher.events[i-1].ID)
+	}
+
+	published, err = relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, published)
+}
+
+func TestOutbox_FailedPublishBlocksLaterEvents(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+	testSuite.CreatePullRequest(t, "PR-801", "First", testAuthorID)
+	testSuite.CreatePullRequest(t, "PR-802", "Second", testAuthorID)
+
+	var pendingIDs []int64
+	rows, err := testPool.Query(context.Background(), `SELECT id FROM outbox_events ORDER BY id`)
+	require.NoError(t, err)
+	for rows.Next() {
+		var id int64
+		require.NoError(t, rows.Scan(&id))
+		pendingIDs = append(pendingIDs, id)
+	}
+	rows.Close()
+	require.Len(t, pendingIDs, 4)
+
+	publisher := &recordingPublisher{failOn: map[int64]int{pendingIDs[2]: 1}}
+	relay := newTestRelay(publisher, 10)
+
+	published, err := relay.RelayPending(context.Background())
+	require.Error(t, err)
+	require.Equal(t, 2, published)
+
+	published, err = relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, published)
+
+	delivered := make([]int64, 0, len(publisher.events))
+	for _, event := range publisher.events {
+		delivered = append(delivered, event.ID)
+	}
+	require.Equal(t, pendingIDs, delivered)
+}
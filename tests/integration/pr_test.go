@@ -3,6 +3,7 @@ package tests
 import (
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	helpers "pr-reviewer-assignment/tests/shared"
@@ -234,6 +235,76 @@ func TestPullRequestEndpoints_ReassignValidationErrors(t *testing.T) {
 	}
 }
 
+func TestPullRequestEndpoints_OptimisticConcurrency(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", true).
+		With("reviewer-3", "Dana", true).
+		With("reviewer-4", "Eve", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+
+	pr := testSuite.CreatePullRequest(t, "PR-700", "Versioned", testAuthorID)
+	require.Equal(t, 1, pr.Version)
+
+	reassign := func(oldUserID, ifMatch string) *httptest.ResponseRecorder {
+		headers := map[string]string{}
+		if ifMatch != "" {
+			headers["If-Match"] = ifMatch
+		}
+		return testSuite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/reassign", map[string]any{
+			"pull_request_id": pr.PullRequestID,
+			"old_user_id":     oldUserID,
+		}, headers)
+	}
+
+	resp := reassign(pr.AssignedReviewers[0], `"1"`)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, `"2"`, resp.Header().Get("ETag"))
+
+	var updated helpers.ReassignResponse
+	testSuite.DecodeBody(t, resp, &updated)
+	require.Equal(t, 2, updated.PR.Version)
+
+	resp = reassign(updated.PR.AssignedReviewers[0], `"1"`)
+	testSuite.ExpectError(t, resp, http.StatusPreconditionFailed, "CONFLICT")
+
+	resp = reassign(updated.PR.AssignedReviewers[0], "v2")
+	testSuite.ExpectError(t, resp, http.StatusBadRequest, "BAD_REQUEST")
+
+	// Without If-Match both writers race on the same version; the loser is
+	// replayed against the fresh row instead of overwriting the winner.
+	var wg sync.WaitGroup
+	codes := make([]int, len(updated.PR.AssignedReviewers))
+	for i, reviewer := range updated.PR.AssignedReviewers {
+		wg.Add(1)
+		go func(i int, reviewer string) {
+			defer wg.Done()
+			codes[i] = reassign(reviewer, "").Code
+		}(i, reviewer)
+	}
+	wg.Wait()
+
+	for _, code := range codes {
+		require.Equal(t, http.StatusOK, code)
+	}
+
+	resp = testSuite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/merge", map[string]any{
+		"pull_request_id": pr.PullRequestID,
+	}, map[string]string{"If-Match": `W/"4"`})
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, `"5"`, resp.Header().Get("ETag"))
+
+	var merged helpers.PullRequestResponse
+	testSuite.DecodeBody(t, resp, &merged)
+	require.Equal(t, 5, merged.PR.Version)
+	require.Len(t, merged.PR.AssignedReviewers, 2)
+	require.NotEqual(t, merged.PR.AssignedReviewers[0], merged.PR.AssignedReviewers[1])
+}
+
 func contains(list []string, value string) bool {
 	for _, item := range list {
 		if item == value {
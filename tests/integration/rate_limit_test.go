@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"pr-reviewer-assignment/internal/config"
+	"pr-reviewer-assignment/internal/dto"
+	"pr-reviewer-assignment/internal/middleware"
+	"pr-reviewer-assignment/internal/ratelimit"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestRateLimit_PerRouteAndPerClient(t *testing.T) {
+	resetTables(t)
+
+	rules, err := ratelimit.ParseRules("100/s", "POST /pullRequest/create=2/m:2")
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := middleware.NewRateLimiter(ratelimit.NewMemoryStore(clock.Now), rules, testLogger)
+
+	suite := helpers.NewIntegrationSuite(buildRouter(testPool, routerOptions{
+		auth:    config.AuthConfig{Enabled: true, BootstrapKey: testBootstrapKey},
+		limiter: limiter,
+	}), testPool)
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build())
+
+	rec := suite.PerformRequestWithHeaders(t, http.MethodPost, "/admin/apiKeys/create", map[string]any{
+		"name":   "ci",
+		"scopes": []string{"write:pr"},
+		"teams":  []string{testTeamCore},
+	}, bearer(testBootstrapKey))
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var ci dto.CreateAPIKeyResponse
+	suite.DecodeBody(t, rec, &ci)
+
+	create := func(token, prID string) int {
+		rec := suite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", map[string]any{
+			"pull_request_id":   prID,
+			"pull_request_name": "Throttled",
+			"author_id":         testAuthorID,
+		}, bearer(token))
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusCreated, create(ci.Token, "PR-7001"))
+	require.Equal(t, http.StatusCreated, create(ci.Token, "PR-7002"))
+
+	rec = suite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", map[string]any{
+		"pull_request_id":   "PR-7003",
+		"pull_request_name": "Throttled",
+		"author_id":         testAuthorID,
+	}, bearer(ci.Token))
+	suite.ExpectError(t, rec, http.StatusTooManyRequests, "RATE_LIMITED")
+
+	retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+	require.NoError(t, err)
+	require.Equal(t, 30, retryAfter)
+
+	// Other clients and other routes have their own buckets.
+	require.Equal(t, http.StatusCreated, create(testBootstrapKey, "PR-7004"))
+
+	rec = suite.PerformRequestWithHeaders(t, http.MethodGet, "/stats", nil, bearer(testBootstrapKey))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotEmpty(t, rec.Header().Get("X-RateLimit-Remaining"))
+
+	clock.Advance(30 * time.Second)
+	require.Equal(t, http.StatusCreated, create(ci.Token, "PR-7003"))
+	require.Equal(t, http.StatusTooManyRequests, create(ci.Token, "PR-7005"))
+}
+
+func TestRateLimit_AnonymousClientsKeyedByIP(t *testing.T) {
+	resetTables(t)
+
+	rules, err := ratelimit.ParseRules("1/h", "")
+	require.NoError(t, err)
+
+	suite := helpers.NewIntegrationSuite(buildRouter(testPool, routerOptions{
+		limiter: middleware.NewRateLimiter(ratelimit.NewMemoryStore(nil), rules, testLogger),
+	}), testPool)
+
+	get := func(ip string) int {
+		rec := suite.PerformRequestWithHeaders(t, http.MethodGet, "/stats", nil, map[string]string{"X-Forwarded-For": ip})
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusOK, get("198.51.100.1"))
+	require.Equal(t, http.StatusTooManyRequests, get("198.51.100.1"))
+	require.Equal(t, http.StatusOK, get("198.51.100.2"))
+
+	// Health and metrics are never throttled.
+	for range 3 {
+		rec := suite.PerformRequestWithHeaders(t, http.MethodGet, "/health/live", nil, map[string]string{"X-Forwarded-For": "198.51.100.1"})
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestRateLimit_PerIPLimitRunsBeforeAuth(t *testing.T) {
+	resetTables(t)
+
+	rules, err := ratelimit.ParseRules("", "")
+	require.NoError(t, err)
+	perIP, err := ratelimit.ParseLimit("2/h")
+	require.NoError(t, err)
+	rules.PerIP = &perIP
+
+	suite := helpers.NewIntegrationSuite(buildRouter(testPool, routerOptions{
+		auth:    config.AuthConfig{Enabled: true, BootstrapKey: testBootstrapKey},
+		limiter: middleware.NewRateLimiter(ratelimit.NewMemoryStore(nil), rules, testLogger),
+	}), testPool)
+
+	get := func(ip, token string) *httptest.ResponseRecorder {
+		return suite.PerformRequestWithHeaders(t, http.MethodGet, "/stats", nil, map[string]string{
+			"X-Forwarded-For": ip,
+			"Authorization":   "Bearer " + token,
+		})
+	}
+
+	suite.ExpectError(t, get("198.51.100.1", "prk_guess_1"), http.StatusUnauthorized, "UNAUTHORIZED")
+	suite.ExpectError(t, get("198.51.100.1", "prk_guess_2"), http.StatusUnauthorized, "UNAUTHORIZED")
+	suite.ExpectError(t, get("198.51.100.1", testBootstrapKey), http.StatusTooManyRequests, "RATE_LIMITED")
+
+	require.Equal(t, http.StatusOK, get("198.51.100.2", testBootstrapKey).Code)
+}
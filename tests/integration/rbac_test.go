@@ -0,0 +1,222 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"pr-reviewer-assignment/internal/config"
+	"pr-reviewer-assignment/internal/dto"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRBAC_TeamLeadsManageOnlyTheirTeam(t *testing.T) {
+	resetTables(t)
+
+	fixture := newJWTFixture(t, config.JWTConfig{})
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("lead-core", "Lee", true).
+		Build())
+	testSuite.CreateTeam(t, testTeamPlatform, helpers.NewTeamMembersBuilder().
+		With("platform-1", "Pat", true).
+		Build())
+
+	admin := fixture.token(t, jwt.MapClaims{"sub": "root", "roles": "admin"})
+	lead := fixture.token(t, jwt.MapClaims{"sub": "lead-core", "roles": "team-lead"})
+
+	rec := fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/team/setRole", map[string]any{
+		"team_name": testTeamCore,
+		"user_id":   "lead-core",
+		"role":      "team-lead",
+	}, bearer(lead))
+	fixture.suite.ExpectError(t, rec, http.StatusForbidden, "FORBIDDEN")
+
+	rec = fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/team/setRole", map[string]any{
+		"team_name": testTeamCore,
+		"user_id":   "platform-1",
+		"role":      "team-lead",
+	}, bearer(admin))
+	fixture.suite.ExpectError(t, rec, http.StatusNotFound, "NOT_FOUND")
+
+	rec = fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/team/setRole", map[string]any{
+		"team_name": testTeamCore,
+		"user_id":   "lead-core",
+		"role":      "team-lead",
+	}, bearer(admin))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = fixture.suite.PerformRequestWithHeaders(t, http.MethodGet, "/team/roles?team_name="+testTeamCore, nil, bearer(lead))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var roles dto.TeamRolesResponse
+	fixture.suite.DecodeBody(t, rec, &roles)
+	require.Equal(t, []dto.TeamRoleDTO{{TeamName: testTeamCore, UserID: "lead-core", Role: "team-lead"}}, roles.Roles)
+
+	for _, team := range []string{testTeamCore, testTeamPlatform} {
+		rec = fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/notifications/channels/set", map[string]any{
+			"team_name":   team,
+			"webhook_url": "https://hooks.example.com/services/" + team,
+		}, bearer(admin))
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	rec = fixture.suite.PerformRequestWithHeaders(t, http.MethodGet, "/notifications/channels/list", nil, bearer(lead))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var channels dto.ListChatChannelsResponse
+	fixture.suite.DecodeBody(t, rec, &channels)
+	require.Len(t, channels.Channels, 1)
+	require.Equal(t, testTeamCore, channels.Channels[0].TeamName)
+
+	setActive := func(token, userID string) *httptest.ResponseRecorder {
+		return fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/users/setIsActive", map[string]any{
+			"user_id":   userID,
+			"is_active": true,
+		}, bearer(token))
+	}
+
+	require.Equal(t, http.StatusOK, setActive(lead, "reviewer-1").Code)
+	fixture.suite.ExpectError(t, setActive(lead, "platform-1"), http.StatusForbidden, "FORBIDDEN")
+
+	// Pulling a platform member into core needs the platform team as well.
+	rec = fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/team/add", map[string]any{
+		"team_name": "core-spinoff",
+		"members":   helpers.NewTeamMembersBuilder().With("platform-1", "Pat", true).Build(),
+	}, bearer(fixture.token(t, jwt.MapClaims{"sub": "lead-core", "roles": "team-lead:core-spinoff"})))
+	fixture.suite.ExpectError(t, rec, http.StatusForbidden, "FORBIDDEN")
+
+	rec = fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/team/removeRole", map[string]any{
+		"team_name": testTeamCore,
+		"user_id":   "lead-core",
+	}, bearer(admin))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	fixture.suite.ExpectError(t, setActive(lead, "reviewer-1"), http.StatusForbidden, "FORBIDDEN")
+}
+
+func TestRBAC_PullRequestParticipants(t *testing.T) {
+	resetTables(t)
+
+	fixture := newJWTFixture(t, config.JWTConfig{})
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", true).
+		With("reviewer-3", "Dana", true).
+		Build())
+	testSuite.CreateTeam(t, testTeamPlatform, helpers.NewTeamMembersBuilder().
+		With("platform-1", "Pat", true).
+		Build())
+
+	author := fixture.token(t, jwt.MapClaims{"sub": testAuthorID, "roles": "developer"})
+	outsider := fixture.token(t, jwt.MapClaims{"sub": "platform-1", "roles": "developer"})
+	viewer := fixture.token(t, jwt.MapClaims{"sub": testAuthorID, "roles": "viewer"})
+	lead := fixture.token(t, jwt.MapClaims{"sub": "platform-1", "roles": "team-lead:" + testTeamCore})
+
+	createPayload := map[string]any{
+		"pull_request_id":   "PR-9001",
+		"pull_request_name": "Guarded",
+		"author_id":         testAuthorID,
+	}
+
+	rec := fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", createPayload, bearer(outsider))
+	fixture.suite.ExpectError(t, rec, http.StatusForbidden, "FORBIDDEN")
+
+	rec = fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", createPayload, bearer(viewer))
+	fixture.suite.ExpectError(t, rec, http.StatusForbidden, "FORBIDDEN")
+
+	rec = fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", createPayload, bearer(author))
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var created helpers.PullRequestResponse
+	fixture.suite.DecodeBody(t, rec, &created)
+	require.Len(t, created.PR.AssignedReviewers, 2)
+
+	reassign := func(token, reviewerID string) *httptest.ResponseRecorder {
+		return fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/reassign", map[string]any{
+			"pull_request_id": "PR-9001",
+			"old_user_id":     reviewerID,
+		}, bearer(token))
+	}
+
+	fixture.suite.ExpectError(t, reassign(outsider, created.PR.AssignedReviewers[0]), http.StatusForbidden, "FORBIDDEN")
+	require.Equal(t, http.StatusOK, reassign(lead, created.PR.AssignedReviewers[0]).Code)
+
+	var current helpers.PullRequestResponse
+	rec = fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/merge", map[string]any{
+		"pull_request_id": "PR-9001",
+	}, bearer(outsider))
+	fixture.suite.ExpectError(t, rec, http.StatusForbidden, "FORBIDDEN")
+
+	rec = fixture.suite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/merge", map[string]any{
+		"pull_request_id": "PR-9001",
+	}, bearer(author))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	fixture.suite.DecodeBody(t, rec, &current)
+	require.Equal(t, "MERGED", current.PR.Status)
+}
+
+func TestRBAC_APIKeysActOnlyInTheirTeams(t *testing.T) {
+	resetTables(t)
+
+	suite := helpers.NewIntegrationSuite(buildRouter(testPool, routerOptions{
+		auth: config.AuthConfig{Enabled: true, BootstrapKey: testBootstrapKey},
+	}), testPool)
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build())
+	testSuite.CreateTeam(t, testTeamPlatform, helpers.NewTeamMembersBuilder().
+		With("platform-1", "Pat", true).
+		With("platform-2", "Quinn", true).
+		Build())
+
+	createKey := func(teams []string) string {
+		rec := suite.PerformRequestWithHeaders(t, http.MethodPost, "/admin/apiKeys/create", map[string]any{
+			"name":   "ci",
+			"scopes": []string{"write:pr", "admin:teams"},
+			"teams":  teams,
+		}, bearer(testBootstrapKey))
+		require.Equal(t, http.StatusCreated, rec.Code)
+
+		var created dto.CreateAPIKeyResponse
+		suite.DecodeBody(t, rec, &created)
+		require.Equal(t, teams, created.APIKey.Teams)
+		return created.Token
+	}
+
+	coreKey := createKey([]string{testTeamCore})
+	unbound := createKey(nil)
+
+	createPR := func(token, prID, authorID string) *httptest.ResponseRecorder {
+		return suite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", map[string]any{
+			"pull_request_id":   prID,
+			"pull_request_name": "Service account",
+			"author_id":         authorID,
+		}, bearer(token))
+	}
+
+	require.Equal(t, http.StatusCreated, createPR(coreKey, "PR-9101", testAuthorID).Code)
+	suite.ExpectError(t, createPR(coreKey, "PR-9102", "platform-1"), http.StatusForbidden, "FORBIDDEN")
+	suite.ExpectError(t, createPR(unbound, "PR-9103", testAuthorID), http.StatusForbidden, "FORBIDDEN")
+
+	setActive := func(token, userID string) *httptest.ResponseRecorder {
+		return suite.PerformRequestWithHeaders(t, http.MethodPost, "/users/setIsActive", map[string]any{
+			"user_id":   userID,
+			"is_active": true,
+		}, bearer(token))
+	}
+
+	require.Equal(t, http.StatusOK, setActive(coreKey, "reviewer-1").Code)
+	suite.ExpectError(t, setActive(coreKey, "platform-1"), http.StatusForbidden, "FORBIDDEN")
+	suite.ExpectError(t, setActive(unbound, "reviewer-1"), http.StatusForbidden, "FORBIDDEN")
+}
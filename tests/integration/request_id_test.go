@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestID_PropagatesToResponseAndLogs(t *testing.T) {
+	resetTables(t)
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	suite := helpers.NewIntegrationSuite(buildRouter(testPool, routerOptions{logger: zap.New(core)}), testPool)
+
+	const requestID = "req-correlation-1"
+
+	rec := suite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", map[string]any{
+		"pull_request_id":   "PR-8001",
+		"pull_request_name": "Ghost author",
+		"author_id":         "ghost",
+	}, map[string]string{"X-Request-ID": requestID})
+
+	require.Equal(t, requestID, rec.Header().Get("X-Request-ID"))
+	resp := suite.ExpectError(t, rec, http.StatusNotFound, "NOT_FOUND")
+	require.Equal(t, requestID, resp.Error.RequestID)
+
+	messages := make(map[string]bool)
+	for _, entry := range logs.All() {
+		require.Equal(t, requestID, entry.ContextMap()["request_id"], "log %q is missing the request id", entry.Message)
+		messages[entry.Message] = true
+	}
+
+	require.True(t, messages["User not found"], "repository log expected")
+	require.True(t, messages["Failed to load author"], "service log expected")
+	require.True(t, messages["http request"], "access log expected")
+}
+
+func TestRequestID_GeneratedWhenMissingOrInvalid(t *testing.T) {
+	resetTables(t)
+
+	cases := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{name: "missing", headers: nil},
+		{name: "invalid", headers: map[string]string{"X-Request-ID": "has spaces in it"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := testSuite.PerformRequestWithHeaders(t, http.MethodGet, "/team/get?team_name=ghost", nil, tc.headers)
+
+			generated := rec.Header().Get("X-Request-ID")
+			require.Len(t, generated, 32)
+
+			resp := testSuite.ExpectError(t, rec, http.StatusNotFound, "NOT_FOUND")
+			require.Equal(t, generated, resp.Error.RequestID)
+		})
+	}
+}
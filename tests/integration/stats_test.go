@@ -0,0 +1,170 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"pr-reviewer-assignment/internal/dto"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsEndpoints_Fairness(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", true).
+		With("reviewer-3", "Dana", false).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+
+	testSuite.CreatePullRequest(t, "PR-5001", "One", testAuthorID)
+	testSuite.CreatePullRequest(t, "PR-5002", "Two", testAuthorID)
+
+	resp := testSuite.PerformRequest(t, http.MethodGet, "/stats/fairness?team_name="+testTeamCore, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var parsed dto.FairnessResponse
+	testSuite.DecodeBody(t, resp, &parsed)
+
+	report := parsed.Fairness
+	require.Equal(t, testTeamCore, report.TeamName)
+	require.Equal(t, 4, report.TotalAssignments)
+	require.Len(t, report.Members, 3)
+	require.InDelta(t, 1.0/3.0, report.IdealShare, 1e-9)
+	require.InDelta(t, 1.0/3.0, report.Gini, 1e-9)
+	require.NotEmpty(t, report.LargestDeviation)
+	require.Equal(t, testAuthorID, report.LargestDeviation[0].UserID)
+
+	for _, member := range report.Members {
+		require.NotEqual(t, "reviewer-3", member.UserID)
+	}
+
+	resp = testSuite.PerformRequest(t, http.MethodGet, "/stats/fairness?team_name="+testTeamCore+"&from=2000-01-01&to=2000-01-02", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	testSuite.DecodeBody(t, resp, &parsed)
+	require.Zero(t, parsed.Fairness.TotalAssignments)
+	require.Zero(t, parsed.Fairness.Gini)
+}
+
+func TestStatsEndpoints_FairnessErrors(t *testing.T) {
+	resetTables(t)
+
+	cases := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "missing team name",
+			path:       "/stats/fairness",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "invalid from",
+			path:       "/stats/fairness?team_name=" + testTeamCore + "&from=yesterday",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "inverted range",
+			path:       "/stats/fairness?team_name=" + testTeamCore + "&from=2024-02-01&to=2024-01-01",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "unknown team",
+			path:       "/stats/fairness?team_name=ghost-team",
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := testSuite.PerformRequest(t, http.MethodGet, tc.path, nil)
+			testSuite.ExpectError(t, resp, tc.wantStatus, tc.wantCode)
+		})
+	}
+}
+
+func TestStatsEndpoints_History(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+	testSuite.CreateTeam(t, testTeamPlatform, helpers.NewTeamMembersBuilder().
+		With("platform-1", "Dana", true).
+		Build())
+
+	testSuite.CreatePullRequest(t, "PR-5101", "Backlog", testAuthorID)
+	require.NoError(t, testStatsService.TakeSnapshot(context.Background()))
+
+	testSuite.CreatePullRequest(t, "PR-5102", "More backlog", testAuthorID)
+	require.NoError(t, testStatsService.TakeSnapshot(context.Background()))
+
+	resp := testSuite.PerformRequest(t, http.MethodGet, "/stats/history?metric=open_pull_requests&bucket=day", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var history dto.StatsHistoryResponse
+	testSuite.DecodeBody(t, resp, &history)
+	require.Equal(t, "open_pull_requests", history.Metric)
+	require.Equal(t, "day", history.Bucket)
+	require.NotEmpty(t, history.Points)
+
+	last := history.Points[len(history.Points)-1]
+	require.Equal(t, 2, last.Last)
+	require.Equal(t, 2, last.Max)
+
+	resp = testSuite.PerformRequest(t, http.MethodGet, "/stats/history?metric=open_pull_requests&bucket=day&team_name="+testTeamCore, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	testSuite.DecodeBody(t, resp, &history)
+	require.Equal(t, testTeamCore, history.TeamName)
+	require.NotEmpty(t, history.Points)
+	require.Equal(t, 2, history.Points[len(history.Points)-1].Last)
+
+	resp = testSuite.PerformRequest(t, http.MethodGet, "/stats/history?metric=open_pull_requests&bucket=day&team_name="+testTeamPlatform, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	testSuite.DecodeBody(t, resp, &history)
+	require.NotEmpty(t, history.Points)
+	require.Zero(t, history.Points[len(history.Points)-1].Max)
+
+	resp = testSuite.PerformRequest(t, http.MethodGet, "/stats/history?metric=pull_requests&from=2000-01-01&to=2000-01-02", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	testSuite.DecodeBody(t, resp, &history)
+	require.Empty(t, history.Points)
+}
+
+func TestStatsEndpoints_HistoryErrors(t *testing.T) {
+	resetTables(t)
+
+	cases := []struct {
+		name string
+		path string
+	}{
+		{name: "missing metric", path: "/stats/history"},
+		{name: "unknown metric", path: "/stats/history?metric=latency"},
+		{name: "unknown bucket", path: "/stats/history?metric=users&bucket=fortnight"},
+		{name: "team filter on global metric", path: "/stats/history?metric=users&team_name=" + testTeamCore},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := testSuite.PerformRequest(t, http.MethodGet, tc.path, nil)
+			testSuite.ExpectError(t, resp, http.StatusBadRequest, "BAD_REQUEST")
+		})
+	}
+}
@@ -7,15 +7,25 @@ import (
 	"testing"
 	"time"
 
+	adaptergraphql "pr-reviewer-assignment/internal/adapters/input/graphql"
 	adapterhttp "pr-reviewer-assignment/internal/adapters/input/http"
 	adapterdb "pr-reviewer-assignment/internal/adapters/output/database"
+	"pr-reviewer-assignment/internal/adapters/output/webhook"
+	"pr-reviewer-assignment/internal/config"
 	"pr-reviewer-assignment/internal/core/services"
+	"pr-reviewer-assignment/internal/health"
 	"pr-reviewer-assignment/internal/infrastructure"
 	"pr-reviewer-assignment/internal/infrastructure/database/postgres"
+	"pr-reviewer-assignment/internal/metrics"
+	"pr-reviewer-assignment/internal/middleware"
 	helpers "pr-reviewer-assignment/tests/shared"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/zap"
 )
 
@@ -24,17 +34,28 @@ var (
 	testRouter *gin.Engine
 	testSuite  *helpers.IntegrationSuite
 	testLogger = zap.NewNop()
+
+	testStatsService       *services.StatsService
+	testWebhookService     *services.WebhookService
+	testPullRequestService *services.PullRequestService
+	testSpans              = tracetest.NewInMemoryExporter()
 )
 
 const (
 	testTeamCore     = "core-team"
 	testTeamPlatform = "platform-team"
 	testAuthorID     = "author-1"
+
+	testGitHubSecret = "github-webhook-secret"
+	testGitLabToken  = "gitlab-webhook-token"
 )
 
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
 
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(testSpans)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
 	cfg := helpers.LoadTestDBConfig()
 	pool, err := postgres.NewConnection(&cfg, testLogger)
 	if err != nil {
@@ -59,35 +80,126 @@ func TestMain(m *testing.M) {
 	}
 
 	testPool = pool
-	testRouter = buildRouter(pool)
+	testRouter = buildRouter(pool, routerOptions{logger: testLogger})
 	testSuite = helpers.NewIntegrationSuite(testRouter, testPool)
 
 	code := m.Run()
 	os.Exit(code)
 }
 
-func buildRouter(pool *pgxpool.Pool) *gin.Engine {
-	txManager := postgres.NewTransactionManager(pool, testLogger)
-
-	teamRepo := adapterdb.NewTeamRepository(pool, testLogger)
-	userRepo := adapterdb.NewUserRepository(pool, testLogger)
-	prRepo := adapterdb.NewPullRequestRepository(pool, testLogger)
+type routerOptions struct {
+	logger   *zap.Logger
+	auth     config.AuthConfig
+	verifier adapterhttp.TokenVerifier
+	limiter  *middleware.RateLimiter
+}
 
-	teamService := services.NewTeamService(teamRepo, userRepo, testLogger, txManager)
-	userService := services.NewUserService(userRepo, prRepo, testLogger)
-	prService := services.NewPullRequestService(prRepo, userRepo, teamRepo, testLogger, txManager)
-	statsService := services.NewStatsService(teamRepo, userRepo, prRepo)
+func buildRouter(pool *pgxpool.Pool, opts routerOptions) *gin.Engine {
+	logger := opts.logger
+	if logger == nil {
+		logger = testLogger
+	}
 
-	healthHandler := adapterhttp.NewHealthHandler()
-	teamHandler := adapterhttp.NewTeamHandler(teamService, testLogger)
-	userHandler := adapterhttp.NewUserHandler(userService, testLogger)
-	prHandler := adapterhttp.NewPullRequestHandler(prService, testLogger)
-	statsHandler := adapterhttp.NewStatsHandler(statsService, testLogger)
+	appMetrics := metrics.New()
+	appMetrics.RegisterPool(pool)
+
+	txManager := postgres.NewTransactionManager(pool, logger, appMetrics, testRetryPolicy)
+
+	teamRepo := adapterdb.NewTeamRepository(pool, logger)
+	userRepo := adapterdb.NewUserRepository(pool, logger)
+	prRepo := adapterdb.NewPullRequestRepository(pool, logger)
+	statsRepo := adapterdb.NewStatsRepository(pool, logger)
+	teamRoleRepo := adapterdb.NewTeamRoleRepository(pool, logger)
+	outboxRepo := adapterdb.NewOutboxRepository(pool, logger)
+	apiKeyRepo := adapterdb.NewAPIKeyRepository(pool, logger)
+	webhookRepo := adapterdb.NewWebhookRepository(pool, logger)
+	externalAccountRepo := adapterdb.NewExternalAccountRepository(pool, logger)
+	notificationRepo := adapterdb.NewNotificationRepository(pool, logger)
+	escalationRepo := adapterdb.NewEscalationRepository(pool, logger)
+
+	authz := services.NewAuthorizer(teamRoleRepo)
+
+	teamService := services.NewTeamService(teamRepo, userRepo, teamRoleRepo, logger, txManager, authz)
+	userService := services.NewUserService(userRepo, prRepo, outboxRepo, logger, txManager, authz)
+	prService := services.NewPullRequestService(prRepo, userRepo, teamRepo, outboxRepo, logger, txManager, nil, appMetrics, authz)
+	testPullRequestService = prService
+	statsService := services.NewStatsService(teamRepo, userRepo, prRepo, statsRepo, txManager)
+	testStatsService = statsService
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, logger, opts.auth.BootstrapKey)
+	webhookService := services.NewWebhookService(webhookRepo, webhook.NewHTTPClient(time.Second), logger, testWebhookPolicy)
+	testWebhookService = webhookService
+	integrationService := services.NewIntegrationService(externalAccountRepo, prRepo, prService, logger)
+	notificationService, err := services.NewNotificationService(notificationRepo, userRepo, webhook.NewHTTPClient(time.Second), logger, authz, testWebhookPolicy, services.NotificationSettings{})
+	if err != nil {
+		panic(err)
+	}
+	escalationService := services.NewEscalationService(escalationRepo, prService, notificationService, txManager, nil, logger, authz, 0)
+
+	healthHandler := adapterhttp.NewHealthHandler(health.NewChecker(time.Second,
+		postgres.NewPingCheck(pool),
+		postgres.NewMigrationCheck(pool, postgres.SchemaVersion),
+		postgres.NewPoolSaturationCheck(pool, 0),
+	))
+	teamHandler := adapterhttp.NewTeamHandler(teamService, logger)
+	userHandler := adapterhttp.NewUserHandler(userService, logger)
+	prHandler := adapterhttp.NewPullRequestHandler(prService, logger)
+	statsHandler := adapterhttp.NewStatsHandler(statsService, logger)
+	apiKeyHandler := adapterhttp.NewAPIKeyHandler(apiKeyService, logger)
+	webhookHandler := adapterhttp.NewWebhookHandler(webhookService, logger)
+	integrationHandler := adapterhttp.NewIntegrationHandler(integrationService, logger)
+	githubHandler := adapterhttp.NewGitHubWebhookHandler(integrationService, testGitHubSecret, logger)
+	gitlabHandler := adapterhttp.NewGitLabWebhookHandler(integrationService, testGitLabToken, logger)
+	notificationHandler := adapterhttp.NewNotificationHandler(notificationService, logger)
+	escalationHandler := adapterhttp.NewEscalationHandler(escalationService, logger)
+	eventStreamHandler := adapterhttp.NewEventStreamHandler(
+		services.NewEventStreamService(outboxRepo, teamRepo, userRepo, logger, 0), time.Second, logger)
+	graphqlHandler, err := adaptergraphql.NewHandler(
+		services.NewDashboardService(teamRepo, userRepo, prRepo), statsService, testGraphQLLimits, logger)
+	if err != nil {
+		panic(err)
+	}
 
-	return infrastructure.NewRouter(testLogger, healthHandler, teamHandler, userHandler, prHandler, statsHandler)
+	return infrastructure.NewRouter(infrastructure.RouterDeps{
+		Logger:      logger,
+		Metrics:     appMetrics,
+		Auth:        adapterhttp.NewAuthenticator(apiKeyService, opts.verifier, opts.auth.Enabled, logger),
+		Limiter:     opts.limiter,
+		Idempotency: middleware.NewIdempotency(adapterdb.NewIdempotencyRepository(pool, logger), time.Hour, time.Minute, logger),
+		Health:      healthHandler,
+		Team:        teamHandler,
+		User:        userHandler,
+		PullRequest: prHandler,
+		Stats:       statsHandler,
+		APIKeys:     apiKeyHandler,
+		Webhooks:    webhookHandler,
+		Integration: integrationHandler,
+		GitHub:      githubHandler,
+		GitLab:      gitlabHandler,
+
+		Notifications: notificationHandler,
+		Escalations:   escalationHandler,
+		Events:        eventStreamHandler,
+		GraphQL:       graphqlHandler,
+	})
 }
 
 func resetTables(t testing.TB) {
 	t.Helper()
 	testSuite.ResetTables(t)
 }
+
+var testRetryPolicy = postgres.RetryPolicy{
+	MaxRetries: 10,
+	BaseDelay:  time.Millisecond,
+	MaxDelay:   50 * time.Millisecond,
+}
+
+var testGraphQLLimits = adaptergraphql.Limits{MaxDepth: 6, MaxCost: 25000}
+
+// testWebhookPolicy retries immediately so a test can drive a delivery to
+// the dead-letter state by calling DeliverDue repeatedly.
+var testWebhookPolicy = services.DeliveryPolicy{
+	MaxAttempts: 3,
+	Lease:       time.Minute,
+	BatchSize:   50,
+}
@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracing_CreatePullRequestContinuesIncomingTrace(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+
+	testSpans.Reset()
+
+	const (
+		traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+		traceparent = "00-" + traceID + "-00f067aa0ba902b7-01"
+	)
+
+	resp := testSuite.PerformRequestWithHeaders(t, http.MethodPost, "/pullRequest/create", map[string]any{
+		"pull_request_id":   "PR-7001",
+		"pull_request_name": "Trace me",
+		"author_id":         testAuthorID,
+	}, map[string]string{"traceparent": traceparent})
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	spans := testSpans.GetSpans()
+	require.NotEmpty(t, spans)
+
+	names := make(map[string]int)
+	for _, span := range spans {
+		require.Equal(t, traceID, span.SpanContext.TraceID().String())
+		names[span.Name]++
+	}
+
+	require.Equal(t, 1, names["POST /pullRequest/create"])
+	require.Equal(t, 1, names["PullRequestService.CreatePullRequest"])
+	require.Equal(t, 1, names["TransactionManager.WithinTransaction"])
+	require.GreaterOrEqual(t, names["db.query"], 3)
+}
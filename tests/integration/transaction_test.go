@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	adapterdb "pr-reviewer-assignment/internal/adapters/output/database"
+	"pr-reviewer-assignment/internal/core/ports/transactions"
+	"pr-reviewer-assignment/internal/infrastructure/database/postgres"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionManager_RetriesSerializationFailures(t *testing.T) {
+	resetTables(t)
+
+	txManager := postgres.NewTransactionManager(testPool, testLogger, nil, testRetryPolicy)
+	ctx := context.Background()
+
+	// Both transactions count the teams and then insert one: a write skew
+	// SERIALIZABLE has to break by aborting one of them.
+	var (
+		ready    sync.WaitGroup
+		attempts atomic.Int32
+		wg       sync.WaitGroup
+		errs     = make([]error, 2)
+	)
+	ready.Add(2)
+
+	for i, name := range []string{"skew-a", "skew-b"} {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+
+			first := true
+			errs[i] = txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+				attempts.Add(1)
+				db := adapterdb.DBFromContext(txCtx)
+
+				var count int
+				if err := db.QueryRow(txCtx, `SELECT COUNT(*) FROM teams`).Scan(&count); err != nil {
+					return err
+				}
+
+				if first {
+					first = false
+					ready.Done()
+					ready.Wait()
+				}
+
+				_, err := db.Exec(txCtx, `INSERT INTO teams (team_name) VALUES ($1)`, name)
+				return err
+			}, transactions.WithIsolation(transactions.IsolationSerializable))
+		}(i, name)
+	}
+	wg.Wait()
+
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	require.Greater(t, attempts.Load(), int32(2))
+
+	var teams int
+	require.NoError(t, testPool.QueryRow(ctx, `SELECT COUNT(*) FROM teams`).Scan(&teams))
+	require.Equal(t, 2, teams)
+}
+
+func TestTransactionManager_ReadOnly(t *testing.T) {
+	resetTables(t)
+
+	txManager := postgres.NewTransactionManager(testPool, testLogger, nil, testRetryPolicy)
+
+	err := txManager.WithinTransaction(context.Background(), func(txCtx context.Context) error {
+		_, err := adapterdb.DBFromContext(txCtx).Exec(txCtx, `INSERT INTO teams (team_name) VALUES ('read-only')`)
+		return err
+	}, transactions.ReadOnly())
+	require.Error(t, err)
+
+	var teams int
+	require.NoError(t, testPool.QueryRow(context.Background(), `SELECT COUNT(*) FROM teams`).Scan(&teams))
+	require.Zero(t, teams)
+}
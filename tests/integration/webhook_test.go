@@ -0,0 +1,337 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	adapterdb "pr-reviewer-assignment/internal/adapters/output/database"
+	"pr-reviewer-assignment/internal/adapters/output/webhook"
+	"pr-reviewer-assignment/internal/core/services"
+	"pr-reviewer-assignment/internal/dto"
+	helpers "pr-reviewer-assignment/tests/shared"
+
+	"github.com/stretchr/testify/require"
+)
+
+type receivedWebhook struct {
+	signature string
+	event     string
+	body      []byte
+}
+
+type webhookReceiver struct {
+	mu       sync.Mutex
+	received []receivedWebhook
+	status   atomic.Int32
+}
+
+func newWebhookReceiver(t *testing.T) (*webhookReceiver, *httptest.Server) {
+	t.Helper()
+
+	receiver := &webhookReceiver{}
+	receiver.status.Store(http.StatusOK)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		receiver.mu.Lock()
+		receiver.received = append(receiver.received, receivedWebhook{
+			signature: r.Header.Get(services.SignatureHeader),
+			event:     r.Header.Get("X-Webhook-Event"),
+			body:      body,
+		})
+		receiver.mu.Unlock()
+
+		w.WriteHeader(int(receiver.status.Load()))
+	}))
+	t.Cleanup(server.Close)
+
+	return receiver, server
+}
+
+func (r *webhookReceiver) snapshot() []receivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedWebhook(nil), r.received...)
+}
+
+func createWebhook(t *testing.T, payload map[string]any) dto.CreateWebhookResponse {
+	t.Helper()
+
+	resp := testSuite.PerformRequest(t, http.MethodPost, "/webhooks/create", payload)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var created dto.CreateWebhookResponse
+	testSuite.DecodeBody(t, resp, &created)
+	return created
+}
+
+func TestWebhooks_DeliversSignedEvents(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		With("reviewer-2", "Charlie", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+	testSuite.CreateTeam(t, testTeamPlatform, helpers.NewTeamMembersBuilder().
+		With("platform-author", "Paul", true).
+		With("platform-reviewer", "Rita", true).
+		Build())
+
+	receiver, server := newWebhookReceiver(t)
+	created := createWebhook(t, map[string]any{
+		"url":       server.URL,
+		"secret":    "top-secret",
+		"events":    []string{"pull_request.created", "pull_request.merged"},
+		"team_name": testTeamCore,
+	})
+	require.Equal(t, "top-secret", created.Secret)
+	require.Equal(t, testTeamCore, created.Webhook.TeamName)
+
+	pr := testSuite.CreatePullRequest(t, "PR-900", "Hooks", testAuthorID)
+	testSuite.MergePullRequest(t, pr.PullRequestID)
+	testSuite.CreatePullRequest(t, "PR-901", "Other team", "platform-author")
+
+	_, err := newTestRelay(testWebhookService, 100).RelayPending(context.Background())
+	require.NoError(t, err)
+
+	delivered, err := testWebhookService.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, delivered)
+
+	received := receiver.snapshot()
+	require.Len(t, received, 2)
+
+	eventTypes := make([]string, 0, len(received))
+	for _, hook := range received {
+		require.Equal(t, services.Sign("top-secret", hook.body), hook.signature)
+
+		var event dto.EventDTO
+		require.NoError(t, json.Unmarshal(hook.body, &event))
+		require.Equal(t, hook.event, event.EventType)
+		require.Equal(t, testTeamCore, event.TeamName)
+		require.NotNil(t, event.PullRequest)
+		require.Equal(t, pr.PullRequestID, event.PullRequest.PullRequestID)
+		eventTypes = append(eventTypes, event.EventType)
+	}
+	require.ElementsMatch(t, []string{"pull_request.created", "pull_request.merged"}, eventTypes)
+
+	delivered, err = testWebhookService.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, delivered)
+
+	resp := testSuite.PerformRequest(t, http.MethodGet, "/webhooks/deliveries?status=delivered&webhook_id="+created.Webhook.WebhookID, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var deliveries dto.ListWebhookDeliveriesResponse
+	testSuite.DecodeBody(t, resp, &deliveries)
+	require.Len(t, deliveries.Deliveries, 2)
+	for _, delivery := range deliveries.Deliveries {
+		require.Equal(t, 1, delivery.Attempts)
+		require.NotNil(t, delivery.DeliveredAt)
+	}
+}
+
+func TestWebhooks_DeadLetterAndRedeliver(t *testing.T) {
+	resetTables(t)
+
+	members := helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build()
+	testSuite.CreateTeam(t, testTeamCore, members)
+
+	receiver, server := newWebhookReceiver(t)
+	receiver.status.Store(http.StatusServiceUnavailable)
+
+	created := createWebhook(t, map[string]any{
+		"url":    server.URL,
+		"events": []string{"pull_request.created"},
+	})
+	require.NotEmpty(t, created.Secret)
+
+	testSuite.CreatePullRequest(t, "PR-910", "Flaky receiver", testAuthorID)
+
+	_, err := newTestRelay(testWebhookService, 100).RelayPending(context.Background())
+	require.NoError(t, err)
+
+	for range testWebhookPolicy.MaxAttempts + 1 {
+		delivered, err := testWebhookService.DeliverDue(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, delivered)
+	}
+	require.Len(t, receiver.snapshot(), testWebhookPolicy.MaxAttempts)
+
+	resp := testSuite.PerformRequest(t, http.MethodGet, "/webhooks/deliveries?status=dead", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var deliveries dto.ListWebhookDeliveriesResponse
+	testSuite.DecodeBody(t, resp, &deliveries)
+	require.Len(t, deliveries.Deliveries, 1)
+
+	dead := deliveries.Deliveries[0]
+	require.Equal(t, testWebhookPolicy.MaxAttempts, dead.Attempts)
+	require.NotNil(t, dead.LastStatusCode)
+	require.Equal(t, http.StatusServiceUnavailable, *dead.LastStatusCode)
+	require.NotEmpty(t, dead.LastError)
+
+	receiver.status.Store(http.StatusNoContent)
+
+	resp = testSuite.PerformRequest(t, http.MethodPost, "/webhooks/redeliver", map[string]any{
+		"delivery_id": dead.DeliveryID,
+	})
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var requeued dto.WebhookDeliveryResponse
+	testSuite.DecodeBody(t, resp, &requeued)
+	require.Equal(t, "pending", requeued.Delivery.Status)
+	require.Zero(t, requeued.Delivery.Attempts)
+
+	delivered, err := testWebhookService.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, delivered)
+
+	received := receiver.snapshot()
+	last := received[len(received)-1]
+	require.Equal(t, services.Sign(created.Secret, last.body), last.signature)
+
+	resp = testSuite.PerformRequest(t, http.MethodPost, "/webhooks/delete", map[string]any{
+		"webhook_id": created.Webhook.WebhookID,
+	})
+	require.Equal(t, http.StatusNoContent, resp.Code)
+
+	resp = testSuite.PerformRequest(t, http.MethodGet, "/webhooks/list", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var list dto.ListWebhooksResponse
+	testSuite.DecodeBody(t, resp, &list)
+	require.Empty(t, list.Webhooks)
+}
+
+func TestWebhooks_BackoffDoublesAfterEachFailure(t *testing.T) {
+	resetTables(t)
+	ctx := context.Background()
+
+	testSuite.CreateTeam(t, testTeamCore, helpers.NewTeamMembersBuilder().
+		With(testAuthorID, "Author", true).
+		With("reviewer-1", "Bob", true).
+		Build())
+
+	receiver, server := newWebhookReceiver(t)
+	receiver.status.Store(http.StatusServiceUnavailable)
+	createWebhook(t, map[string]any{
+		"url":    server.URL,
+		"events": []string{"pull_request.created"},
+	})
+
+	policy := testWebhookPolicy
+	policy.MaxAttempts = 5
+	policy.BaseBackoff = time.Hour
+	policy.MaxBackoff = 24 * time.Hour
+	webhooks := services.NewWebhookService(adapterdb.NewWebhookRepository(testPool, testLogger), webhook.NewHTTPClient(time.Second), testLogger, policy)
+
+	testSuite.CreatePullRequest(t, "PR-915", "Backoff", testAuthorID)
+	_, err := newTestRelay(webhooks, 100).RelayPending(ctx)
+	require.NoError(t, err)
+
+	for attempt, wait := range []time.Duration{time.Hour, 2 * time.Hour} {
+		before := time.Now().UTC()
+		_, err := testPool.Exec(ctx, `UPDATE webhook_deliveries SET next_attempt_at = $1`, before.Add(-time.Second))
+		require.NoError(t, err)
+
+		delivered, err := webhooks.DeliverDue(ctx)
+		require.NoError(t, err)
+		require.Zero(t, delivered)
+
+		var attempts int
+		var nextAttemptAt time.Time
+		require.NoError(t, testPool.QueryRow(ctx, `SELECT attempts, next_attempt_at FROM webhook_deliveries`).Scan(&attempts, &nextAttemptAt))
+		require.Equal(t, attempt+1, attempts)
+		require.WithinDuration(t, before.Add(wait), nextAttemptAt, time.Minute, "wait after failure %d", attempts)
+	}
+}
+
+func TestWebhooks_ValidationErrors(t *testing.T) {
+	resetTables(t)
+
+	cases := []struct {
+		name       string
+		method     string
+		path       string
+		payload    any
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "missing url",
+			method:     http.MethodPost,
+			path:       "/webhooks/create",
+			payload:    map[string]any{"events": []string{"pull_request.created"}},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "relative url",
+			method:     http.MethodPost,
+			path:       "/webhooks/create",
+			payload:    map[string]any{"url": "/hooks"},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "unknown event",
+			method:     http.MethodPost,
+			path:       "/webhooks/create",
+			payload:    map[string]any{"url": "https://example.com/hook", "events": []string{"pull_request.deleted"}},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "unknown team",
+			method:     http.MethodPost,
+			path:       "/webhooks/create",
+			payload:    map[string]any{"url": "https://example.com/hook", "team_name": "ghost-team"},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+		{
+			name:       "delete unknown webhook",
+			method:     http.MethodPost,
+			path:       "/webhooks/delete",
+			payload:    map[string]any{"webhook_id": "missing"},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+		{
+			name:       "redeliver unknown delivery",
+			method:     http.MethodPost,
+			path:       "/webhooks/redeliver",
+			payload:    map[string]any{"delivery_id": 424242},
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+		{
+			name:       "invalid status filter",
+			method:     http.MethodGet,
+			path:       "/webhooks/deliveries?status=lost",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := testSuite.PerformRequest(t, tc.method, tc.path, tc.payload)
+			testSuite.ExpectError(t, resp, tc.wantStatus, tc.wantCode)
+		})
+	}
+}
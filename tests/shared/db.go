@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -34,7 +35,39 @@ func ResetSchema(ctx context.Context, pool *pgxpool.Pool, migrationsDir string)
 		return err
 	}
 	_, _ = pool.Exec(ctx, `DROP TYPE IF EXISTS pr_status_enum`)
-	return runSQLFile(ctx, pool, migrationsDir, "001_init.up.sql")
+
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	var version int
+	for _, file := range files {
+		name := filepath.Base(file)
+		if err := runSQLFile(ctx, pool, migrationsDir, name); err != nil {
+			return err
+		}
+
+		if prefix, _, ok := strings.Cut(name, "_"); ok {
+			if parsed, err := strconv.Atoi(prefix); err == nil {
+				version = parsed
+			}
+		}
+	}
+
+	return recordSchemaVersion(ctx, pool, version)
+}
+
+// recordSchemaVersion mirrors the bookkeeping table golang-migrate maintains, so
+// readiness checks behave as they do against a migrated database.
+func recordSchemaVersion(ctx context.Context, pool *pgxpool.Pool, version int) error {
+	if _, err := pool.Exec(ctx, `CREATE TABLE schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`); err != nil {
+		return err
+	}
+
+	_, err := pool.Exec(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, version)
+	return err
 }
 
 type DBLock struct {
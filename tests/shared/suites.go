@@ -43,6 +43,19 @@ func (s *IntegrationSuite) PerformRequest(t testing.TB, method, path string, pay
 	return rec
 }
 
+func (s *IntegrationSuite) PerformRequestWithHeaders(t testing.TB, method, path string, payload any, headers map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := newRequest(t, method, path, payload)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	return rec
+}
+
 func (s *IntegrationSuite) PerformRawRequest(t testing.TB, method, path, rawBody, contentType string) *httptest.ResponseRecorder {
 	t.Helper()
 
@@ -283,7 +296,7 @@ func resetTables(t testing.TB, pool *pgxpool.Pool) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	const truncateTablesSQL = `TRUNCATE TABLE pr_reviewers, pull_requests, users, teams RESTART IDENTITY CASCADE`
+	const truncateTablesSQL = `TRUNCATE TABLE review_digests, review_escalations, review_slas, chat_notifications, chat_channels, code_host_sync_jobs, external_accounts, webhook_deliveries, webhook_subscriptions, outbox_events, idempotency_keys, team_roles, api_keys, stats_snapshots, pr_reviewers, pull_requests, users, teams RESTART IDENTITY CASCADE`
 	_, err := pool.Exec(ctx, truncateTablesSQL)
 	require.NoError(t, err)
 }